@@ -36,7 +36,7 @@ build-controller: ${SRC} compile ## Builds the controller Go binary.
 K8S_RUN_PRE_TARGETS ?=
 .PHONY: run
 run: generate-deepcopy $(K8S_RUN_PRE_TARGETS) ## Run a controller from your host.
-	go run -ldflags "-X main.Version=$(VERSION)" ./main.go
+	go run -ldflags "-X main.Version=$(VERSION)" .
 
 ##@ K8s - Integration test with envtest
 
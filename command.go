@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const runCommandName = "run"
+
+// command is a subcommand of the service discovery binary.
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+func getCommands() []command {
+	return []command{
+		{name: runCommandName, description: "Runs the service discovery in the cluster (default).", run: runManager},
+		{name: renderCommandName, description: "Renders the routing objects of a manifest directory without a cluster.", run: runRender},
+	}
+}
+
+// execute runs the subcommand named by the first argument. Without a subcommand, e.g., if the arguments start with a
+// flag, the service discovery is run in the cluster.
+func execute(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	name := runCommandName
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	for _, cmd := range getCommands() {
+		if cmd.name == name {
+			return cmd.run(ctx, args, stdout, stderr)
+		}
+	}
+
+	printCommandUsage(stderr)
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func printCommandUsage(writer io.Writer) {
+	_, _ = fmt.Fprintln(writer, "Usage: k8s-service-discovery <command> [flags]")
+	_, _ = fmt.Fprintln(writer, "\nCommands:")
+	for _, cmd := range getCommands() {
+		_, _ = fmt.Fprintf(writer, "  %-8s %s\n", cmd.name, cmd.description)
+	}
+	_, _ = fmt.Fprintln(writer, "\nUse \"k8s-service-discovery <command> -h\" for the flags of a command.")
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_execute(t *testing.T) {
+	t.Run("should run render command", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		err := execute(context.Background(), []string{"render", "controllers/testdata/render/dogus/manifests"}, &stdout, &stderr)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "kind: Ingress")
+	})
+	t.Run("should run manager for flags without command", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		err := execute(context.Background(), []string{"-h"}, &stdout, &stderr)
+
+		// then
+		require.ErrorIs(t, err, flag.ErrHelp)
+		assert.Contains(t, stderr.String(), "Usage: k8s-service-discovery run [flags]")
+	})
+	t.Run("should fail on unknown command", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		err := execute(context.Background(), []string{"serve"}, &stdout, &stderr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown command \"serve\"")
+		assert.Contains(t, stderr.String(), "render   Renders the routing objects")
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/gatewayapi"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -17,6 +19,12 @@ const (
 	// networkPolicyCIDREnvVar define the ip range which is allowed to access the ingress controller if networkpolicies are enabled.
 	networkPolicyCIDREnvVar    = "NETWORK_POLICIES_CIDR"
 	networkPolicyEnabledEnvVar = "NETWORK_POLICIES_ENABLED"
+
+	// gatewayNameEnvVar defines the name of the gateway the routes are attached to if the gateway api is used.
+	gatewayNameEnvVar = "GATEWAY_NAME"
+
+	// traefikRoutingModeEnvVar defines whether the dogus are routed via ingress objects or traefik ingress routes.
+	traefikRoutingModeEnvVar = "TRAEFIK_ROUTING_MODE"
 )
 
 var (
@@ -28,6 +36,26 @@ func ReadIngressController() string {
 	return envIngressController
 }
 
+func ReadGatewayName() string {
+	gatewayName := os.Getenv(gatewayNameEnvVar)
+	if gatewayName == "" {
+		return gatewayapi.DefaultGatewayName
+	}
+	logger.Info(fmt.Sprintf("found gateway name: [%s]", gatewayName))
+
+	return gatewayName
+}
+
+func ReadTraefikRoutingMode() string {
+	routingMode := os.Getenv(traefikRoutingModeEnvVar)
+	if routingMode == "" {
+		return expose.TraefikRoutingModeIngress
+	}
+	logger.Info(fmt.Sprintf("found traefik routing mode: [%s]", routingMode))
+
+	return routingMode
+}
+
 func ReadWatchNamespace() (string, error) {
 	watchNamespace, found := os.LookupEnv(namespaceEnvVar)
 	if !found {
@@ -0,0 +1,26 @@
+package controllers
+
+// Names of the controllers of the service discovery. They identify the controllers in logs and metrics and are used
+// to configure the maximum number of concurrent reconciles per controller.
+const (
+	ServiceControllerName              = "service"
+	DeploymentControllerName           = "deployment"
+	EcosystemCertificateControllerName = "secret"
+	RedirectControllerName             = "redirect"
+	LoadBalancerControllerName         = "loadbalancer-configmap"
+	MaintenanceControllerName          = "maintenance"
+	FQDNControllerName                 = "fqdn"
+)
+
+// ControllerNames returns the names of all controllers of the service discovery.
+func ControllerNames() []string {
+	return []string{
+		ServiceControllerName,
+		DeploymentControllerName,
+		EcosystemCertificateControllerName,
+		RedirectControllerName,
+		LoadBalancerControllerName,
+		MaintenanceControllerName,
+		FQDNControllerName,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 // deploymentReconciler watches every Deployment object in the cluster and creates ingress objects when the ready state
@@ -29,7 +30,8 @@ func NewDeploymentReconciler(client client.Client, updater IngressUpdater) *depl
 // move the current state of the cluster closer to the desired state.
 //
 // The deploymentReconciler is responsible to regenerate ingress objects for respective dogus containing the ces service
-// discovery annotation when their state switches between ready <-> not ready.
+// discovery annotation when their state switches between ready <-> not ready. It also advances the canary of an
+// upgraded dogu.
 func (r *deploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -50,18 +52,26 @@ func (r *deploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, fmt.Errorf("failed to find service for deployment [%s]: %w", deployment.Name, err)
 	}
 
+	requeueAfter, err := r.updater.UpdateCanary(ctx, doguService, deployment)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update canary of service [%s]: %w", doguService.Name, err)
+	}
+
 	err = r.updater.UpsertIngressForService(ctx, doguService)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to create/update ingress object of service [%s]: %w", doguService.Name, err)
 	}
 
-	return ctrl.Result{}, nil
+	// the next step of a canary is due without any change of the deployment
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *deploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *deploymentReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1.Deployment{}).
+		Named(DeploymentControllerName).
+		WithOptions(options).
 		Complete(r)
 }
 
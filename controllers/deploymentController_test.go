@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"testing"
+	"time"
 
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -126,6 +127,7 @@ func Test_deploymentReconciler_Reconcile(t *testing.T) {
 		}
 		clientMock := testclient.NewClientBuilder().WithScheme(getScheme()).WithObjects(deployment, service).Build()
 		ingressUpdaterMock := NewMockIngressUpdater(t)
+		ingressUpdaterMock.EXPECT().UpdateCanary(testCtx, service, mock.Anything).Return(0, nil)
 		ingressUpdaterMock.EXPECT().UpsertIngressForService(testCtx, service).Return(assert.AnError)
 
 		sut := NewDeploymentReconciler(clientMock, ingressUpdaterMock)
@@ -138,4 +140,58 @@ func Test_deploymentReconciler_Reconcile(t *testing.T) {
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "failed to create/update ingress object of service [my-dogu]: assert.AnError general error for testing")
 	})
+
+	t.Run("should fail to update the canary", func(t *testing.T) {
+		// given
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-dogu",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"dogu.name": "my-dogu"},
+		}}
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-dogu", Namespace: testNamespace},
+		}
+		clientMock := testclient.NewClientBuilder().WithScheme(getScheme()).WithObjects(deployment, service).Build()
+		ingressUpdaterMock := NewMockIngressUpdater(t)
+		ingressUpdaterMock.EXPECT().UpdateCanary(testCtx, service, mock.Anything).Return(0, assert.AnError)
+
+		sut := NewDeploymentReconciler(clientMock, ingressUpdaterMock)
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-dogu", Namespace: testNamespace}}
+
+		// when
+		_, err := sut.Reconcile(testCtx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to update canary of service [my-dogu]")
+	})
+
+	t.Run("should requeue until the next step of the canary is due", func(t *testing.T) {
+		// given
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-dogu",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"dogu.name": "my-dogu"},
+		}}
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-dogu", Namespace: testNamespace},
+		}
+		clientMock := testclient.NewClientBuilder().WithScheme(getScheme()).WithObjects(deployment, service).Build()
+		ingressUpdaterMock := NewMockIngressUpdater(t)
+		ingressUpdaterMock.EXPECT().UpdateCanary(testCtx, service, mock.MatchedBy(func(actual *appsv1.Deployment) bool {
+			return actual.Name == "my-dogu"
+		})).Return(5*time.Minute, nil)
+		ingressUpdaterMock.EXPECT().UpsertIngressForService(testCtx, service).Return(nil)
+
+		sut := NewDeploymentReconciler(clientMock, ingressUpdaterMock)
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-dogu", Namespace: testNamespace}}
+
+		// when
+		actualResult, err := sut.Reconcile(testCtx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 5 * time.Minute}, actualResult)
+	})
 }
@@ -6,6 +6,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -35,9 +36,11 @@ func (r *ecosystemCertificateReconciler) Reconcile(ctx context.Context, _ ctrl.R
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *ecosystemCertificateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *ecosystemCertificateReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1.Secret{}).
+		Named(EcosystemCertificateControllerName).
+		WithOptions(options).
 		WithEventFilter(ecosystemCertificatePredicate()).
 		Complete(r)
 }
@@ -0,0 +1,518 @@
+package expose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// doguConfigBackendSchemeKey is the dogu config key which overrides the scheme of the requests to the backend of a
+	// ces service, e.g., `ingress/nexus/backend_scheme`.
+	doguConfigBackendSchemeKey = "ingress/%s/backend_scheme"
+	// doguConfigResponseTimeoutKey is the dogu config key which overrides the time to wait for the response headers of
+	// the backend of a ces service, e.g., `10m`.
+	doguConfigResponseTimeoutKey = "ingress/%s/response_timeout"
+	// doguConfigIdleTimeoutKey is the dogu config key which overrides the time an idle connection to the backend of a
+	// ces service is kept open, e.g., `90s`.
+	doguConfigIdleTimeoutKey = "ingress/%s/idle_timeout"
+	// doguConfigMaxBodySizeKey is the dogu config key which overrides the max body size of the requests to a ces
+	// service in the nginx format, e.g., `1g`.
+	doguConfigMaxBodySizeKey = "ingress/%s/max_body_size"
+	// doguConfigBalancingStrategyKey is the dogu config key which overrides the balancing strategy of the requests to
+	// the pods of a ces service, e.g., `p2c`.
+	doguConfigBalancingStrategyKey = "ingress/%s/balancing_strategy"
+	// doguConfigStickyCookieEnabledKey is the dogu config key which enables or disables the sticky cookie of a ces
+	// service, e.g., `true`.
+	doguConfigStickyCookieEnabledKey = "ingress/%s/sticky_cookie/enabled"
+	// doguConfigStickyCookieNameKey is the dogu config key which overrides the name of the sticky cookie of a ces
+	// service. Traefik generates a name by default.
+	doguConfigStickyCookieNameKey = "ingress/%s/sticky_cookie/name"
+	// doguConfigStickyCookieSecureKey is the dogu config key which overrides the secure flag of the sticky cookie.
+	doguConfigStickyCookieSecureKey = "ingress/%s/sticky_cookie/secure"
+	// doguConfigStickyCookieHTTPOnlyKey is the dogu config key which overrides the http only flag of the sticky cookie.
+	doguConfigStickyCookieHTTPOnlyKey = "ingress/%s/sticky_cookie/http_only"
+	// doguConfigStickyCookieSameSiteKey is the dogu config key which overrides the same site policy of the sticky
+	// cookie, e.g., `lax`.
+	doguConfigStickyCookieSameSiteKey = "ingress/%s/sticky_cookie/same_site"
+)
+
+const (
+	// ingressServiceServersSchemeAnnotation defines the scheme of the requests of traefik to the service of an ingress.
+	ingressServiceServersSchemeAnnotation = "traefik.ingress.kubernetes.io/service.serversscheme"
+	// ingressServiceServersTransportAnnotation references the servers transport of the service of an ingress.
+	ingressServiceServersTransportAnnotation = "traefik.ingress.kubernetes.io/service.serverstransport"
+	// ingressServiceStickyCookieAnnotation enables the sticky cookie of the service of an ingress.
+	ingressServiceStickyCookieAnnotation         = "traefik.ingress.kubernetes.io/service.sticky.cookie"
+	ingressServiceStickyCookieNameAnnotation     = "traefik.ingress.kubernetes.io/service.sticky.cookie.name"
+	ingressServiceStickyCookieSecureAnnotation   = "traefik.ingress.kubernetes.io/service.sticky.cookie.secure"
+	ingressServiceStickyCookieHTTPOnlyAnnotation = "traefik.ingress.kubernetes.io/service.sticky.cookie.httponly"
+	ingressServiceStickyCookieSameSiteAnnotation = "traefik.ingress.kubernetes.io/service.sticky.cookie.samesite"
+)
+
+// backendAnnotations are the annotations of the services of ingress objects which are managed by the backend config.
+var backendAnnotations = []string{
+	ingressServiceServersSchemeAnnotation,
+	ingressServiceServersTransportAnnotation,
+	ingressServiceStickyCookieAnnotation,
+	ingressServiceStickyCookieNameAnnotation,
+	ingressServiceStickyCookieSecureAnnotation,
+	ingressServiceStickyCookieHTTPOnlyAnnotation,
+	ingressServiceStickyCookieSameSiteAnnotation,
+}
+
+// backendSchemes are the supported schemes of the backends of ces services. The scheme `h2c` serves http/2 and grpc
+// without tls.
+var backendSchemes = []string{"http", "https", "h2c"}
+
+// balancingStrategies are the supported strategies of traefik to balance the requests between the pods of a service:
+// weighted round-robin, power of two choices and highest random weight.
+var balancingStrategies = []string{"wrr", "p2c", "hrw"}
+
+// stickyCookieSameSitePolicies are the supported same site policies of sticky cookies.
+var stickyCookieSameSitePolicies = []string{"none", "lax", "strict"}
+
+// StickyCookie pins the clients of a ces service to one pod of its dogu by a cookie set by traefik.
+type StickyCookie struct {
+	// Name of the cookie. Traefik generates a name by default.
+	Name string `json:"name,omitempty"`
+	// Secure restricts the cookie to https requests.
+	Secure bool `json:"secure,omitempty"`
+	// HTTPOnly hides the cookie from scripts.
+	HTTPOnly bool `json:"httpOnly,omitempty"`
+	// SameSite policy of the cookie: `none`, `lax` or `strict`.
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+// backendConfig defines how traefik forwards the requests of a ces service to its backend. Empty values keep the
+// defaults of traefik.
+type backendConfig struct {
+	scheme          string
+	responseTimeout string
+	idleTimeout     string
+	// maxBodyBytes limits the request body size. Zero disables the limit, nil keeps the limit of the nginx annotations.
+	maxBodyBytes *int64
+	// stickyCookie of the backend. Nil disables sticky sessions.
+	stickyCookie *StickyCookie
+	strategy     string
+}
+
+// resolveBackendConfig returns the backend config of the given ces service overridden by the given dogu config.
+func resolveBackendConfig(cesService CesService, doguConfig libconfig.DoguConfig) (backendConfig, error) {
+	get := func(value string, key string) (string, string) {
+		source := fmt.Sprintf("ces service [%s]", cesService.Name)
+		configKey := libconfig.Key(fmt.Sprintf(key, cesService.Name))
+		if configValue, ok := doguConfig.Get(configKey); ok {
+			value = configValue.String()
+			source = fmt.Sprintf("dogu config key [%s]", configKey)
+		}
+
+		return strings.TrimSpace(value), source
+	}
+
+	backend := backendConfig{}
+
+	scheme, source := get(cesService.BackendScheme, doguConfigBackendSchemeKey)
+	if scheme != "" && !slices.Contains(backendSchemes, scheme) {
+		return backendConfig{}, fmt.Errorf("invalid backend scheme [%s] of %s: expected one of %v", scheme, source, backendSchemes)
+	}
+	backend.scheme = scheme
+
+	timeouts := []struct {
+		value  string
+		key    string
+		target *string
+	}{
+		{value: cesService.ResponseTimeout, key: doguConfigResponseTimeoutKey, target: &backend.responseTimeout},
+		{value: cesService.IdleTimeout, key: doguConfigIdleTimeoutKey, target: &backend.idleTimeout},
+	}
+	for _, timeout := range timeouts {
+		value, source := get(timeout.value, timeout.key)
+		if value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return backendConfig{}, fmt.Errorf("invalid timeout [%s] of %s: expected duration", value, source)
+		}
+
+		*timeout.target = value
+	}
+
+	maxBodySize, source := get(cesService.MaxBodySize, doguConfigMaxBodySizeKey)
+	if maxBodySize != "" {
+		size, err := parseNginxSize(maxBodySize)
+		if err != nil {
+			return backendConfig{}, fmt.Errorf("invalid max body size of %s: %w", source, err)
+		}
+
+		backend.maxBodyBytes = &size
+	}
+
+	strategy, source := get(cesService.BalancingStrategy, doguConfigBalancingStrategyKey)
+	if strategy != "" && !slices.Contains(balancingStrategies, strategy) {
+		return backendConfig{}, fmt.Errorf("invalid balancing strategy [%s] of %s: expected one of %v", strategy, source, balancingStrategies)
+	}
+	backend.strategy = strategy
+
+	var err error
+	backend.stickyCookie, err = resolveStickyCookie(cesService, doguConfig)
+	if err != nil {
+		return backendConfig{}, err
+	}
+
+	return backend, nil
+}
+
+// resolveStickyCookie returns the sticky cookie of the given ces service overridden by the given dogu config or nil if
+// the sticky cookie is disabled.
+func resolveStickyCookie(cesService CesService, doguConfig libconfig.DoguConfig) (*StickyCookie, error) {
+	getKey := func(key string) libconfig.Key {
+		return libconfig.Key(fmt.Sprintf(key, cesService.Name))
+	}
+	getBool := func(key libconfig.Key, target *bool) error {
+		value, ok := doguConfig.Get(key)
+		if !ok {
+			return nil
+		}
+
+		parsed, err := strconv.ParseBool(strings.TrimSpace(value.String()))
+		if err != nil {
+			return fmt.Errorf("invalid value [%s] of dogu config key [%s]: expected boolean", value, key)
+		}
+
+		*target = parsed
+		return nil
+	}
+
+	var cookie StickyCookie
+	enabled := cesService.StickyCookie != nil
+	if enabled {
+		cookie = *cesService.StickyCookie
+	}
+
+	err := getBool(getKey(doguConfigStickyCookieEnabledKey), &enabled)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	if name, ok := doguConfig.Get(getKey(doguConfigStickyCookieNameKey)); ok {
+		cookie.Name = strings.TrimSpace(name.String())
+	}
+	if sameSite, ok := doguConfig.Get(getKey(doguConfigStickyCookieSameSiteKey)); ok {
+		cookie.SameSite = strings.TrimSpace(sameSite.String())
+	}
+
+	err = getBool(getKey(doguConfigStickyCookieSecureKey), &cookie.Secure)
+	if err != nil {
+		return nil, err
+	}
+	err = getBool(getKey(doguConfigStickyCookieHTTPOnlyKey), &cookie.HTTPOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	cookie.SameSite = strings.ToLower(cookie.SameSite)
+	if cookie.SameSite != "" && !slices.Contains(stickyCookieSameSitePolicies, cookie.SameSite) {
+		return nil, fmt.Errorf("invalid same site policy [%s] of the sticky cookie of ces service [%s]: expected one of %v", cookie.SameSite, cesService.Name, stickyCookieSameSitePolicies)
+	}
+
+	return &cookie, nil
+}
+
+// getSticky returns the sticky sessions of the load balancer of traefik or nil without sticky cookie.
+func (b backendConfig) getSticky() *dynamic.Sticky {
+	if b.stickyCookie == nil {
+		return nil
+	}
+
+	return &dynamic.Sticky{Cookie: &dynamic.Cookie{
+		Name:     b.stickyCookie.Name,
+		Secure:   b.stickyCookie.Secure,
+		HTTPOnly: b.stickyCookie.HTTPOnly,
+		SameSite: b.stickyCookie.SameSite,
+	}}
+}
+
+// getStickyCookieAnnotations returns the annotations of the service of an ingress which enable the sticky cookie.
+func (b backendConfig) getStickyCookieAnnotations() map[string]string {
+	annotations := map[string]string{}
+	if b.stickyCookie == nil {
+		return annotations
+	}
+
+	annotations[ingressServiceStickyCookieAnnotation] = "true"
+	if b.stickyCookie.Name != "" {
+		annotations[ingressServiceStickyCookieNameAnnotation] = b.stickyCookie.Name
+	}
+	if b.stickyCookie.Secure {
+		annotations[ingressServiceStickyCookieSecureAnnotation] = "true"
+	}
+	if b.stickyCookie.HTTPOnly {
+		annotations[ingressServiceStickyCookieHTTPOnlyAnnotation] = "true"
+	}
+	if b.stickyCookie.SameSite != "" {
+		annotations[ingressServiceStickyCookieSameSiteAnnotation] = b.stickyCookie.SameSite
+	}
+
+	return annotations
+}
+
+// getForwardingTimeouts returns the given translated forwarding timeouts of the nginx annotations overridden by the
+// timeouts of the backend config or nil if no timeout is defined.
+func (b backendConfig) getForwardingTimeouts(translated *traefikapi.ForwardingTimeouts) *traefikapi.ForwardingTimeouts {
+	if b.responseTimeout == "" && b.idleTimeout == "" {
+		return translated
+	}
+
+	timeouts := &traefikapi.ForwardingTimeouts{}
+	if translated != nil {
+		*timeouts = *translated
+	}
+
+	if b.responseTimeout != "" {
+		responseTimeout := intstr.FromString(b.responseTimeout)
+		timeouts.ResponseHeaderTimeout = &responseTimeout
+	}
+	if b.idleTimeout != "" {
+		idleTimeout := intstr.FromString(b.idleTimeout)
+		timeouts.IdleConnTimeout = &idleTimeout
+	}
+
+	return timeouts
+}
+
+// overrideMaxBodySize returns the given translation with the buffering middleware of the max body size of the backend
+// config, which takes precedence over the nginx annotation `proxy-body-size`.
+func (b backendConfig) overrideMaxBodySize(translation nginxTranslation) nginxTranslation {
+	if b.maxBodyBytes == nil {
+		return translation
+	}
+
+	translation.middlewares = maps.Clone(translation.middlewares)
+	if translation.middlewares == nil {
+		translation.middlewares = map[string]traefikapi.MiddlewareSpec{}
+	}
+
+	delete(translation.middlewares, bufferingMiddlewareSuffix)
+	if *b.maxBodyBytes > 0 {
+		translation.middlewares[bufferingMiddlewareSuffix] = traefikapi.MiddlewareSpec{
+			Buffering: &dynamic.Buffering{MaxRequestBodyBytes: *b.maxBodyBytes},
+		}
+	}
+
+	return translation
+}
+
+// getServiceBackendConfig merges the backend configs of the given ces services of one service. Traefik reads the
+// scheme, the servers transport and the sticky cookie of an ingress from the annotations of its service, so a setting
+// of one ces service applies to all ces services of the service and conflicting settings fail.
+func getServiceBackendConfig(cesServices []resolvedCesService) (backendConfig, error) {
+	merged := backendConfig{}
+	for _, cesService := range cesServices {
+		settings := []struct {
+			name   string
+			value  string
+			target *string
+		}{
+			{name: "scheme", value: cesService.backend.scheme, target: &merged.scheme},
+			{name: "response timeout", value: cesService.backend.responseTimeout, target: &merged.responseTimeout},
+			{name: "idle timeout", value: cesService.backend.idleTimeout, target: &merged.idleTimeout},
+		}
+
+		for _, setting := range settings {
+			if setting.value == "" {
+				continue
+			}
+
+			if *setting.target != "" && *setting.target != setting.value {
+				return backendConfig{}, fmt.Errorf("conflicting backend %s [%s] and [%s] of the ces services", setting.name, *setting.target, setting.value)
+			}
+
+			*setting.target = setting.value
+		}
+
+		if cesService.backend.stickyCookie != nil {
+			if merged.stickyCookie != nil && *merged.stickyCookie != *cesService.backend.stickyCookie {
+				return backendConfig{}, fmt.Errorf("conflicting backend sticky cookies [%+v] and [%+v] of the ces services", *merged.stickyCookie, *cesService.backend.stickyCookie)
+			}
+
+			merged.stickyCookie = cesService.backend.stickyCookie
+		}
+	}
+
+	return merged, nil
+}
+
+// getTranslatedForwardingTimeouts merges the forwarding timeouts translated from the nginx annotations of the given
+// ces services of the given service. Like the backend config, the timeouts apply to all ces services of the service,
+// so conflicting timeouts fail.
+func getTranslatedForwardingTimeouts(service *corev1.Service, cesServices []resolvedCesService) (*traefikapi.ForwardingTimeouts, error) {
+	annotations, err := getAdditionalIngressAnnotations(service)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged *traefikapi.ForwardingTimeouts
+	mergedCesServiceName := ""
+	for _, cesService := range cesServices {
+		pathPrefix, _, err := cesService.getReplacePath()
+		if err != nil {
+			return nil, fmt.Errorf("error getting rewrite-config from ces-service: %w", err)
+		}
+
+		translation, _ := translateNginxAnnotations(annotations, pathPrefix)
+		if translation.forwardingTimeouts == nil {
+			continue
+		}
+
+		if merged != nil && !equality.Semantic.DeepEqual(merged, translation.forwardingTimeouts) {
+			return nil, fmt.Errorf("conflicting translated forwarding timeouts of the ces services [%s] and [%s]", mergedCesServiceName, cesService.Name)
+		}
+
+		merged = translation.forwardingTimeouts
+		mergedCesServiceName = cesService.Name
+	}
+
+	return merged, nil
+}
+
+// upsertBackendOfService applies the merged backend config of the given ces services to the annotations of the given
+// service and to its servers transport, which also carries the translated timeout annotations of the dogu. The servers
+// transport is deleted as soon as the service references no timeouts anymore.
+func (i *ingressUpdater) upsertBackendOfService(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService) error {
+	backend, err := getServiceBackendConfig(cesServices)
+	if err != nil {
+		return fmt.Errorf("invalid backend config of service [%s]: %w", service.Name, err)
+	}
+
+	annotations := backend.getStickyCookieAnnotations()
+	if backend.scheme != "" {
+		annotations[ingressServiceServersSchemeAnnotation] = backend.scheme
+	}
+
+	translatedTimeouts, err := getTranslatedForwardingTimeouts(service, cesServices)
+	if err != nil {
+		return fmt.Errorf("invalid timeout annotations of service [%s]: %w", service.Name, err)
+	}
+
+	timeouts := backend.getForwardingTimeouts(translatedTimeouts)
+	if _, referenced := service.Annotations[ingressServiceServersTransportAnnotation]; timeouts != nil || referenced {
+		ownerReferences := []v1.OwnerReference{{
+			APIVersion: service.APIVersion,
+			Kind:       service.Kind,
+			Name:       service.Name,
+			UID:        service.UID,
+		}}
+
+		serversTransportName, err := i.upsertServersTransport(ctx, service.Name, timeouts, ownerReferences)
+		if err != nil {
+			return err
+		}
+
+		if serversTransportName != "" {
+			annotations[ingressServiceServersTransportAnnotation] = fmt.Sprintf("%s-%s%s", i.namespace, serversTransportName, kubernetesCRDProviderSuffix)
+		}
+	}
+
+	return i.patchBackendAnnotations(ctx, service, annotations)
+}
+
+// patchBackendAnnotations sets the given backend annotations of the given service and removes the other backend
+// annotations. The service is only patched if its annotations differ.
+func (i *ingressUpdater) patchBackendAnnotations(ctx context.Context, service *corev1.Service, annotations map[string]string) error {
+	patch := map[string]any{}
+	for _, key := range backendAnnotations {
+		desired, ok := annotations[key]
+		current, exists := service.Annotations[key]
+		switch {
+		case ok && (!exists || current != desired):
+			patch[key] = desired
+		case !ok && exists:
+			patch[key] = nil
+		}
+	}
+
+	if len(patch) == 0 {
+		return nil
+	}
+
+	err := i.patchServiceAnnotations(ctx, service, patch)
+	if err != nil {
+		return fmt.Errorf("failed to patch backend annotations of service [%s]: %w", service.Name, err)
+	}
+
+	return nil
+}
+
+// patchServiceAnnotations merges the given annotations into the annotations of the given service. Nil values remove
+// the annotation.
+func (i *ingressUpdater) patchServiceAnnotations(ctx context.Context, service *corev1.Service, annotations map[string]any) error {
+	data, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": annotations}})
+	if err != nil {
+		return err
+	}
+
+	_, err = i.serviceInterface.Patch(ctx, service.Name, types.MergePatchType, data, v1.PatchOptions{})
+	return err
+}
+
+// upsertServersTransport creates or updates the servers transport with the given name and forwarding timeouts and
+// returns its name. The servers transport is deleted if no timeouts are given.
+func (i *ingressUpdater) upsertServersTransport(ctx context.Context, name string, timeouts *traefikapi.ForwardingTimeouts, ownerReferences []v1.OwnerReference) (string, error) {
+	if timeouts == nil {
+		return "", i.deleteServersTransport(ctx, name)
+	}
+
+	serversTransport := &traefikapi.ServersTransport{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       serversTransportKind,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:            name,
+			Namespace:       i.namespace,
+			Labels:          util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: traefikapi.ServersTransportSpec{
+			ForwardingTimeouts: timeouts,
+		},
+	}
+
+	_, err := util.ServerSideApply[*traefikapi.ServersTransport](ctx, i.serversTransportInterface, serversTransport, i.eventRecorder)
+	if err != nil {
+		return "", fmt.Errorf("failed to upsert servers transport %s: %w", serversTransport.Name, err)
+	}
+
+	return serversTransport.Name, nil
+}
+
+func (i *ingressUpdater) deleteServersTransport(ctx context.Context, name string) error {
+	err := i.serversTransportInterface.Delete(ctx, name, v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete servers transport %s: %w", name, err)
+	}
+
+	return nil
+}
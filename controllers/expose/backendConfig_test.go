@@ -0,0 +1,413 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_resolveBackendConfig(t *testing.T) {
+	t.Run("should keep the defaults of traefik without config", func(t *testing.T) {
+		// when
+		actual, err := resolveBackendConfig(CesService{Name: "nexus"}, config.CreateDoguConfig("nexus", config.Entries{}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, backendConfig{}, actual)
+	})
+	t.Run("should override the backend config of the ces service with the dogu config", func(t *testing.T) {
+		// given
+		cesService := CesService{Name: "nexus", BackendScheme: "https", ResponseTimeout: "1m", IdleTimeout: "90s", MaxBodySize: "1g"}
+		doguConfig := config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/backend_scheme":   "h2c",
+			"ingress/nexus/response_timeout": "10m",
+			"ingress/nexus/max_body_size":    "0",
+			"ingress/other/idle_timeout":     "1s",
+		})
+
+		// when
+		actual, err := resolveBackendConfig(cesService, doguConfig)
+
+		// then
+		require.NoError(t, err)
+		noLimit := int64(0)
+		assert.Equal(t, backendConfig{scheme: "h2c", responseTimeout: "10m", idleTimeout: "90s", maxBodyBytes: &noLimit}, actual)
+	})
+	t.Run("should parse the max body size in the nginx format", func(t *testing.T) {
+		// when
+		actual, err := resolveBackendConfig(CesService{Name: "nexus", MaxBodySize: "2m"}, config.CreateDoguConfig("nexus", config.Entries{}))
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, actual.maxBodyBytes)
+		assert.Equal(t, int64(2*1024*1024), *actual.maxBodyBytes)
+	})
+	t.Run("should fail for unknown scheme", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("nexus", config.Entries{"ingress/nexus/backend_scheme": "ftp"})
+
+		// when
+		_, err := resolveBackendConfig(CesService{Name: "nexus"}, doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid backend scheme [ftp] of dogu config key [ingress/nexus/backend_scheme]: expected one of [http https h2c]")
+	})
+	t.Run("should fail for invalid timeout", func(t *testing.T) {
+		// when
+		_, err := resolveBackendConfig(CesService{Name: "jenkins", IdleTimeout: "-1s"}, config.CreateDoguConfig("jenkins", config.Entries{}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid timeout [-1s] of ces service [jenkins]: expected duration")
+	})
+	t.Run("should fail for invalid max body size", func(t *testing.T) {
+		// when
+		_, err := resolveBackendConfig(CesService{Name: "nexus", MaxBodySize: "1t"}, config.CreateDoguConfig("nexus", config.Entries{}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid max body size of ces service [nexus]: invalid nginx size [1t]")
+	})
+	t.Run("should override the sticky cookie and the balancing strategy with the dogu config", func(t *testing.T) {
+		// given
+		cesService := CesService{Name: "redmine", BalancingStrategy: "wrr", StickyCookie: &StickyCookie{Name: "redmine_lb", Secure: true}}
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{
+			"ingress/redmine/balancing_strategy":      "p2c",
+			"ingress/redmine/sticky_cookie/http_only": "true",
+			"ingress/redmine/sticky_cookie/same_site": "Lax",
+		})
+
+		// when
+		actual, err := resolveBackendConfig(cesService, doguConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, backendConfig{strategy: "p2c", stickyCookie: &StickyCookie{Name: "redmine_lb", Secure: true, HTTPOnly: true, SameSite: "lax"}}, actual)
+		assert.Equal(t, &StickyCookie{Name: "redmine_lb", Secure: true}, cesService.StickyCookie)
+	})
+	t.Run("should enable the sticky cookie with the dogu config", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"ingress/redmine/sticky_cookie/enabled": "true"})
+
+		// when
+		actual, err := resolveBackendConfig(CesService{Name: "redmine"}, doguConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &StickyCookie{}, actual.stickyCookie)
+	})
+	t.Run("should disable the sticky cookie of the ces service with the dogu config", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"ingress/redmine/sticky_cookie/enabled": "false"})
+
+		// when
+		actual, err := resolveBackendConfig(CesService{Name: "redmine", StickyCookie: &StickyCookie{Name: "redmine_lb"}}, doguConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, actual.stickyCookie)
+	})
+	t.Run("should fail for unknown balancing strategy", func(t *testing.T) {
+		// when
+		_, err := resolveBackendConfig(CesService{Name: "redmine", BalancingStrategy: "random"}, config.CreateDoguConfig("redmine", config.Entries{}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid balancing strategy [random] of ces service [redmine]: expected one of [wrr p2c hrw]")
+	})
+	t.Run("should fail for invalid boolean of the sticky cookie", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"ingress/redmine/sticky_cookie/secure": "yes please"})
+
+		// when
+		_, err := resolveBackendConfig(CesService{Name: "redmine", StickyCookie: &StickyCookie{}}, doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [yes please] of dogu config key [ingress/redmine/sticky_cookie/secure]: expected boolean")
+	})
+	t.Run("should fail for unknown same site policy", func(t *testing.T) {
+		// when
+		_, err := resolveBackendConfig(CesService{Name: "redmine", StickyCookie: &StickyCookie{SameSite: "always"}}, config.CreateDoguConfig("redmine", config.Entries{}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid same site policy [always] of the sticky cookie of ces service [redmine]: expected one of [none lax strict]")
+	})
+}
+
+func Test_backendConfig_getForwardingTimeouts(t *testing.T) {
+	t.Run("should return the translated timeouts without backend timeouts", func(t *testing.T) {
+		// given
+		readTimeout := intstr.FromString("600s")
+		translated := &traefikapi.ForwardingTimeouts{ResponseHeaderTimeout: &readTimeout}
+
+		// when
+		actual := backendConfig{scheme: "https"}.getForwardingTimeouts(translated)
+
+		// then
+		assert.Same(t, translated, actual)
+	})
+	t.Run("should override the translated timeouts", func(t *testing.T) {
+		// given
+		readTimeout := intstr.FromString("600s")
+		dialTimeout := intstr.FromString("5s")
+		translated := &traefikapi.ForwardingTimeouts{ResponseHeaderTimeout: &readTimeout, DialTimeout: &dialTimeout}
+
+		// when
+		actual := backendConfig{responseTimeout: "10m", idleTimeout: "90s"}.getForwardingTimeouts(translated)
+
+		// then
+		responseTimeout := intstr.FromString("10m")
+		idleTimeout := intstr.FromString("90s")
+		assert.Equal(t, &traefikapi.ForwardingTimeouts{ResponseHeaderTimeout: &responseTimeout, DialTimeout: &dialTimeout, IdleConnTimeout: &idleTimeout}, actual)
+		assert.Equal(t, "600s", translated.ResponseHeaderTimeout.String())
+	})
+}
+
+func Test_backendConfig_overrideMaxBodySize(t *testing.T) {
+	translation := nginxTranslation{middlewares: map[string]traefikapi.MiddlewareSpec{
+		"buffering": {Buffering: &dynamic.Buffering{MaxRequestBodyBytes: 1024}},
+		"rewrite":   {},
+	}}
+
+	t.Run("should keep the translated body size without max body size", func(t *testing.T) {
+		// when
+		actual := backendConfig{}.overrideMaxBodySize(translation)
+
+		// then
+		assert.Equal(t, translation, actual)
+	})
+	t.Run("should override the translated body size", func(t *testing.T) {
+		// given
+		maxBodyBytes := int64(2048)
+
+		// when
+		actual := backendConfig{maxBodyBytes: &maxBodyBytes}.overrideMaxBodySize(translation)
+
+		// then
+		assert.Equal(t, int64(2048), actual.middlewares["buffering"].Buffering.MaxRequestBodyBytes)
+		assert.Equal(t, int64(1024), translation.middlewares["buffering"].Buffering.MaxRequestBodyBytes)
+	})
+	t.Run("should remove the body size limit with zero", func(t *testing.T) {
+		// given
+		noLimit := int64(0)
+
+		// when
+		actual := backendConfig{maxBodyBytes: &noLimit}.overrideMaxBodySize(translation)
+
+		// then
+		assert.Equal(t, []string{"rewrite"}, actual.getMiddlewareSuffixes())
+	})
+}
+
+func Test_getServiceBackendConfig(t *testing.T) {
+	t.Run("should merge the backend configs of the ces services", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "nexus"}, backend: backendConfig{scheme: "https", responseTimeout: "10m"}},
+			{CesService: CesService{Name: "nexus-docker"}, backend: backendConfig{scheme: "https", idleTimeout: "90s"}},
+		}
+
+		// when
+		actual, err := getServiceBackendConfig(cesServices)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, backendConfig{scheme: "https", responseTimeout: "10m", idleTimeout: "90s"}, actual)
+	})
+	t.Run("should fail for conflicting backend configs", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "nexus"}, backend: backendConfig{scheme: "https"}},
+			{CesService: CesService{Name: "nexus-docker"}, backend: backendConfig{scheme: "h2c"}},
+		}
+
+		// when
+		_, err := getServiceBackendConfig(cesServices)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "conflicting backend scheme [https] and [h2c] of the ces services")
+	})
+	t.Run("should fail for conflicting sticky cookies", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "redmine"}, backend: backendConfig{stickyCookie: &StickyCookie{Name: "a"}}},
+			{CesService: CesService{Name: "redmine-api"}, backend: backendConfig{stickyCookie: &StickyCookie{Name: "b"}}},
+		}
+
+		// when
+		_, err := getServiceBackendConfig(cesServices)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "conflicting backend sticky cookies [{Name:a Secure:false HTTPOnly:false SameSite:}] and [{Name:b Secure:false HTTPOnly:false SameSite:}] of the ces services")
+	})
+}
+
+func Test_ingressUpdater_upsertBackendOfService(t *testing.T) {
+	getService := func(annotations map[string]string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: testNamespace, UID: "uid", Annotations: annotations},
+		}
+	}
+
+	t.Run("should annotate the service with the scheme and the servers transport", func(t *testing.T) {
+		// given
+		service := getService(nil)
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "jenkins"}, backend: backendConfig{scheme: "https", responseTimeout: "10m"}}}
+		responseTimeout := intstr.FromString("10m")
+
+		serversTransportInterfaceMock := newMockServersTransportInterface(t)
+		expectApplyServersTransport(t, serversTransportInterfaceMock, getTestServersTransport("jenkins", service, &traefikapi.ForwardingTimeouts{ResponseHeaderTimeout: &responseTimeout}))
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"traefik.ingress.kubernetes.io/service.serversscheme":"https","traefik.ingress.kubernetes.io/service.serverstransport":"my-namespace-jenkins@kubernetescrd"}}}`),
+			metav1.PatchOptions{}).Return(service, nil)
+
+		sut := &ingressUpdater{namespace: testNamespace, serviceInterface: serviceInterfaceMock, serversTransportInterface: serversTransportInterfaceMock}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, service, cesServices)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should reference the servers transport with the translated timeout annotations", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{
+			annotation.AdditionalIngressAnnotationsAnnotation: `{"nginx.ingress.kubernetes.io/proxy-connect-timeout":"5","nginx.ingress.kubernetes.io/proxy-read-timeout":"600"}`,
+		})
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "jenkins"}, backend: backendConfig{responseTimeout: "10m"}},
+			{CesService: CesService{Name: "jenkins-api", Location: "/api", Pass: "/jenkins/api"}},
+		}
+		dialTimeout := intstr.FromString("5s")
+		responseTimeout := intstr.FromString("10m")
+
+		serversTransportInterfaceMock := newMockServersTransportInterface(t)
+		expectApplyServersTransport(t, serversTransportInterfaceMock, getTestServersTransport("jenkins", service, &traefikapi.ForwardingTimeouts{DialTimeout: &dialTimeout, ResponseHeaderTimeout: &responseTimeout}))
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"traefik.ingress.kubernetes.io/service.serverstransport":"my-namespace-jenkins@kubernetescrd"}}}`),
+			metav1.PatchOptions{}).Return(service, nil)
+
+		sut := &ingressUpdater{namespace: testNamespace, serviceInterface: serviceInterfaceMock, serversTransportInterface: serversTransportInterfaceMock}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, service, cesServices)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail for invalid additional ingress annotations", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{annotation.AdditionalIngressAnnotationsAnnotation: "{"})
+
+		sut := &ingressUpdater{namespace: testNamespace}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, service, []resolvedCesService{{CesService: CesService{Name: "jenkins"}}})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid timeout annotations of service [jenkins]")
+	})
+	t.Run("should remove the annotations and the servers transport of the removed backend config", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{
+			"traefik.ingress.kubernetes.io/service.serversscheme":    "https",
+			"traefik.ingress.kubernetes.io/service.serverstransport": "my-namespace-jenkins@kubernetescrd",
+		})
+
+		serversTransportInterfaceMock := newMockServersTransportInterface(t)
+		serversTransportInterfaceMock.EXPECT().Delete(testCtx, "jenkins", metav1.DeleteOptions{}).Return(nil)
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"traefik.ingress.kubernetes.io/service.serversscheme":null,"traefik.ingress.kubernetes.io/service.serverstransport":null}}}`),
+			metav1.PatchOptions{}).Return(service, nil)
+
+		sut := &ingressUpdater{namespace: testNamespace, serviceInterface: serviceInterfaceMock, serversTransportInterface: serversTransportInterfaceMock}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, service, []resolvedCesService{{CesService: CesService{Name: "jenkins"}}})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should annotate the service with the sticky cookie", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{"traefik.ingress.kubernetes.io/service.sticky.cookie.secure": "true"})
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "jenkins"}, backend: backendConfig{stickyCookie: &StickyCookie{Name: "jenkins_lb", SameSite: "strict"}}}}
+
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"traefik.ingress.kubernetes.io/service.sticky.cookie":"true","traefik.ingress.kubernetes.io/service.sticky.cookie.name":"jenkins_lb","traefik.ingress.kubernetes.io/service.sticky.cookie.samesite":"strict","traefik.ingress.kubernetes.io/service.sticky.cookie.secure":null}}}`),
+			metav1.PatchOptions{}).Return(service, nil)
+
+		sut := &ingressUpdater{namespace: testNamespace, serviceInterface: serviceInterfaceMock}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, service, cesServices)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not patch the service with unchanged annotations", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{"traefik.ingress.kubernetes.io/service.serversscheme": "h2c"})
+
+		sut := &ingressUpdater{namespace: testNamespace}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, service, []resolvedCesService{{CesService: CesService{Name: "jenkins"}, backend: backendConfig{scheme: "h2c"}}})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail for conflicting backend configs", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "jenkins"}, backend: backendConfig{idleTimeout: "90s"}},
+			{CesService: CesService{Name: "jenkins-agent"}, backend: backendConfig{idleTimeout: "10m"}},
+		}
+
+		sut := &ingressUpdater{namespace: testNamespace}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, getService(nil), cesServices)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid backend config of service [jenkins]: conflicting backend idle timeout [90s] and [10m] of the ces services")
+	})
+	t.Run("should fail to patch the service", func(t *testing.T) {
+		// given
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"traefik.ingress.kubernetes.io/service.serversscheme":"h2c"}}}`),
+			metav1.PatchOptions{}).Return(nil, assert.AnError)
+
+		sut := &ingressUpdater{namespace: testNamespace, serviceInterface: serviceInterfaceMock}
+
+		// when
+		err := sut.upsertBackendOfService(testCtx, getService(nil), []resolvedCesService{{CesService: CesService{Name: "jenkins"}, backend: backendConfig{scheme: "h2c"}}})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to patch backend annotations of service [jenkins]")
+	})
+}
@@ -0,0 +1,516 @@
+package expose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// doguConfigCanaryWeightsKey is the dogu config key with the comma separated percentages of the requests which are
+	// routed to the new version of an upgraded dogu, e.g., `10,25,50`. Every percentage is one step of the canary.
+	doguConfigCanaryWeightsKey = "ingress/canary/weights"
+	// doguConfigCanaryStepIntervalKey is the dogu config key with the duration of every step of the canary, e.g., `5m`.
+	doguConfigCanaryStepIntervalKey = "ingress/canary/step_interval"
+)
+
+const (
+	// canaryStateAnnotation contains the json-marshalled canaryState of a dogu service.
+	canaryStateAnnotation = "k8s-service-discovery.cloudogu.com/canary"
+	// doguVersionLabel contains the version of the dogu of a pod.
+	doguVersionLabel          = "dogu.version"
+	defaultCanaryStepInterval = time.Minute
+	stableServiceSuffix       = "stable"
+	canaryServiceSuffix       = "canary"
+)
+
+const (
+	canaryRollbackEventReason  = "CanaryRollback"
+	canaryReleaseEventReason   = "CanaryRelease"
+	canaryPromotionEventReason = "CanaryPromotion"
+	canaryRefusedEventReason   = "CanaryRefused"
+)
+
+// canaryConfig defines the steps of the canary of an upgraded dogu. The canary is disabled without weights.
+type canaryConfig struct {
+	weights      []int
+	stepInterval time.Duration
+}
+
+// getCanaryConfig returns the canary config of the given dogu config.
+func getCanaryConfig(doguConfig libconfig.DoguConfig) (canaryConfig, error) {
+	config := canaryConfig{stepInterval: defaultCanaryStepInterval}
+
+	weights, _ := doguConfig.Get(doguConfigCanaryWeightsKey)
+	for _, value := range strings.Split(weights.String(), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight <= 0 || weight >= 100 || (len(config.weights) > 0 && weight <= config.weights[len(config.weights)-1]) {
+			return canaryConfig{}, fmt.Errorf("invalid value [%s] of dogu config key [%s]: expected ascending percentages between 1 and 99", weights, doguConfigCanaryWeightsKey)
+		}
+
+		config.weights = append(config.weights, weight)
+	}
+
+	if interval, ok := doguConfig.Get(doguConfigCanaryStepIntervalKey); ok {
+		duration, err := time.ParseDuration(interval.String())
+		if err != nil || duration <= 0 {
+			return canaryConfig{}, fmt.Errorf("invalid value [%s] of dogu config key [%s]: expected duration", interval, doguConfigCanaryStepIntervalKey)
+		}
+
+		config.stepInterval = duration
+	}
+
+	return config, nil
+}
+
+func (c canaryConfig) isEnabled() bool {
+	return len(c.weights) > 0
+}
+
+// getCanaryRefusal returns why the given deployment can't run the pods of the stable and the canary version at the same
+// time or an empty string. Without pods of both versions, the canary version would be promoted as soon as the pods of
+// the stable version are stopped and could not be rolled back.
+func getCanaryRefusal(deployment *appsv1.Deployment) string {
+	if deployment.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return "the deployment has the strategy Recreate"
+	}
+
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas < 2 {
+		return "the deployment has less than two replicas"
+	}
+
+	return ""
+}
+
+// canaryState is the state of the canary of a dogu service, which is stored in the annotation of the service.
+type canaryState struct {
+	// StableVersion of the dogu which serves all requests without canary.
+	StableVersion string `json:"stableVersion"`
+	// CanaryVersion of the upgraded dogu. Empty without canary.
+	CanaryVersion string `json:"canaryVersion,omitempty"`
+	// Step is the index of the current weight of the canary config.
+	Step int `json:"step,omitempty"`
+	// Weight is the percentage of the requests which are routed to the canary version.
+	Weight int `json:"weight,omitempty"`
+	// StepStartedAt is the start of the current step. Nil until the canary version is ready.
+	StepStartedAt *v1.Time `json:"stepStartedAt,omitempty"`
+	// RolledBack is true if the canary version lost its readiness. The requests are routed to the stable version until
+	// the dogu is upgraded again.
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// Released is true after the last step of the canary. The rollout of the deployment replaces the remaining pods of
+	// the stable version and the canary version is promoted as soon as the rollout is finished.
+	Released bool `json:"released,omitempty"`
+	// HoldsRollout is true while the service discovery pauses the rollout of the deployment, so the pods of the stable
+	// version keep running during the steps of the canary.
+	HoldsRollout bool `json:"holdsRollout,omitempty"`
+}
+
+// getCanaryState returns the canary state of the given service. Missing or invalid states are ignored.
+func getCanaryState(service *corev1.Service) (canaryState, bool) {
+	value, ok := service.Annotations[canaryStateAnnotation]
+	if !ok {
+		return canaryState{}, false
+	}
+
+	state := canaryState{}
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return canaryState{}, false
+	}
+
+	return state, true
+}
+
+func (s canaryState) isRunning() bool {
+	return s.CanaryVersion != ""
+}
+
+func getCanaryServiceName(serviceName string, suffix string) string {
+	return fmt.Sprintf("%s-%s", serviceName, suffix)
+}
+
+// UpdateCanary records a warning event if the dogu config of the given service configures a canary, as weighted
+// canaries require the traefik services of the routing mode ingressroute.
+func (i *ingressUpdater) UpdateCanary(ctx context.Context, service *corev1.Service, _ *appsv1.Deployment) (time.Duration, error) {
+	doguConfig, err := i.getDoguConfig(ctx, service)
+	if err != nil {
+		return 0, err
+	}
+
+	if weights, ok := doguConfig.Get(doguConfigCanaryWeightsKey); ok && strings.TrimSpace(weights.String()) != "" {
+		i.eventRecorder.Eventf(service, corev1.EventTypeWarning, ignoredRoutingConfigEventReason, "Ignored canary config of service [%s]: weighted canaries require the routing mode ingressroute.", service.Name)
+	}
+
+	return 0, nil
+}
+
+// UpdateCanary advances the canary of the given dogu service after an upgrade of the given deployment and stores the
+// canary state in the annotation of the service. It returns the time until the next step of the canary is due or zero
+// if no step is due.
+//
+// A canary starts as soon as the deployment has another dogu version than the ready pods of the stable version. The
+// requests are split between the services `<service>-stable` and `<service>-canary`, which select the pods of their
+// version, by the weights of the dogu config. The canary is rolled back if the canary version loses its readiness. The
+// canary of deployments which can't run the pods of both versions at the same time is refused with a warning event.
+//
+// The rollout of the deployment is paused as soon as a pod of the canary version exists, so the pods of the stable
+// version keep serving their share of the requests. After the last step, the rollout is resumed and the canary version
+// is promoted once no pod of the stable version is left.
+func (r *ingressRouteUpdater) UpdateCanary(ctx context.Context, service *corev1.Service, deployment *appsv1.Deployment) (time.Duration, error) {
+	doguConfig, err := r.getDoguConfig(ctx, service)
+	if err != nil {
+		return 0, err
+	}
+
+	config, err := getCanaryConfig(doguConfig)
+	if err != nil {
+		return 0, fmt.Errorf("invalid canary config of service [%s]: %w", service.Name, err)
+	}
+
+	if refusal := getCanaryRefusal(deployment); config.isEnabled() && refusal != "" {
+		r.eventRecorder.Eventf(service, corev1.EventTypeWarning, canaryRefusedEventReason, "Refused the canary of service [%s]: %s.", service.Name, refusal)
+		config = canaryConfig{}
+	}
+
+	current, hasState := getCanaryState(service)
+	targetVersion := deployment.Spec.Template.Labels[doguVersionLabel]
+	if !config.isEnabled() || targetVersion == "" {
+		if !hasState {
+			return 0, nil
+		}
+
+		if current.HoldsRollout {
+			err = r.setRolloutPaused(ctx, deployment, false)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		return 0, r.storeCanaryState(ctx, service, nil)
+	}
+
+	podVersions, err := r.getPodVersions(ctx, service)
+	if err != nil {
+		return 0, err
+	}
+
+	state, requeueAfter := r.advanceCanary(service, current, targetVersion, podVersions, config)
+	state, err = r.updateRolloutHold(ctx, deployment, current.HoldsRollout, state, podVersions)
+	if err != nil {
+		return 0, err
+	}
+
+	if hasState && equalCanaryStates(current, state) {
+		return requeueAfter, nil
+	}
+
+	return requeueAfter, r.storeCanaryState(ctx, service, &state)
+}
+
+// advanceCanary returns the next state of the given canary state of the given service and the time until the next
+// step is due. The given pod versions map the dogu versions of the pods of the service to their readiness.
+func (r *ingressRouteUpdater) advanceCanary(service *corev1.Service, state canaryState, targetVersion string, podVersions map[string]bool, config canaryConfig) (canaryState, time.Duration) {
+	if state.StableVersion == "" {
+		// ready pods of another version than the deployment are the stable version of the first canary
+		state.StableVersion = targetVersion
+		for _, version := range slices.Sorted(maps.Keys(podVersions)) {
+			if version != targetVersion && podVersions[version] {
+				state.StableVersion = version
+				break
+			}
+		}
+	}
+
+	if targetVersion == state.StableVersion {
+		// the dogu was not upgraded or the upgrade was reverted
+		return canaryState{StableVersion: targetVersion}, 0
+	}
+
+	if state.CanaryVersion != targetVersion {
+		state = canaryState{StableVersion: state.StableVersion, CanaryVersion: targetVersion}
+	}
+
+	switch {
+	case !podVersions[state.CanaryVersion]:
+		if state.Weight > 0 {
+			r.eventRecorder.Eventf(service, corev1.EventTypeWarning, canaryRollbackEventReason, "Rolled back canary version [%s] of service [%s]: the canary version has no ready pod.", state.CanaryVersion, service.Name)
+			// the rollout is paused again, so the remaining pods of the stable version keep running
+			state.Weight, state.RolledBack, state.Released = 0, true, false
+		}
+
+		return state, 0
+	case !podVersions[state.StableVersion]:
+		// the rollout of the deployment replaced all pods of the stable version, so there is nothing left to route to
+		return r.promoteCanary(service, state, "the rollout of the deployment finished"), 0
+	case state.RolledBack, state.Released:
+		return state, 0
+	case state.StepStartedAt == nil:
+		now := v1.Now()
+		state.Step, state.Weight, state.StepStartedAt = 0, config.weights[0], &now
+		return state, config.stepInterval
+	}
+
+	elapsed := time.Since(state.StepStartedAt.Time)
+	if elapsed < config.stepInterval {
+		return state, config.stepInterval - elapsed
+	}
+
+	if state.Step+1 >= len(config.weights) {
+		// the canary version receives all requests while the rollout replaces the pods of the stable version
+		r.eventRecorder.Eventf(service, corev1.EventTypeNormal, canaryReleaseEventReason, "Released the rollout of canary version [%s] of service [%s]: the last step of the canary finished.", state.CanaryVersion, service.Name)
+		state.Weight, state.Released = 100, true
+		return state, 0
+	}
+
+	state.Step++
+	now := v1.Now()
+	state.Weight, state.StepStartedAt = config.weights[state.Step], &now
+	return state, config.stepInterval
+}
+
+func (r *ingressRouteUpdater) promoteCanary(service *corev1.Service, state canaryState, reason string) canaryState {
+	r.eventRecorder.Eventf(service, corev1.EventTypeNormal, canaryPromotionEventReason, "Promoted canary version [%s] of service [%s] to the stable version: %s.", state.CanaryVersion, service.Name, reason)
+	return canaryState{StableVersion: state.CanaryVersion}
+}
+
+// updateRolloutHold pauses the rollout of the given deployment while the given canary runs and resumes it after the
+// last step or at the end of the canary. A paused deployment doesn't create the pods of a new version, so the rollout
+// is only paused once a pod of the canary version exists. The given held flag tells whether the rollout was paused by
+// the previous state of the canary.
+func (r *ingressRouteUpdater) updateRolloutHold(ctx context.Context, deployment *appsv1.Deployment, held bool, state canaryState, podVersions map[string]bool) (canaryState, error) {
+	_, hasCanaryPod := podVersions[state.CanaryVersion]
+	hold := state.isRunning() && !state.Released && hasCanaryPod
+	if hold && !held && deployment.Spec.Paused {
+		// the rollout was paused by someone else, who has to resume it as well
+		return state, nil
+	}
+
+	if hold != held {
+		err := r.setRolloutPaused(ctx, deployment, hold)
+		if err != nil {
+			return state, err
+		}
+	}
+
+	state.HoldsRollout = hold
+	return state, nil
+}
+
+// setRolloutPaused pauses or resumes the rollout of the given deployment.
+func (r *ingressRouteUpdater) setRolloutPaused(ctx context.Context, deployment *appsv1.Deployment, paused bool) error {
+	if deployment.Spec.Paused == paused {
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]any{"spec": map[string]any{"paused": paused}})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.deploymentInterface.Patch(ctx, deployment.Name, types.MergePatchType, data, v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set the paused rollout of deployment [%s] to [%t]: %w", deployment.Name, paused, err)
+	}
+
+	deployment.Spec.Paused = paused
+	return nil
+}
+
+func equalCanaryStates(a canaryState, b canaryState) bool {
+	aData, _ := json.Marshal(a)
+	bData, _ := json.Marshal(b)
+	return string(aData) == string(bData)
+}
+
+// getPodVersions returns the dogu versions of the pods of the given service mapped to true if at least one pod of the
+// version is ready.
+func (r *ingressRouteUpdater) getPodVersions(ctx context.Context, service *corev1.Service) (map[string]bool, error) {
+	podVersions := map[string]bool{}
+	if len(service.Spec.Selector) == 0 {
+		return podVersions, nil
+	}
+
+	selector := labels.SelectorFromSet(service.Spec.Selector).String()
+	pods, err := r.podInterface.List(ctx, v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of service [%s]: %w", service.Name, err)
+	}
+
+	for _, pod := range pods.Items {
+		version := pod.Labels[doguVersionLabel]
+		if version != "" {
+			podVersions[version] = podVersions[version] || isPodReady(pod)
+		}
+	}
+
+	return podVersions, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// storeCanaryState applies the version services of the given canary state and stores the state in the annotation of
+// the given service. A nil state removes the canary.
+func (r *ingressRouteUpdater) storeCanaryState(ctx context.Context, service *corev1.Service, state *canaryState) error {
+	var err error
+	if state != nil && state.isRunning() {
+		err = r.applyCanaryServices(ctx, service, *state)
+	} else {
+		err = r.deleteCanaryServices(ctx, service)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var value any
+	if state != nil {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal canary state of service [%s]: %w", service.Name, err)
+		}
+
+		value = string(data)
+	}
+
+	err = r.patchServiceAnnotations(ctx, service, map[string]any{canaryStateAnnotation: value})
+	if err != nil {
+		return fmt.Errorf("failed to patch canary state of service [%s]: %w", service.Name, err)
+	}
+
+	// the updated service is routed afterward, so the routes use the new weights right away
+	if service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+
+	if value == nil {
+		delete(service.Annotations, canaryStateAnnotation)
+	} else {
+		service.Annotations[canaryStateAnnotation] = value.(string)
+	}
+
+	return nil
+}
+
+func (r *ingressRouteUpdater) applyCanaryServices(ctx context.Context, service *corev1.Service, state canaryState) error {
+	versions := map[string]string{stableServiceSuffix: state.StableVersion, canaryServiceSuffix: state.CanaryVersion}
+	for _, suffix := range []string{stableServiceSuffix, canaryServiceSuffix} {
+		versionService := r.getCanaryService(service, suffix, versions[suffix])
+		_, err := util.ServerSideApply[*corev1.Service](ctx, r.serviceInterface, versionService, r.eventRecorder)
+		if err != nil {
+			return fmt.Errorf("failed to upsert canary service %s: %w", versionService.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ingressRouteUpdater) deleteCanaryServices(ctx context.Context, service *corev1.Service) error {
+	for _, suffix := range []string{stableServiceSuffix, canaryServiceSuffix} {
+		name := getCanaryServiceName(service.Name, suffix)
+		err := r.serviceInterface.Delete(ctx, name, v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete canary service %s: %w", name, err)
+		}
+	}
+
+	ctrl.LoggerFrom(ctx).V(1).Info(fmt.Sprintf("Deleted canary services of service [%s]", service.Name))
+	return nil
+}
+
+// getCanaryService returns the service with the given suffix which selects the pods of the given dogu version of the
+// given dogu service.
+func (r *ingressRouteUpdater) getCanaryService(service *corev1.Service, suffix string, version string) *corev1.Service {
+	selector := maps.Clone(service.Spec.Selector)
+	selector[doguVersionLabel] = version
+
+	ports := make([]corev1.ServicePort, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		ports = append(ports, corev1.ServicePort{Name: port.Name, Protocol: port.Protocol, Port: port.Port, TargetPort: port.TargetPort})
+	}
+
+	return &corev1.Service{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      getCanaryServiceName(service.Name, suffix),
+			Namespace: r.namespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+}
+
+// getWeightedServices returns the services of the traefik service of the given ces service with the backend config of
+// the ces service. A running canary splits the requests between the stable and the canary version of the dogu by the
+// weight of the canary.
+func (r *ingressRouteUpdater) getWeightedServices(cesService resolvedCesService, service *corev1.Service, serversTransportName string) []traefikapi.Service {
+	getService := func(name string, weight *int) traefikapi.Service {
+		return traefikapi.Service{LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+			Name:             name,
+			Namespace:        r.namespace,
+			Port:             intstr.FromInt32(int32(cesService.Port)),
+			Scheme:           cesService.backend.scheme,
+			Strategy:         dynamic.BalancerStrategy(cesService.backend.strategy),
+			Sticky:           cesService.backend.getSticky(),
+			ServersTransport: serversTransportName,
+			Weight:           weight,
+		}}
+	}
+
+	state, ok := getCanaryState(service)
+	if !ok || !state.isRunning() {
+		return []traefikapi.Service{getService(service.Name, nil)}
+	}
+
+	var services []traefikapi.Service
+	if stableWeight := 100 - state.Weight; stableWeight > 0 {
+		services = append(services, getService(getCanaryServiceName(service.Name, stableServiceSuffix), &stableWeight))
+	}
+
+	if canaryWeight := state.Weight; canaryWeight > 0 {
+		services = append(services, getService(getCanaryServiceName(service.Name, canaryServiceSuffix), &canaryWeight))
+	}
+
+	return services
+}
@@ -0,0 +1,747 @@
+package expose
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func Test_getCanaryConfig(t *testing.T) {
+	t.Run("should disable the canary without weights", func(t *testing.T) {
+		// when
+		actual, err := getCanaryConfig(config.CreateDoguConfig("jenkins", config.Entries{}))
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, actual.isEnabled())
+		assert.Equal(t, time.Minute, actual.stepInterval)
+	})
+	t.Run("should parse the weights and the step interval", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("jenkins", config.Entries{
+			"ingress/canary/weights":       "10, 25,50",
+			"ingress/canary/step_interval": "5m",
+		})
+
+		// when
+		actual, err := getCanaryConfig(doguConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, canaryConfig{weights: []int{10, 25, 50}, stepInterval: 5 * time.Minute}, actual)
+	})
+	t.Run("should fail for weights which are not ascending", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("jenkins", config.Entries{"ingress/canary/weights": "50,25"})
+
+		// when
+		_, err := getCanaryConfig(doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [50,25] of dogu config key [ingress/canary/weights]: expected ascending percentages between 1 and 99")
+	})
+	t.Run("should fail for a weight of all requests", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("jenkins", config.Entries{"ingress/canary/weights": "50,100"})
+
+		// when
+		_, err := getCanaryConfig(doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [50,100] of dogu config key [ingress/canary/weights]")
+	})
+	t.Run("should fail for invalid step interval", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("jenkins", config.Entries{
+			"ingress/canary/weights":       "50",
+			"ingress/canary/step_interval": "soon",
+		})
+
+		// when
+		_, err := getCanaryConfig(doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [soon] of dogu config key [ingress/canary/step_interval]: expected duration")
+	})
+}
+
+func Test_ingressRouteUpdater_advanceCanary(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: testNamespace}}
+	canaryConf := canaryConfig{weights: []int{10, 50}, stepInterval: time.Minute}
+	bothReady := map[string]bool{"1.0.0": true, "2.0.0": true}
+	started := func(ago time.Duration) *metav1.Time {
+		return &metav1.Time{Time: time.Now().Add(-ago)}
+	}
+
+	t.Run("should store the ready version without upgrade", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, canaryState{}, "1.0.0", map[string]bool{"1.0.0": true}, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "1.0.0"}, actual)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should start the first step as soon as the canary version is ready", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, canaryState{StableVersion: "1.0.0"}, "2.0.0", bothReady, canaryConf)
+
+		// then
+		assert.Equal(t, "1.0.0", actual.StableVersion)
+		assert.Equal(t, "2.0.0", actual.CanaryVersion)
+		assert.Equal(t, 10, actual.Weight)
+		assert.NotNil(t, actual.StepStartedAt)
+		assert.Equal(t, time.Minute, requeueAfter)
+	})
+	t.Run("should wait for the readiness of the canary version", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, canaryState{StableVersion: "1.0.0"}, "2.0.0", map[string]bool{"1.0.0": true}, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0"}, actual)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should wait for the readiness of the started canary pods", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, canaryState{StableVersion: "1.0.0"}, "2.0.0", map[string]bool{"1.0.0": true, "2.0.0": false}, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0"}, actual)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should wait until the step interval elapsed", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Weight: 10, StepStartedAt: started(30 * time.Second)}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, state, "2.0.0", bothReady, canaryConf)
+
+		// then
+		assert.Equal(t, state, actual)
+		assert.InDelta(t, 30*time.Second, requeueAfter, float64(time.Second))
+	})
+	t.Run("should shift the weight after the step interval", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Weight: 10, StepStartedAt: started(2 * time.Minute)}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, state, "2.0.0", bothReady, canaryConf)
+
+		// then
+		assert.Equal(t, 1, actual.Step)
+		assert.Equal(t, 50, actual.Weight)
+		assert.Equal(t, time.Minute, requeueAfter)
+	})
+	t.Run("should release the rollout after the last step", func(t *testing.T) {
+		// given
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Normal", "CanaryRelease", "Released the rollout of canary version [%s] of service [%s]: the last step of the canary finished.", "2.0.0", "jenkins")
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{eventRecorder: recorderMock}}
+		stepStartedAt := started(2 * time.Minute)
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Step: 1, Weight: 50, StepStartedAt: stepStartedAt, HoldsRollout: true}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, state, "2.0.0", bothReady, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Step: 1, Weight: 100, StepStartedAt: stepStartedAt, Released: true, HoldsRollout: true}, actual)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should wait for the released rollout", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Step: 1, Weight: 100, StepStartedAt: started(time.Hour), Released: true}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, state, "2.0.0", bothReady, canaryConf)
+
+		// then
+		assert.Equal(t, state, actual)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should promote the canary version when the rollout replaced the stable version", func(t *testing.T) {
+		// given
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Normal", "CanaryPromotion", "Promoted canary version [%s] of service [%s] to the stable version: %s.", "2.0.0", "jenkins", "the rollout of the deployment finished")
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{eventRecorder: recorderMock}}
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Weight: 10, StepStartedAt: started(time.Second)}
+
+		// when
+		actual, _ := sut.advanceCanary(service, state, "2.0.0", map[string]bool{"2.0.0": true}, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "2.0.0"}, actual)
+	})
+	t.Run("should roll back if the canary version loses its readiness", func(t *testing.T) {
+		// given
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Warning", "CanaryRollback", "Rolled back canary version [%s] of service [%s]: the canary version has no ready pod.", "2.0.0", "jenkins")
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{eventRecorder: recorderMock}}
+		stepStartedAt := started(time.Second)
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Step: 1, Weight: 100, StepStartedAt: stepStartedAt, Released: true}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, state, "2.0.0", map[string]bool{"1.0.0": true, "2.0.0": false}, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Step: 1, StepStartedAt: stepStartedAt, RolledBack: true}, actual)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should stay rolled back if the canary version is ready again", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", StepStartedAt: started(time.Hour), RolledBack: true}
+
+		// when
+		actual, requeueAfter := sut.advanceCanary(service, state, "2.0.0", bothReady, canaryConf)
+
+		// then
+		assert.Equal(t, state, actual)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should restart the canary for another upgrade", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", RolledBack: true}
+
+		// when
+		actual, _ := sut.advanceCanary(service, state, "2.0.1", map[string]bool{"1.0.0": true}, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.1"}, actual)
+	})
+	t.Run("should stop the canary if the upgrade is reverted", func(t *testing.T) {
+		// given
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{}}
+		state := canaryState{StableVersion: "1.0.0", CanaryVersion: "2.0.0", Weight: 10, StepStartedAt: started(time.Second)}
+
+		// when
+		actual, _ := sut.advanceCanary(service, state, "1.0.0", bothReady, canaryConf)
+
+		// then
+		assert.Equal(t, canaryState{StableVersion: "1.0.0"}, actual)
+	})
+}
+
+func Test_ingressUpdater_UpdateCanary(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Labels: map[string]string{"dogu.name": "jenkins"}}}
+
+	t.Run("should warn about the ignored canary config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(config.CreateDoguConfig("jenkins", config.Entries{"ingress/canary/weights": "10,50"}), nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Warning", "IgnoredRoutingConfig", "Ignored canary config of service [%s]: weighted canaries require the routing mode ingressroute.", "jenkins")
+
+		sut := &ingressUpdater{doguConfigRepository: doguConfigRepoMock, eventRecorder: recorderMock}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, service, &appsv1.Deployment{})
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should do nothing without canary config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(config.CreateDoguConfig("jenkins", nil), nil)
+
+		sut := &ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, service, &appsv1.Deployment{})
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should return error when getting the dogu config fails", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(config.DoguConfig{}, assert.AnError)
+
+		sut := &ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		_, err := sut.UpdateCanary(testCtx, service, &appsv1.Deployment{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_ingressRouteUpdater_UpdateCanary(t *testing.T) {
+	getService := func(annotations map[string]string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "jenkins",
+				Namespace:   testNamespace,
+				UID:         "uid",
+				Labels:      map[string]string{"dogu.name": "jenkins"},
+				Annotations: annotations,
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"dogu.name": "jenkins"},
+				Ports:    []corev1.ServicePort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 8080, TargetPort: intstr.FromInt32(8080)}},
+			},
+		}
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: testNamespace}, Spec: appsv1.DeploymentSpec{
+		Replicas: ptr.To(int32(2)),
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"dogu.name": "jenkins", "dogu.version": "2.0.0"}},
+		},
+	}}
+	getPod := func(version string, ready corev1.ConditionStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"dogu.name": "jenkins", "dogu.version": version}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	enabledConfig := config.CreateDoguConfig("jenkins", config.Entries{"ingress/canary/weights": "10,50", "ingress/canary/step_interval": "2m"})
+
+	t.Run("should do nothing without canary config and state", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(config.CreateDoguConfig("jenkins", nil), nil)
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock}}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, getService(nil), deployment)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should start the canary of an upgraded dogu", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0"}`})
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		podInterfaceMock := newMockPodInterface(t)
+		podInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: "dogu.name=jenkins"}).
+			Return(&corev1.PodList{Items: []corev1.Pod{getPod("1.0.0", corev1.ConditionTrue), getPod("2.0.0", corev1.ConditionTrue), getPod("2.0.0", corev1.ConditionFalse)}}, nil)
+		serviceInterfaceMock := newMockServiceInterface(t)
+		expectApplyService(t, serviceInterfaceMock, getTestCanaryService("jenkins-stable", service, "1.0.0"))
+		expectApplyService(t, serviceInterfaceMock, getTestCanaryService("jenkins-canary", service, "2.0.0"))
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(service, nil)
+		deploymentInterfaceMock := newMockDeploymentInterface(t)
+		deploymentInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType, []byte(`{"spec":{"paused":true}}`), metav1.PatchOptions{}).Return(nil, nil)
+		upgradedDeployment := deployment.DeepCopy()
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater:      &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock, serviceInterface: serviceInterfaceMock},
+			podInterface:        podInterfaceMock,
+			deploymentInterface: deploymentInterfaceMock,
+		}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, service, upgradedDeployment)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, requeueAfter)
+		state, ok := getCanaryState(service)
+		require.True(t, ok)
+		assert.Equal(t, "2.0.0", state.CanaryVersion)
+		assert.Equal(t, 10, state.Weight)
+		assert.True(t, state.HoldsRollout)
+		assert.True(t, upgradedDeployment.Spec.Paused)
+	})
+	t.Run("should resume the rollout after the last step", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0","canaryVersion":"2.0.0","step":1,"weight":50,"stepStartedAt":"2020-01-01T00:00:00Z","holdsRollout":true}`})
+		pausedDeployment := deployment.DeepCopy()
+		pausedDeployment.Spec.Paused = true
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		podInterfaceMock := newMockPodInterface(t)
+		podInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: "dogu.name=jenkins"}).
+			Return(&corev1.PodList{Items: []corev1.Pod{getPod("1.0.0", corev1.ConditionTrue), getPod("2.0.0", corev1.ConditionTrue)}}, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Normal", "CanaryRelease", "Released the rollout of canary version [%s] of service [%s]: the last step of the canary finished.", "2.0.0", "jenkins")
+		serviceInterfaceMock := newMockServiceInterface(t)
+		expectApplyService(t, serviceInterfaceMock, getTestCanaryService("jenkins-stable", service, "1.0.0"))
+		expectApplyService(t, serviceInterfaceMock, getTestCanaryService("jenkins-canary", service, "2.0.0"))
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(service, nil)
+		deploymentInterfaceMock := newMockDeploymentInterface(t)
+		deploymentInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType, []byte(`{"spec":{"paused":false}}`), metav1.PatchOptions{}).Return(nil, nil)
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater: &ingressUpdater{
+				namespace:            testNamespace,
+				doguConfigRepository: doguConfigRepoMock,
+				serviceInterface:     serviceInterfaceMock,
+				eventRecorder:        recorderMock,
+			},
+			podInterface:        podInterfaceMock,
+			deploymentInterface: deploymentInterfaceMock,
+		}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, service, pausedDeployment)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+		state, ok := getCanaryState(service)
+		require.True(t, ok)
+		assert.Equal(t, 100, state.Weight)
+		assert.True(t, state.Released)
+		assert.False(t, state.HoldsRollout)
+		assert.False(t, pausedDeployment.Spec.Paused)
+	})
+	t.Run("should not hold the rollout of a deployment paused by someone else", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0"}`})
+		pausedDeployment := deployment.DeepCopy()
+		pausedDeployment.Spec.Paused = true
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		podInterfaceMock := newMockPodInterface(t)
+		podInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: "dogu.name=jenkins"}).
+			Return(&corev1.PodList{Items: []corev1.Pod{getPod("1.0.0", corev1.ConditionTrue), getPod("2.0.0", corev1.ConditionFalse)}}, nil)
+		serviceInterfaceMock := newMockServiceInterface(t)
+		expectApplyService(t, serviceInterfaceMock, getTestCanaryService("jenkins-stable", service, "1.0.0"))
+		expectApplyService(t, serviceInterfaceMock, getTestCanaryService("jenkins-canary", service, "2.0.0"))
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType, mock.Anything, metav1.PatchOptions{}).Return(service, nil)
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater: &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock, serviceInterface: serviceInterfaceMock},
+			podInterface:   podInterfaceMock,
+		}
+
+		// when
+		_, err := sut.UpdateCanary(testCtx, service, pausedDeployment)
+
+		// then
+		require.NoError(t, err)
+		state, ok := getCanaryState(service)
+		require.True(t, ok)
+		assert.False(t, state.HoldsRollout)
+		assert.True(t, pausedDeployment.Spec.Paused)
+	})
+	t.Run("should fail to pause the rollout", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0"}`})
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		podInterfaceMock := newMockPodInterface(t)
+		podInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: "dogu.name=jenkins"}).
+			Return(&corev1.PodList{Items: []corev1.Pod{getPod("1.0.0", corev1.ConditionTrue), getPod("2.0.0", corev1.ConditionFalse)}}, nil)
+		deploymentInterfaceMock := newMockDeploymentInterface(t)
+		deploymentInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType, []byte(`{"spec":{"paused":true}}`), metav1.PatchOptions{}).Return(nil, assert.AnError)
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater:      &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock},
+			podInterface:        podInterfaceMock,
+			deploymentInterface: deploymentInterfaceMock,
+		}
+
+		// when
+		_, err := sut.UpdateCanary(testCtx, service, deployment.DeepCopy())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to set the paused rollout of deployment [jenkins] to [true]")
+	})
+	t.Run("should not patch the service with unchanged state", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"2.0.0"}`})
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		podInterfaceMock := newMockPodInterface(t)
+		podInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: "dogu.name=jenkins"}).
+			Return(&corev1.PodList{Items: []corev1.Pod{getPod("2.0.0", corev1.ConditionTrue)}}, nil)
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater: &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock},
+			podInterface:   podInterfaceMock,
+		}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, service, deployment)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should remove the canary if the canary config is removed", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0","canaryVersion":"2.0.0","weight":10}`})
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(config.CreateDoguConfig("jenkins", nil), nil)
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Delete(testCtx, "jenkins-stable", metav1.DeleteOptions{}).Return(nil)
+		serviceInterfaceMock.EXPECT().Delete(testCtx, "jenkins-canary", metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "jenkins-canary"))
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"k8s-service-discovery.cloudogu.com/canary":null}}}`), metav1.PatchOptions{}).Return(service, nil)
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock, serviceInterface: serviceInterfaceMock}}
+
+		// when
+		_, err := sut.UpdateCanary(testCtx, service, deployment)
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, service.Annotations, canaryStateAnnotation)
+	})
+	t.Run("should resume the held rollout if the canary config is removed", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0","canaryVersion":"2.0.0","weight":10,"holdsRollout":true}`})
+		pausedDeployment := deployment.DeepCopy()
+		pausedDeployment.Spec.Paused = true
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(config.CreateDoguConfig("jenkins", nil), nil)
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Delete(testCtx, "jenkins-stable", metav1.DeleteOptions{}).Return(nil)
+		serviceInterfaceMock.EXPECT().Delete(testCtx, "jenkins-canary", metav1.DeleteOptions{}).Return(nil)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"k8s-service-discovery.cloudogu.com/canary":null}}}`), metav1.PatchOptions{}).Return(service, nil)
+		deploymentInterfaceMock := newMockDeploymentInterface(t)
+		deploymentInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType, []byte(`{"spec":{"paused":false}}`), metav1.PatchOptions{}).Return(nil, nil)
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater:      &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock, serviceInterface: serviceInterfaceMock},
+			deploymentInterface: deploymentInterfaceMock,
+		}
+
+		// when
+		_, err := sut.UpdateCanary(testCtx, service, pausedDeployment)
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, service.Annotations, canaryStateAnnotation)
+		assert.False(t, pausedDeployment.Spec.Paused)
+	})
+	t.Run("should refuse and remove the canary of a deployment with the strategy Recreate", func(t *testing.T) {
+		// given
+		service := getService(map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0","canaryVersion":"2.0.0","weight":10}`})
+		recreateDeployment := deployment.DeepCopy()
+		recreateDeployment.Spec.Strategy.Type = appsv1.RecreateDeploymentStrategyType
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Warning", "CanaryRefused", "Refused the canary of service [%s]: %s.", "jenkins", "the deployment has the strategy Recreate")
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Delete(testCtx, "jenkins-stable", metav1.DeleteOptions{}).Return(nil)
+		serviceInterfaceMock.EXPECT().Delete(testCtx, "jenkins-canary", metav1.DeleteOptions{}).Return(nil)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "jenkins", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"k8s-service-discovery.cloudogu.com/canary":null}}}`), metav1.PatchOptions{}).Return(service, nil)
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{
+			namespace:            testNamespace,
+			doguConfigRepository: doguConfigRepoMock,
+			serviceInterface:     serviceInterfaceMock,
+			eventRecorder:        recorderMock,
+		}}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, service, recreateDeployment)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+		assert.NotContains(t, service.Annotations, canaryStateAnnotation)
+	})
+	t.Run("should refuse the canary of a deployment with a single replica", func(t *testing.T) {
+		// given
+		service := getService(nil)
+		singleReplicaDeployment := deployment.DeepCopy()
+		singleReplicaDeployment.Spec.Replicas = nil
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Warning", "CanaryRefused", "Refused the canary of service [%s]: %s.", "jenkins", "the deployment has less than two replicas")
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock, eventRecorder: recorderMock}}
+
+		// when
+		requeueAfter, err := sut.UpdateCanary(testCtx, service, singleReplicaDeployment)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+	})
+	t.Run("should fail for invalid canary config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(config.CreateDoguConfig("jenkins", config.Entries{"ingress/canary/weights": "ten"}), nil)
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock}}
+
+		// when
+		_, err := sut.UpdateCanary(testCtx, getService(nil), deployment)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid canary config of service [jenkins]")
+	})
+	t.Run("should fail to list the pods", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("jenkins")).Return(enabledConfig, nil)
+		podInterfaceMock := newMockPodInterface(t)
+		podInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: "dogu.name=jenkins"}).Return(nil, assert.AnError)
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater: &ingressUpdater{namespace: testNamespace, doguConfigRepository: doguConfigRepoMock},
+			podInterface:   podInterfaceMock,
+		}
+
+		// when
+		_, err := sut.UpdateCanary(testCtx, getService(nil), deployment)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list pods of service [jenkins]")
+	})
+}
+
+func Test_ingressRouteUpdater_getWeightedServices(t *testing.T) {
+	cesService := resolvedCesService{CesService: CesService{Name: "jenkins", Port: 8080}}
+	getLoadBalancer := func(name string, weight *int) traefikapi.Service {
+		return traefikapi.Service{LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+			Name:      name,
+			Namespace: testNamespace,
+			Port:      intstr.FromInt32(8080),
+			Weight:    weight,
+		}}
+	}
+	weight := func(value int) *int {
+		return &value
+	}
+
+	t.Run("should route to the service without canary", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Annotations: map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0"}`}}}
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}}
+
+		// when
+		actual := sut.getWeightedServices(cesService, service, "")
+
+		// then
+		assert.Equal(t, []traefikapi.Service{getLoadBalancer("jenkins", nil)}, actual)
+	})
+	t.Run("should split the requests between the versions of the canary", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Annotations: map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0","canaryVersion":"2.0.0","weight":25}`}}}
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}}
+
+		// when
+		actual := sut.getWeightedServices(cesService, service, "")
+
+		// then
+		assert.Equal(t, []traefikapi.Service{getLoadBalancer("jenkins-stable", weight(75)), getLoadBalancer("jenkins-canary", weight(25))}, actual)
+	})
+	t.Run("should set the balancing strategy and the sticky cookie of the backend", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins"}}
+		backendCesService := resolvedCesService{CesService: CesService{Name: "jenkins", Port: 8080}, backend: backendConfig{strategy: "p2c", stickyCookie: &StickyCookie{Name: "jenkins_lb", HTTPOnly: true}}}
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}}
+
+		// when
+		actual := sut.getWeightedServices(backendCesService, service, "")
+
+		// then
+		expected := getLoadBalancer("jenkins", nil)
+		expected.Strategy = "p2c"
+		expected.Sticky = &dynamic.Sticky{Cookie: &dynamic.Cookie{Name: "jenkins_lb", HTTPOnly: true}}
+		assert.Equal(t, []traefikapi.Service{expected}, actual)
+	})
+	t.Run("should route to the stable version until the canary starts", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Annotations: map[string]string{canaryStateAnnotation: `{"stableVersion":"1.0.0","canaryVersion":"2.0.0"}`}}}
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}}
+
+		// when
+		actual := sut.getWeightedServices(cesService, service, "")
+
+		// then
+		assert.Equal(t, []traefikapi.Service{getLoadBalancer("jenkins-stable", weight(100))}, actual)
+	})
+}
+
+func getTestCanaryService(name string, service *corev1.Service, version string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"dogu.name": service.Name, "dogu.version": version},
+			Ports:    service.Spec.Ports,
+		},
+	}
+}
+
+func expectApplyService(t *testing.T, serviceInterfaceMock *mockServiceInterface, expectedService *corev1.Service) {
+	serviceInterfaceMock.EXPECT().Get(testCtx, expectedService.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedService.Name))
+	serviceInterfaceMock.EXPECT().Patch(testCtx, expectedService.Name, types.ApplyPatchType, mock.Anything, metav1.PatchOptions{FieldManager: "k8s-service-discovery"}).
+		Return(nil, nil).
+		Run(func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+			appliedService := &corev1.Service{}
+			require.NoError(t, json.Unmarshal(data, appliedService))
+			assert.Equal(t, expectedService, appliedService)
+		})
+}
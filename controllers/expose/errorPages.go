@@ -0,0 +1,104 @@
+package expose
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+const (
+	// doguConfigErrorPageStatusKey is the dogu config key which overrides the status ranges of the error pages of a
+	// ces service, e.g., `ingress/nexus/error_page_status`.
+	doguConfigErrorPageStatusKey = "ingress/%s/error_page_status"
+	// defaultErrorPageStatus are the status ranges of a dogu answered by the error pages by default, i.e., the errors
+	// of an unavailable dogu.
+	defaultErrorPageStatus = "502-504"
+	// errorPageStatusNone disables the error pages of a ces service.
+	errorPageStatusNone = "none"
+	// errorPageQuery is the path of the error page of the static content backend. The placeholder `{status}` is
+	// replaced by traefik.
+	errorPageQuery = "/errors/{status}.html?dogu=%s"
+)
+
+const errorPagesMiddlewareSuffix = "errors"
+
+var errorPageStatusRegex = regexp.MustCompile(`^(\d{3})(?:-(\d{3}))?$`)
+
+// errorPages replace the error responses of a dogu with the given status ranges by the error pages of the static
+// content backend, which show the failed dogu.
+type errorPages struct {
+	status []string
+	dogu   string
+}
+
+// resolveErrorPages returns the error pages of the given ces service of the given dogu. The status ranges of the ces
+// service are overridden by the dogu config and default to `502-504`. The status ranges `none` disable the error
+// pages.
+func resolveErrorPages(cesService CesService, doguConfig libconfig.DoguConfig, dogu string) (errorPages, error) {
+	status := cesService.ErrorPageStatus
+	source := fmt.Sprintf("ces service [%s]", cesService.Name)
+	key := libconfig.Key(fmt.Sprintf(doguConfigErrorPageStatusKey, cesService.Name))
+	if value, ok := doguConfig.Get(key); ok {
+		status = value.String()
+		source = fmt.Sprintf("dogu config key [%s]", key)
+	}
+
+	status = strings.TrimSpace(status)
+	if status == "" {
+		status = defaultErrorPageStatus
+	}
+
+	if status == errorPageStatusNone {
+		return errorPages{}, nil
+	}
+
+	ranges, err := parseErrorPageStatus(status)
+	if err != nil {
+		return errorPages{}, fmt.Errorf("invalid error page status of %s: %w", source, err)
+	}
+
+	return errorPages{status: ranges, dogu: dogu}, nil
+}
+
+// parseErrorPageStatus parses comma separated status codes and ranges, e.g., `500,502-504`.
+func parseErrorPageStatus(status string) ([]string, error) {
+	var ranges []string
+	for _, statusRange := range strings.Split(status, ",") {
+		statusRange = strings.TrimSpace(statusRange)
+		match := errorPageStatusRegex.FindStringSubmatch(statusRange)
+		if match == nil {
+			return nil, fmt.Errorf("invalid status range [%s]", statusRange)
+		}
+
+		from, _ := strconv.Atoi(match[1])
+		to := from
+		if match[2] != "" {
+			to, _ = strconv.Atoi(match[2])
+		}
+
+		if from < 100 || to > 599 || from > to {
+			return nil, fmt.Errorf("invalid status range [%s]", statusRange)
+		}
+
+		ranges = append(ranges, statusRange)
+	}
+
+	return ranges, nil
+}
+
+func (e errorPages) isEnabled() bool {
+	return len(e.status) > 0
+}
+
+func (e errorPages) getMiddleware() traefikapi.MiddlewareSpec {
+	return traefikapi.MiddlewareSpec{Errors: &traefikapi.ErrorPage{
+		Status:  e.status,
+		Service: getStaticContentService(),
+		Query:   fmt.Sprintf(errorPageQuery, url.QueryEscape(e.dogu)),
+	}}
+}
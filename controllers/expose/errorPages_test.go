@@ -0,0 +1,86 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_resolveErrorPages(t *testing.T) {
+	t.Run("should default to the errors of an unavailable dogu", func(t *testing.T) {
+		// when
+		actual, err := resolveErrorPages(CesService{Name: "nexus"}, config.DoguConfig{}, "nexus")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, errorPages{status: []string{"502-504"}, dogu: "nexus"}, actual)
+	})
+	t.Run("should use the status ranges of the ces service", func(t *testing.T) {
+		// when
+		actual, err := resolveErrorPages(CesService{Name: "nexus", ErrorPageStatus: "500, 502-504"}, config.DoguConfig{}, "nexus")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, errorPages{status: []string{"500", "502-504"}, dogu: "nexus"}, actual)
+	})
+	t.Run("should override the status ranges with the dogu config", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("nexus", config.Entries{"ingress/nexus/error_page_status": "503"})
+
+		// when
+		actual, err := resolveErrorPages(CesService{Name: "nexus", ErrorPageStatus: "500-599"}, doguConfig, "nexus")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, errorPages{status: []string{"503"}, dogu: "nexus"}, actual)
+	})
+	t.Run("should opt out with none", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("nexus", config.Entries{"ingress/nexus/error_page_status": "none"})
+
+		// when
+		actual, err := resolveErrorPages(CesService{Name: "nexus"}, doguConfig, "nexus")
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, actual.isEnabled())
+	})
+	t.Run("should fail for invalid status ranges of the ces service", func(t *testing.T) {
+		// when
+		_, err := resolveErrorPages(CesService{Name: "nexus", ErrorPageStatus: "504-502"}, config.DoguConfig{}, "nexus")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid error page status of ces service [nexus]: invalid status range [504-502]")
+	})
+	t.Run("should fail for invalid status ranges of the dogu config", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("nexus", config.Entries{"ingress/nexus/error_page_status": "5xx"})
+
+		// when
+		_, err := resolveErrorPages(CesService{Name: "nexus"}, doguConfig, "nexus")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid error page status of dogu config key [ingress/nexus/error_page_status]: invalid status range [5xx]")
+	})
+}
+
+func Test_errorPages_getMiddleware(t *testing.T) {
+	// when
+	actual := errorPages{status: []string{"502-504"}, dogu: "nexus"}.getMiddleware()
+
+	// then
+	assert.Equal(t, &traefikapi.ErrorPage{
+		Status: []string{"502-504"},
+		Service: traefikapi.Service{LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+			Name: "k8s-ces-assets-service",
+			Port: intstr.FromInt32(80),
+		}},
+		Query: "/errors/{status}.html?dogu=nexus",
+	}, actual.Errors)
+}
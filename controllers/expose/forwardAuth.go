@@ -0,0 +1,96 @@
+package expose
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+const (
+	// GlobalConfigForwardAuthAddressKey is the global config key with the address of the auth endpoint of the forward
+	// auth middleware, e.g., `http://auth-proxy.ecosystem.svc.cluster.local/auth`.
+	GlobalConfigForwardAuthAddressKey = "ingress/security/forward_auth_address"
+	// GlobalConfigForwardAuthResponseHeadersKey is the global config key with the comma separated headers of the
+	// response of the auth endpoint which are passed to the dogu, e.g., `X-Forwarded-User,X-Forwarded-Groups`.
+	GlobalConfigForwardAuthResponseHeadersKey = "ingress/security/forward_auth_response_headers"
+	// doguConfigForwardAuthKey is the dogu config key which enables or disables the forward auth of a ces service,
+	// e.g., `ingress/dashboard/forward_auth`.
+	doguConfigForwardAuthKey = "ingress/%s/forward_auth"
+)
+
+// forwardAuthMiddlewareName is the name of the forward auth middleware shared by all ces services with forward auth.
+const forwardAuthMiddlewareName = "forward-auth"
+
+// getForwardAuthMiddleware returns the forward auth middleware of the given global config or nil if no auth endpoint
+// is configured.
+func getForwardAuthMiddleware(globalConfig libconfig.GlobalConfig) (*globalMiddleware, error) {
+	address := getGlobalConfigString(globalConfig, GlobalConfigForwardAuthAddressKey)
+	if address == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(address)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid value [%s] of global config key [%s]: expected http(s) url", address, GlobalConfigForwardAuthAddressKey)
+	}
+
+	var responseHeaders []string
+	for _, header := range strings.Split(getGlobalConfigString(globalConfig, GlobalConfigForwardAuthResponseHeadersKey), ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			responseHeaders = append(responseHeaders, header)
+		}
+	}
+
+	return &globalMiddleware{
+		name: forwardAuthMiddlewareName,
+		spec: traefikapi.MiddlewareSpec{ForwardAuth: &traefikapi.ForwardAuth{
+			Address:             address,
+			AuthResponseHeaders: responseHeaders,
+		}},
+	}, nil
+}
+
+// GetForwardAuthMiddlewareRef returns the reference of the forward auth middleware of the given global config or an
+// empty string if no auth endpoint is configured.
+func GetForwardAuthMiddlewareRef(namespace string, globalConfig libconfig.GlobalConfig) (string, error) {
+	middleware, err := getForwardAuthMiddleware(globalConfig)
+	if err != nil || middleware == nil {
+		return "", err
+	}
+
+	return getCRDMiddlewareRef(namespace, middleware.name), nil
+}
+
+// resolveForwardAuth returns the reference of the forward auth middleware if the forward auth of the given ces
+// service is enabled by the ces service or the dogu config. Enabled forward auth without auth endpoint or ingress
+// controller with middlewares fails, so the ces service is never exposed unprotected.
+func (i *ingressUpdater) resolveForwardAuth(cesService CesService, doguConfig libconfig.DoguConfig, forwardAuthMiddleware string) (string, error) {
+	enabled := cesService.ForwardAuth
+	key := libconfig.Key(fmt.Sprintf(doguConfigForwardAuthKey, cesService.Name))
+	if value, ok := doguConfig.Get(key); ok {
+		parsed, err := strconv.ParseBool(value.String())
+		if err != nil {
+			return "", fmt.Errorf("invalid value [%s] of dogu config key [%s]: expected boolean", value, key)
+		}
+
+		enabled = parsed
+	}
+
+	if !enabled {
+		return "", nil
+	}
+
+	if !i.controller.UsesMiddlewares() {
+		return "", fmt.Errorf("forward auth of ces service [%s] requires an ingress controller with middlewares", cesService.Name)
+	}
+
+	if forwardAuthMiddleware == "" {
+		return "", fmt.Errorf("forward auth of ces service [%s] requires the global config key [%s]", cesService.Name, GlobalConfigForwardAuthAddressKey)
+	}
+
+	return forwardAuthMiddleware, nil
+}
@@ -0,0 +1,164 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+func Test_getForwardAuthMiddleware(t *testing.T) {
+	t.Run("should return nil without auth endpoint", func(t *testing.T) {
+		// when
+		actual, err := getForwardAuthMiddleware(config.CreateGlobalConfig(config.Entries{
+			"ingress/security/forward_auth_response_headers": "X-Forwarded-User",
+		}))
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+	t.Run("should pass the response headers of the auth endpoint", func(t *testing.T) {
+		// when
+		actual, err := getForwardAuthMiddleware(config.CreateGlobalConfig(config.Entries{
+			"ingress/security/forward_auth_address":          "https://cas.example.com/auth",
+			"ingress/security/forward_auth_response_headers": "X-Forwarded-User,,X-Forwarded-Groups",
+		}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &globalMiddleware{
+			name: "forward-auth",
+			spec: traefikapi.MiddlewareSpec{ForwardAuth: &traefikapi.ForwardAuth{
+				Address:             "https://cas.example.com/auth",
+				AuthResponseHeaders: []string{"X-Forwarded-User", "X-Forwarded-Groups"},
+			}},
+		}, actual)
+	})
+	t.Run("should fail for address without http scheme", func(t *testing.T) {
+		// when
+		_, err := getForwardAuthMiddleware(config.CreateGlobalConfig(config.Entries{
+			"ingress/security/forward_auth_address": "ftp://cas.example.com/auth",
+		}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [ftp://cas.example.com/auth] of global config key [ingress/security/forward_auth_address]: expected http(s) url")
+	})
+}
+
+func TestGetForwardAuthMiddlewareRef(t *testing.T) {
+	t.Run("should reference the forward auth middleware in the namespace", func(t *testing.T) {
+		// when
+		actual, err := GetForwardAuthMiddlewareRef(testNamespace, config.CreateGlobalConfig(config.Entries{
+			"ingress/security/forward_auth_address": "http://localhost:4181",
+		}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "my-namespace-forward-auth@kubernetescrd", actual)
+	})
+	t.Run("should return empty reference without auth endpoint", func(t *testing.T) {
+		// when
+		actual, err := GetForwardAuthMiddlewareRef(testNamespace, config.CreateGlobalConfig(config.Entries{}))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func Test_ingressUpdater_resolveForwardAuth(t *testing.T) {
+	const forwardAuthRef = "my-namespace-forward-auth@kubernetescrd"
+
+	t.Run("should not protect ces services without forward auth", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{}
+
+		// when
+		actual, err := sut.resolveForwardAuth(CesService{Name: "dashboard"}, config.DoguConfig{}, forwardAuthRef)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+	t.Run("should protect ces services with forward auth", func(t *testing.T) {
+		// given
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		sut := ingressUpdater{controller: ingressControllerMock}
+
+		// when
+		actual, err := sut.resolveForwardAuth(CesService{Name: "dashboard", ForwardAuth: true}, config.DoguConfig{}, forwardAuthRef)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, forwardAuthRef, actual)
+	})
+	t.Run("should enable the forward auth by the dogu config", func(t *testing.T) {
+		// given
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		sut := ingressUpdater{controller: ingressControllerMock}
+		doguConfig := config.CreateDoguConfig("dashboard", config.Entries{"ingress/dashboard/forward_auth": "true"})
+
+		// when
+		actual, err := sut.resolveForwardAuth(CesService{Name: "dashboard"}, doguConfig, forwardAuthRef)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, forwardAuthRef, actual)
+	})
+	t.Run("should disable the forward auth by the dogu config", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{}
+		doguConfig := config.CreateDoguConfig("dashboard", config.Entries{"ingress/dashboard/forward_auth": "false"})
+
+		// when
+		actual, err := sut.resolveForwardAuth(CesService{Name: "dashboard", ForwardAuth: true}, doguConfig, forwardAuthRef)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+	t.Run("should fail for invalid dogu config", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{}
+		doguConfig := config.CreateDoguConfig("dashboard", config.Entries{"ingress/dashboard/forward_auth": "on"})
+
+		// when
+		_, err := sut.resolveForwardAuth(CesService{Name: "dashboard"}, doguConfig, forwardAuthRef)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [on] of dogu config key [ingress/dashboard/forward_auth]: expected boolean")
+	})
+	t.Run("should fail without auth endpoint", func(t *testing.T) {
+		// given
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		sut := ingressUpdater{controller: ingressControllerMock}
+
+		// when
+		_, err := sut.resolveForwardAuth(CesService{Name: "dashboard", ForwardAuth: true}, config.DoguConfig{}, "")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "forward auth of ces service [dashboard] requires the global config key [ingress/security/forward_auth_address]")
+	})
+	t.Run("should fail for ingress controller without middlewares", func(t *testing.T) {
+		// given
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(false)
+		sut := ingressUpdater{controller: ingressControllerMock}
+
+		// when
+		_, err := sut.resolveForwardAuth(CesService{Name: "dashboard", ForwardAuth: true}, config.DoguConfig{}, forwardAuthRef)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "forward auth of ces service [dashboard] requires an ingress controller with middlewares")
+	})
+}
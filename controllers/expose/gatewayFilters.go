@@ -0,0 +1,200 @@
+package expose
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// refusedRoutingEventReason is the reason of the events about ces services which are not routed, because the routing
+// mode can't enforce their access protections.
+const refusedRoutingEventReason = "RefusedRouting"
+
+// gatewayTranslation contains the http route filters of the routing features of a ces service which the gateway api
+// can express.
+type gatewayTranslation struct {
+	filters []gatewayv1.HTTPRouteFilter
+	// unsupported describes the routing features without gateway api equivalent.
+	unsupported []string
+}
+
+// getGatewayUnenforceableProtections returns the access protections of the ces service which the gateway api can't
+// enforce. Ces services with such protections must not be routed, as they would be exposed unprotected. The forward
+// auth already fails to resolve for ingress controllers without middlewares.
+func (cs resolvedCesService) getGatewayUnenforceableProtections() []string {
+	var protections []string
+	if cs.hasGlobalMiddleware(ipAllowListMiddlewareName) {
+		protections = append(protections, "the global ip allowlist")
+	}
+	if cs.ipAllowList.isEnabled() {
+		protections = append(protections, "the ip allowlist")
+	}
+
+	return protections
+}
+
+func (cs resolvedCesService) hasGlobalMiddleware(name string) bool {
+	return slices.ContainsFunc(cs.globalMiddlewareSpecs, func(middleware globalMiddleware) bool {
+		return middleware.name == name
+	})
+}
+
+// translateForGateway translates the security headers, the header directives of the nginx annotations and the
+// mirroring of the given ces service into the filters of its http route. The path rewrite is not part of the
+// translation, as it differs for the maintenance mode and starting dogus.
+func translateForGateway(cesService resolvedCesService, annotations doguv2.IngressAnnotations, pathPrefix string) gatewayTranslation {
+	translation := gatewayTranslation{}
+	requestHeaders := map[string]string{}
+	responseHeaders := map[string]string{}
+
+	for _, middleware := range cesService.globalMiddlewareSpecs {
+		if middleware.name == securityHeadersMiddlewareName {
+			maps.Copy(responseHeaders, getSecurityResponseHeaders(middleware.spec.Headers))
+		}
+	}
+	if cesService.httpsRedirect {
+		translation.unsupported = append(translation.unsupported, "the global https redirect")
+	}
+
+	nginx, remaining := translateNginxAnnotations(annotations, pathPrefix)
+	if headers, ok := nginx.middlewares[headersMiddlewareSuffix]; ok {
+		maps.Copy(requestHeaders, headers.Headers.CustomRequestHeaders)
+		maps.Copy(responseHeaders, headers.Headers.CustomResponseHeaders)
+	}
+	if nginx.hasRewrite() {
+		translation.unsupported = append(translation.unsupported, fmt.Sprintf("annotation [%s] has no gateway api equivalent", nginxRewriteTargetAnnotation))
+	}
+	if _, ok := nginx.middlewares[bufferingMiddlewareSuffix]; ok {
+		translation.unsupported = append(translation.unsupported, fmt.Sprintf("annotation [%s] has no gateway api equivalent", nginxProxyBodySizeAnnotation))
+	}
+	if nginx.forwardingTimeouts != nil {
+		translation.unsupported = append(translation.unsupported, "the forwarding timeouts of the nginx annotations have no gateway api equivalent")
+	}
+	translation.unsupported = append(translation.unsupported, nginx.untranslated...)
+	for _, key := range slices.Sorted(maps.Keys(remaining)) {
+		translation.unsupported = append(translation.unsupported, fmt.Sprintf("annotation [%s] has no gateway api equivalent", key))
+	}
+
+	if len(requestHeaders) > 0 {
+		translation.filters = append(translation.filters, gatewayv1.HTTPRouteFilter{
+			Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: newHeaderFilter(requestHeaders),
+		})
+	}
+	if len(responseHeaders) > 0 {
+		translation.filters = append(translation.filters, gatewayv1.HTTPRouteFilter{
+			Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: newHeaderFilter(responseHeaders),
+		})
+	}
+
+	if cesService.mirroring.isEnabled() {
+		translation.filters = append(translation.filters, newRequestMirrorFilter(cesService))
+		if cesService.mirroring.MaxBodySize != "" {
+			translation.unsupported = append(translation.unsupported, "the max body size of the mirroring")
+		}
+	}
+
+	translation.unsupported = append(translation.unsupported, cesService.getGatewayUnsupportedFeatures()...)
+	return translation
+}
+
+// getGatewayUnsupportedFeatures describes the traefik middlewares and backend settings of the ces service without
+// gateway api equivalent.
+func (cs resolvedCesService) getGatewayUnsupportedFeatures() []string {
+	var unsupported []string
+	if len(cs.requestLimits.getMiddlewareSuffixes()) > 0 {
+		unsupported = append(unsupported, "the request limits")
+	}
+	if cs.defaultMiddlewares != "" {
+		unsupported = append(unsupported, fmt.Sprintf("the default middlewares [%s]", cs.defaultMiddlewares))
+	}
+	if cs.middlewareOverrides != "" {
+		unsupported = append(unsupported, fmt.Sprintf("the middleware overrides [%s]", cs.middlewareOverrides))
+	}
+	if cs.errorPages.isEnabled() {
+		unsupported = append(unsupported, "the error pages")
+	}
+	if cs.backend.scheme != "" {
+		unsupported = append(unsupported, "the backend scheme")
+	}
+	if cs.backend.responseTimeout != "" || cs.backend.idleTimeout != "" {
+		unsupported = append(unsupported, "the backend timeouts")
+	}
+	if cs.backend.maxBodyBytes != nil {
+		unsupported = append(unsupported, "the max body size")
+	}
+	if cs.backend.strategy != "" {
+		unsupported = append(unsupported, "the balancing strategy")
+	}
+	if cs.backend.stickyCookie != nil {
+		unsupported = append(unsupported, "the sticky cookie")
+	}
+
+	return unsupported
+}
+
+// getSecurityResponseHeaders returns the response headers which the traefik headers middleware of the given security
+// headers sets.
+func getSecurityResponseHeaders(headers *dynamic.Headers) map[string]string {
+	responseHeaders := map[string]string{}
+	if headers == nil {
+		return responseHeaders
+	}
+
+	if headers.CustomFrameOptionsValue != "" {
+		responseHeaders["X-Frame-Options"] = headers.CustomFrameOptionsValue
+	}
+	if headers.ContentSecurityPolicy != "" {
+		responseHeaders["Content-Security-Policy"] = headers.ContentSecurityPolicy
+	}
+	if headers.ReferrerPolicy != "" {
+		responseHeaders["Referrer-Policy"] = headers.ReferrerPolicy
+	}
+	if headers.STSSeconds > 0 {
+		sts := fmt.Sprintf("max-age=%d", headers.STSSeconds)
+		if headers.STSIncludeSubdomains {
+			sts += "; includeSubDomains"
+		}
+		if headers.STSPreload {
+			sts += "; preload"
+		}
+		responseHeaders["Strict-Transport-Security"] = sts
+	}
+
+	return responseHeaders
+}
+
+// newHeaderFilter sets the given headers sorted by their names. Headers with an empty value are removed, like by the
+// traefik headers middleware.
+func newHeaderFilter(headers map[string]string) *gatewayv1.HTTPHeaderFilter {
+	filter := &gatewayv1.HTTPHeaderFilter{}
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		if headers[name] == "" {
+			filter.Remove = append(filter.Remove, name)
+			continue
+		}
+
+		filter.Set = append(filter.Set, gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: headers[name]})
+	}
+
+	return filter
+}
+
+func newRequestMirrorFilter(cesService resolvedCesService) gatewayv1.HTTPRouteFilter {
+	mirrorService := cesService.mirroring.getMirrorService(cesService, "")
+
+	return gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: util.NewGatewayServiceBackendRef(mirrorService.Name, mirrorService.Port.IntVal).BackendObjectReference,
+			Percent:    ptr.To(int32(mirrorService.Percent)),
+		},
+	}
+}
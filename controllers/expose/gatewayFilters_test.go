@@ -0,0 +1,156 @@
+package expose
+
+import (
+	"testing"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_resolvedCesService_getGatewayUnenforceableProtections(t *testing.T) {
+	t.Run("should return no protections of an unprotected ces service", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:            CesService{Name: "nexus"},
+			globalMiddlewareSpecs: []globalMiddleware{{name: securityHeadersMiddlewareName}},
+		}
+
+		// when
+		actual := cesService.getGatewayUnenforceableProtections()
+
+		// then
+		assert.Empty(t, actual)
+	})
+	t.Run("should return the global and the dogu ip allowlist", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:            CesService{Name: "nexus"},
+			globalMiddlewareSpecs: []globalMiddleware{{name: ipAllowListMiddlewareName}},
+			ipAllowList:           ipAllowList{sourceRanges: []string{"10.0.0.0/8"}},
+		}
+
+		// when
+		actual := cesService.getGatewayUnenforceableProtections()
+
+		// then
+		assert.Equal(t, []string{"the global ip allowlist", "the ip allowlist"}, actual)
+	})
+}
+
+func Test_translateForGateway(t *testing.T) {
+	t.Run("should translate nothing without routing features", func(t *testing.T) {
+		// when
+		actual := translateForGateway(resolvedCesService{CesService: CesService{Name: "nexus", Port: 8082}}, nil, "/nexus")
+
+		// then
+		assert.Equal(t, gatewayTranslation{}, actual)
+	})
+	t.Run("should set the security headers and the headers of the nginx annotations", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService: CesService{Name: "nexus", Port: 8082},
+			globalMiddlewareSpecs: []globalMiddleware{{name: securityHeadersMiddlewareName, spec: traefikapi.MiddlewareSpec{Headers: &dynamic.Headers{
+				CustomFrameOptionsValue: "SAMEORIGIN",
+				ReferrerPolicy:          "no-referrer",
+				STSSeconds:              31536000,
+				STSIncludeSubdomains:    true,
+				STSPreload:              true,
+			}}}},
+		}
+		annotations := doguv2.IngressAnnotations{
+			"nginx.ingress.kubernetes.io/configuration-snippet": `more_set_headers "X-Frame-Options: DENY"; more_set_headers "Server: "; proxy_set_header X-Script-Name /nexus;`,
+		}
+
+		// when
+		actual := translateForGateway(cesService, annotations, "/nexus")
+
+		// then
+		assert.Equal(t, gatewayTranslation{filters: []gatewayv1.HTTPRouteFilter{
+			{
+				Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{{Name: "X-Script-Name", Value: "/nexus"}},
+				},
+			},
+			{
+				Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{
+						{Name: "Referrer-Policy", Value: "no-referrer"},
+						{Name: "Strict-Transport-Security", Value: "max-age=31536000; includeSubDomains; preload"},
+						{Name: "X-Frame-Options", Value: "DENY"},
+					},
+					Remove: []string{"Server"},
+				},
+			},
+		}}, actual)
+	})
+	t.Run("should mirror the requests to the shadow service", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService: CesService{Name: "nexus", Port: 8082},
+			mirroring:  mirroring{Target: "nexus-shadow", Percent: ptr.To(25), MaxBodySize: "1m"},
+		}
+
+		// when
+		actual := translateForGateway(cesService, nil, "/nexus")
+
+		// then
+		assert.Equal(t, gatewayTranslation{
+			filters: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestMirror,
+				RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+					BackendRef: gatewayv1.BackendObjectReference{
+						Group: ptr.To(gatewayv1.Group("")),
+						Kind:  ptr.To(gatewayv1.Kind("Service")),
+						Name:  "nexus-shadow",
+						Port:  ptr.To(gatewayv1.PortNumber(8082)),
+					},
+					Percent: ptr.To(int32(25)),
+				},
+			}},
+			unsupported: []string{"the max body size of the mirroring"},
+		}, actual)
+	})
+	t.Run("should describe the routing features without gateway api equivalent", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:          CesService{Name: "nexus", Port: 8082},
+			httpsRedirect:       true,
+			defaultMiddlewares:  "compress@file",
+			middlewareOverrides: "my-namespace-auth@kubernetescrd",
+			requestLimits:       requestLimits{Average: 10},
+			errorPages:          errorPages{status: []string{"502-504"}, dogu: "nexus"},
+			backend:             backendConfig{scheme: "https", responseTimeout: "30s", strategy: "p2c", stickyCookie: &StickyCookie{}},
+		}
+		annotations := doguv2.IngressAnnotations{
+			"nginx.ingress.kubernetes.io/rewrite-target":       "/$2",
+			"nginx.ingress.kubernetes.io/proxy-read-timeout":   "300",
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-hsts@kubernetescrd",
+		}
+
+		// when
+		actual := translateForGateway(cesService, annotations, "/nexus")
+
+		// then
+		assert.Empty(t, actual.filters)
+		assert.Equal(t, []string{
+			"the global https redirect",
+			"annotation [nginx.ingress.kubernetes.io/rewrite-target] has no gateway api equivalent",
+			"the forwarding timeouts of the nginx annotations have no gateway api equivalent",
+			"annotation [traefik.ingress.kubernetes.io/router.middlewares] has no gateway api equivalent",
+			"the request limits",
+			"the default middlewares [compress@file]",
+			"the middleware overrides [my-namespace-auth@kubernetescrd]",
+			"the error pages",
+			"the backend scheme",
+			"the backend timeouts",
+			"the balancing strategy",
+			"the sticky cookie",
+		}, actual.unsupported)
+	})
+}
@@ -0,0 +1,204 @@
+package expose
+
+import (
+	"fmt"
+	"strconv"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+const (
+	// GlobalConfigHTTPSRedirectKey is the global config key which redirects all http requests to https if `true`.
+	GlobalConfigHTTPSRedirectKey = "ingress/security/https_redirect"
+	// GlobalConfigHSTSMaxAgeKey is the global config key with the max age of the `Strict-Transport-Security` header in
+	// seconds.
+	GlobalConfigHSTSMaxAgeKey = "ingress/security/hsts_max_age"
+	// GlobalConfigHSTSIncludeSubdomainsKey is the global config key which adds `includeSubDomains` to the
+	// `Strict-Transport-Security` header if `true`.
+	GlobalConfigHSTSIncludeSubdomainsKey = "ingress/security/hsts_include_subdomains"
+	// GlobalConfigHSTSPreloadKey is the global config key which adds `preload` to the `Strict-Transport-Security`
+	// header if `true`.
+	GlobalConfigHSTSPreloadKey = "ingress/security/hsts_preload"
+	// GlobalConfigFrameOptionsKey is the global config key with the value of the `X-Frame-Options` header, e.g.,
+	// `SAMEORIGIN`.
+	GlobalConfigFrameOptionsKey = "ingress/security/frame_options"
+	// GlobalConfigContentSecurityPolicyKey is the global config key with the value of the `Content-Security-Policy`
+	// header.
+	GlobalConfigContentSecurityPolicyKey = "ingress/security/content_security_policy"
+	// GlobalConfigReferrerPolicyKey is the global config key with the value of the `Referrer-Policy` header, e.g.,
+	// `strict-origin-when-cross-origin`.
+	GlobalConfigReferrerPolicyKey = "ingress/security/referrer_policy"
+	// GlobalConfigIPAllowListKey is the global config key with the comma separated ip ranges allowed to access the
+	// ecosystem, e.g., `10.0.0.0/8,192.168.1.7`.
+	GlobalConfigIPAllowListKey = "ingress/security/ip_allowlist"
+	// GlobalConfigIPAllowListDepthKey is the global config key with the depth of the `X-Forwarded-For` header which
+	// contains the client ip of the ip allowlists, e.g., `1` behind a single proxy.
+	GlobalConfigIPAllowListDepthKey = "ingress/security/ip_allowlist_depth"
+)
+
+const (
+	ipAllowListMiddlewareName     = "global-ip-allowlist"
+	httpsRedirectMiddlewareName   = "global-https-redirect"
+	securityHeadersMiddlewareName = "global-security-headers"
+)
+
+const (
+	// httpsRedirectRouteName is the name of the catch-all ingress route of the http entrypoint which redirects all
+	// requests to https.
+	httpsRedirectRouteName = "global-https-redirect"
+	// httpEntryPoint is the entrypoint of traefik for plain http requests.
+	httpEntryPoint = "web"
+	// noopServiceName is the internal traefik service of routes whose middlewares answer every request.
+	noopServiceName = "noop@internal"
+)
+
+// GlobalConfigSecurityKeys are the keys of the global config which configure the global middlewares and the forward
+// auth middleware.
+var GlobalConfigSecurityKeys = []libconfig.Key{
+	GlobalConfigHTTPSRedirectKey,
+	GlobalConfigHSTSMaxAgeKey,
+	GlobalConfigHSTSIncludeSubdomainsKey,
+	GlobalConfigHSTSPreloadKey,
+	GlobalConfigFrameOptionsKey,
+	GlobalConfigContentSecurityPolicyKey,
+	GlobalConfigReferrerPolicyKey,
+	GlobalConfigIPAllowListKey,
+	GlobalConfigIPAllowListDepthKey,
+	GlobalConfigForwardAuthAddressKey,
+	GlobalConfigForwardAuthResponseHeadersKey,
+}
+
+// globalMiddlewareNames are the names of all global middlewares in the order of the middleware chain. The ip allowlist
+// comes first, so rejected requests don't pass any other middleware.
+var globalMiddlewareNames = []string{ipAllowListMiddlewareName, securityHeadersMiddlewareName}
+
+// globalMiddleware is a middleware of the service discovery which is part of the route of every dogu.
+type globalMiddleware struct {
+	name string
+	spec traefikapi.MiddlewareSpec
+}
+
+// getGlobalMiddlewares returns the global middlewares configured in the given global config in the order of the
+// middleware chain. Middlewares without any configured key are omitted.
+func getGlobalMiddlewares(globalConfig libconfig.GlobalConfig) ([]globalMiddleware, error) {
+	var middlewares []globalMiddleware
+
+	allowList, err := getGlobalIPAllowList(globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowList.isEnabled() {
+		middlewares = append(middlewares, globalMiddleware{name: ipAllowListMiddlewareName, spec: allowList.getMiddleware()})
+	}
+
+	headers, err := getSecurityHeaders(globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if headers != nil {
+		middlewares = append(middlewares, globalMiddleware{
+			name: securityHeadersMiddlewareName,
+			spec: traefikapi.MiddlewareSpec{Headers: headers},
+		})
+	}
+
+	return middlewares, nil
+}
+
+// getHTTPSRedirectMiddleware returns the https redirect middleware of the given global config or nil if the redirect
+// is disabled. The middleware is not part of the middleware chain of the dogus, as their routers only serve https
+// requests. It belongs to the catch-all router of the http entrypoint instead (see httpsRedirectRouteName).
+func getHTTPSRedirectMiddleware(globalConfig libconfig.GlobalConfig) (*globalMiddleware, error) {
+	httpsRedirect, err := getGlobalConfigBool(globalConfig, GlobalConfigHTTPSRedirectKey)
+	if err != nil || !httpsRedirect {
+		return nil, err
+	}
+
+	return &globalMiddleware{
+		name: httpsRedirectMiddlewareName,
+		spec: traefikapi.MiddlewareSpec{RedirectScheme: &dynamic.RedirectScheme{Scheme: "https", Permanent: true}},
+	}, nil
+}
+
+// getSecurityHeaders returns the security headers of the given global config or nil if no header is configured.
+func getSecurityHeaders(globalConfig libconfig.GlobalConfig) (*dynamic.Headers, error) {
+	headers := &dynamic.Headers{
+		CustomFrameOptionsValue: getGlobalConfigString(globalConfig, GlobalConfigFrameOptionsKey),
+		ContentSecurityPolicy:   getGlobalConfigString(globalConfig, GlobalConfigContentSecurityPolicyKey),
+		ReferrerPolicy:          getGlobalConfigString(globalConfig, GlobalConfigReferrerPolicyKey),
+	}
+
+	if maxAge := getGlobalConfigString(globalConfig, GlobalConfigHSTSMaxAgeKey); maxAge != "" {
+		seconds, err := strconv.ParseInt(maxAge, 10, 64)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid value [%s] of global config key [%s]: expected seconds", maxAge, GlobalConfigHSTSMaxAgeKey)
+		}
+
+		includeSubdomains, err := getGlobalConfigBool(globalConfig, GlobalConfigHSTSIncludeSubdomainsKey)
+		if err != nil {
+			return nil, err
+		}
+
+		preload, err := getGlobalConfigBool(globalConfig, GlobalConfigHSTSPreloadKey)
+		if err != nil {
+			return nil, err
+		}
+
+		headers.STSSeconds = seconds
+		headers.STSIncludeSubdomains = includeSubdomains
+		headers.STSPreload = preload
+	}
+
+	if headers.STSSeconds == 0 && headers.CustomFrameOptionsValue == "" && headers.ContentSecurityPolicy == "" && headers.ReferrerPolicy == "" {
+		return nil, nil
+	}
+
+	return headers, nil
+}
+
+// getGlobalIPAllowList returns the ip allowlist of the whole ecosystem of the given global config.
+func getGlobalIPAllowList(globalConfig libconfig.GlobalConfig) (ipAllowList, error) {
+	return parseIPAllowList(
+		getGlobalConfigString(globalConfig, GlobalConfigIPAllowListKey), GlobalConfigIPAllowListKey,
+		getGlobalConfigString(globalConfig, GlobalConfigIPAllowListDepthKey), GlobalConfigIPAllowListDepthKey,
+	)
+}
+
+func getGlobalConfigString(globalConfig libconfig.GlobalConfig, key libconfig.Key) string {
+	value, _ := globalConfig.Get(key)
+	return value.String()
+}
+
+func getGlobalConfigBool(globalConfig libconfig.GlobalConfig, key libconfig.Key) (bool, error) {
+	value := getGlobalConfigString(globalConfig, key)
+	if value == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value [%s] of global config key [%s]: expected boolean", value, key)
+	}
+
+	return enabled, nil
+}
+
+// GetGlobalMiddlewareRefs returns the references of the global middlewares configured in the given global config in
+// the order of the middleware chain, e.g., `<namespace>-global-security-headers@kubernetescrd`.
+func GetGlobalMiddlewareRefs(namespace string, globalConfig libconfig.GlobalConfig) ([]string, error) {
+	middlewares, err := getGlobalMiddlewares(globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []string
+	for _, middleware := range middlewares {
+		refs = append(refs, getCRDMiddlewareRef(namespace, middleware.name))
+	}
+
+	return refs, nil
+}
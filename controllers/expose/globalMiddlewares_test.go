@@ -0,0 +1,151 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+func Test_getGlobalMiddlewares(t *testing.T) {
+	t.Run("should return no middlewares without security config", func(t *testing.T) {
+		// when
+		actual, err := getGlobalMiddlewares(config.CreateGlobalConfig(config.Entries{"fqdn": testFQDN}))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+	t.Run("should return security headers without the redirect", func(t *testing.T) {
+		// given
+		globalConfig := config.CreateGlobalConfig(config.Entries{
+			"ingress/security/https_redirect":          "true",
+			"ingress/security/hsts_max_age":            "31536000",
+			"ingress/security/hsts_include_subdomains": "true",
+			"ingress/security/frame_options":           "SAMEORIGIN",
+			"ingress/security/content_security_policy": "frame-ancestors 'self'",
+			"ingress/security/referrer_policy":         "strict-origin-when-cross-origin",
+		})
+
+		// when
+		actual, err := getGlobalMiddlewares(globalConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []globalMiddleware{
+			{
+				name: "global-security-headers",
+				spec: traefikapi.MiddlewareSpec{Headers: &dynamic.Headers{
+					STSSeconds:              31536000,
+					STSIncludeSubdomains:    true,
+					CustomFrameOptionsValue: "SAMEORIGIN",
+					ContentSecurityPolicy:   "frame-ancestors 'self'",
+					ReferrerPolicy:          "strict-origin-when-cross-origin",
+				}},
+			},
+		}, actual)
+	})
+	t.Run("should return the ip allowlist before the security headers", func(t *testing.T) {
+		// given
+		globalConfig := config.CreateGlobalConfig(config.Entries{
+			"ingress/security/referrer_policy":    "no-referrer",
+			"ingress/security/ip_allowlist":       "10.0.0.0/8, 192.168.1.7",
+			"ingress/security/ip_allowlist_depth": "1",
+		})
+
+		// when
+		actual, err := getGlobalMiddlewares(globalConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []globalMiddleware{
+			{name: "global-ip-allowlist", spec: traefikapi.MiddlewareSpec{IPAllowList: &dynamic.IPAllowList{
+				SourceRange: []string{"10.0.0.0/8", "192.168.1.7"},
+				IPStrategy:  &dynamic.IPStrategy{Depth: 1},
+			}}},
+			{name: "global-security-headers", spec: traefikapi.MiddlewareSpec{Headers: &dynamic.Headers{ReferrerPolicy: "no-referrer"}}},
+		}, actual)
+	})
+	t.Run("should fail for invalid values", func(t *testing.T) {
+		tests := []struct {
+			key   config.Key
+			value config.Value
+		}{
+			{key: "ingress/security/hsts_max_age", value: "-1"},
+			{key: "ingress/security/hsts_preload", value: "maybe"},
+			{key: "ingress/security/ip_allowlist", value: "10.0.0.0/33"},
+			{key: "ingress/security/ip_allowlist_depth", value: "-1"},
+		}
+		for _, tt := range tests {
+			entries := config.Entries{tt.key: tt.value}
+			if tt.key != "ingress/security/hsts_max_age" {
+				entries["ingress/security/hsts_max_age"] = "60"
+			}
+
+			// when
+			_, err := getGlobalMiddlewares(config.CreateGlobalConfig(entries))
+
+			// then
+			require.Error(t, err)
+			assert.ErrorContains(t, err, string(tt.key))
+		}
+	})
+}
+
+func Test_getHTTPSRedirectMiddleware(t *testing.T) {
+	t.Run("should return the redirect middleware", func(t *testing.T) {
+		// when
+		actual, err := getHTTPSRedirectMiddleware(config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "true"}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &globalMiddleware{
+			name: "global-https-redirect",
+			spec: traefikapi.MiddlewareSpec{RedirectScheme: &dynamic.RedirectScheme{Scheme: "https", Permanent: true}},
+		}, actual)
+	})
+	t.Run("should not redirect if disabled", func(t *testing.T) {
+		// when
+		actual, err := getHTTPSRedirectMiddleware(config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "false"}))
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+	t.Run("should fail for invalid value", func(t *testing.T) {
+		// when
+		_, err := getHTTPSRedirectMiddleware(config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "yes please"}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "ingress/security/https_redirect")
+	})
+}
+
+func TestGetGlobalMiddlewareRefs(t *testing.T) {
+	t.Run("should reference the configured middlewares in the namespace", func(t *testing.T) {
+		// given
+		globalConfig := config.CreateGlobalConfig(config.Entries{
+			"ingress/security/https_redirect":  "true",
+			"ingress/security/referrer_policy": "no-referrer",
+		})
+
+		// when
+		actual, err := GetGlobalMiddlewareRefs(testNamespace, globalConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"my-namespace-global-security-headers@kubernetescrd"}, actual)
+	})
+	t.Run("should return nil without configured middlewares", func(t *testing.T) {
+		// when
+		actual, err := GetGlobalMiddlewareRefs(testNamespace, config.CreateGlobalConfig(config.Entries{}))
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
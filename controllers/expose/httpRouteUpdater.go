@@ -0,0 +1,316 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	staticContentMaintenancePath  = "/errors/503.html"
+	staticContentDoguStartingPath = "/errors/starting.html"
+	httpRouteKind                 = "http route"
+)
+
+const failedHTTPRouteUpdateErrMsg = "failed to update http route: %w"
+
+// httpRouteUpdater exposes the ces services as http routes of the gateway api instead of ingress objects. The
+// path rewrites, headers and mirroring are done by filters, so no traefik middlewares are required. Ces services with
+// access protections without filter equivalent are not routed.
+type httpRouteUpdater struct {
+	*ingressUpdater
+	httpRouteInterface httpRouteInterface
+	gatewayName        string
+}
+
+// NewHTTPRouteUpdater creates a new instance responsible for updating the http routes of the gateway api.
+func NewHTTPRouteUpdater(deps IngressUpdaterDependencies) *httpRouteUpdater {
+	return &httpRouteUpdater{
+		ingressUpdater:     NewIngressUpdater(deps),
+		httpRouteInterface: deps.HTTPRouteInterface,
+		gatewayName:        deps.GatewayName,
+	}
+}
+
+// UpsertIngressForService creates or updates the http routes of the given service.
+func (h *httpRouteUpdater) UpsertIngressForService(ctx context.Context, service *corev1.Service) error {
+	_, isMaintenanceMode, err := h.maintenanceAdapter.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	cesServices, err := h.getRoutedCesServices(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	cesServices = h.withoutUnprotectedCesServices(service, cesServices)
+
+	selector := labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).String()
+	routeList, err := h.httpRouteInterface.List(ctx, v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list http routes: %w", err)
+	}
+
+	var otherRoutes []ingressRoute
+	routes := make([]v1.Object, 0, len(routeList.Items))
+	for index, httpRoute := range routeList.Items {
+		routes = append(routes, &routeList.Items[index])
+
+		route, ok := newRouteOfHTTPRoute(httpRoute)
+		if ok && !isOwnedByService(httpRoute.GetOwnerReferences(), service) {
+			otherRoutes = append(otherRoutes, route)
+		}
+	}
+
+	cesServices, err = h.resolveRouteConflicts(ctx, service, cesServices, otherRoutes, h.deleteHTTPRoute)
+	if err != nil {
+		return fmt.Errorf("failed to resolve http route conflicts of service [%s]: %w", service.Name, err)
+	}
+
+	for _, cesService := range cesServices {
+		upsertErr := h.upsertHTTPRouteForCesService(ctx, cesService, service, isMaintenanceMode)
+		if upsertErr != nil {
+			return fmt.Errorf("failed to create http route for ces service [%+v]: %w", cesService, upsertErr)
+		}
+	}
+
+	err = h.deleteStaleRoutes(ctx, service, cesServices, routes, httpRouteKind, h.deleteHTTPRoute)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale http routes of service [%s]: %w", service.Name, err)
+	}
+
+	return nil
+}
+
+// withoutUnprotectedCesServices returns the given ces services without those whose access protections the gateway api
+// can't enforce. Their http routes are deleted as stale routes, so they aren't exposed unprotected.
+func (h *httpRouteUpdater) withoutUnprotectedCesServices(service *corev1.Service, cesServices []resolvedCesService) []resolvedCesService {
+	protectedServices := make([]resolvedCesService, 0, len(cesServices))
+	for _, cesService := range cesServices {
+		protections := cesService.getGatewayUnenforceableProtections()
+		if len(protections) > 0 {
+			h.eventRecorder.Eventf(service, corev1.EventTypeWarning, refusedRoutingEventReason, "Refused to route ces service [%s]: the gateway api can't enforce %s.", cesService.Name, strings.Join(protections, ", "))
+			continue
+		}
+
+		protectedServices = append(protectedServices, cesService)
+	}
+
+	return protectedServices
+}
+
+func newRouteOfHTTPRoute(httpRoute gatewayv1.HTTPRoute) (ingressRoute, bool) {
+	if len(httpRoute.OwnerReferences) == 0 || len(httpRoute.Spec.Rules) == 0 {
+		return ingressRoute{}, false
+	}
+
+	matches := httpRoute.Spec.Rules[0].Matches
+	if len(matches) == 0 || matches[0].Path == nil || matches[0].Path.Value == nil {
+		return ingressRoute{}, false
+	}
+
+	route := ingressRoute{
+		ingressName: httpRoute.Name,
+		serviceName: httpRoute.OwnerReferences[0].Name,
+		path:        normalizeRoutePath(*matches[0].Path.Value),
+	}
+
+	if len(httpRoute.Spec.Hostnames) > 0 {
+		route.host = string(httpRoute.Spec.Hostnames[0])
+	}
+
+	return route, true
+}
+
+func (h *httpRouteUpdater) deleteHTTPRoute(ctx context.Context, name string) error {
+	return h.httpRouteInterface.Delete(ctx, name, v1.DeleteOptions{})
+}
+
+func (h *httpRouteUpdater) upsertHTTPRouteForCesService(ctx context.Context, cesService resolvedCesService, service *corev1.Service, isMaintenanceMode bool) error {
+	dogu, err := h.doguInterface.Get(ctx, service.Name, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get dogu for service [%s]: %w", service.Name, err)
+	}
+
+	if isMaintenanceMode {
+		return h.upsertMaintenanceModeHTTPRoute(ctx, cesService, service, dogu)
+	}
+
+	if util.HasDoguLabel(service) {
+		isReady, err := h.deploymentReadyChecker.IsReady(ctx, service.Name)
+		if err != nil {
+			return err
+		}
+
+		if !isReady {
+			return h.upsertDoguIsStartingHTTPRoute(ctx, cesService, service)
+		}
+	}
+
+	err = h.upsertDoguHTTPRoute(ctx, cesService, service)
+	if err != nil {
+		return err
+	}
+
+	h.eventRecorder.Eventf(dogu, corev1.EventTypeNormal, ingressCreationEventReason, "Created regular http route for service [%s].", cesService.Name)
+	return nil
+}
+
+func (h *httpRouteUpdater) upsertMaintenanceModeHTTPRoute(ctx context.Context, cesService resolvedCesService, service *corev1.Service, dogu *doguv2.Dogu) error {
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("system is in maintenance mode -> create maintenance http route for service [%s]", service.GetName()))
+
+	filter := newURLRewriteFilter(gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To(staticContentMaintenancePath)})
+	err := h.upsertHTTPRoute(ctx, cesService, service, cesService.Location, staticContentBackendName, staticContentBackendPort, filter)
+	if err != nil {
+		return fmt.Errorf(failedHTTPRouteUpdateErrMsg, err)
+	}
+
+	h.eventRecorder.Eventf(dogu, corev1.EventTypeNormal, ingressCreationEventReason, "Http route for service [%s] has been updated to maintenance mode.", cesService.Name)
+	return nil
+}
+
+func (h *httpRouteUpdater) upsertDoguIsStartingHTTPRoute(ctx context.Context, cesService resolvedCesService, service *corev1.Service) error {
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("dogu is still starting -> create dogu is starting http route for service [%s]", service.GetName()))
+
+	filter := newURLRewriteFilter(gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To(staticContentDoguStartingPath)})
+	err := h.upsertHTTPRoute(ctx, cesService, service, cesService.Location, staticContentBackendName, staticContentBackendPort, filter)
+	if err != nil {
+		return fmt.Errorf(failedHTTPRouteUpdateErrMsg, err)
+	}
+
+	return nil
+}
+
+func (h *httpRouteUpdater) upsertDoguHTTPRoute(ctx context.Context, cesService resolvedCesService, service *corev1.Service) error {
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("dogu is ready -> update ces service http route for service [%s]", service.GetName()))
+
+	routePath := cesService.Location
+	var filters []gatewayv1.HTTPRouteFilter
+
+	if cesService.hasRewriteConfig() {
+		rewriteCfg, err := cesService.getRewriteConfig()
+		if err != nil {
+			return fmt.Errorf("error getting rewrite-config from ces-service: %w", err)
+		}
+
+		routePath = rewriteCfg.Pattern
+		filters = append(filters, newReplacePrefixFilter(rewriteCfg.Rewrite))
+	} else if cesService.needsReplacePathMiddleware() {
+		filters = append(filters, newReplacePrefixFilter(cesService.Pass))
+	}
+
+	additionalAnnotations, err := getAdditionalIngressAnnotations(service)
+	if err != nil {
+		return err
+	}
+
+	translation := translateForGateway(cesService, additionalAnnotations, routePath)
+	filters = append(filters, translation.filters...)
+	if len(translation.unsupported) > 0 {
+		h.eventRecorder.Eventf(service, corev1.EventTypeWarning, ignoredRoutingConfigEventReason, "Ignored routing config of ces service [%s] without gateway api equivalent: %s.", cesService.Name, strings.Join(translation.unsupported, "; "))
+	}
+
+	err = h.upsertHTTPRoute(ctx, cesService, service, routePath, service.GetName(), int32(cesService.Port), filters...)
+	if err != nil {
+		return fmt.Errorf(failedHTTPRouteUpdateErrMsg, err)
+	}
+
+	return nil
+}
+
+func newReplacePrefixFilter(path string) gatewayv1.HTTPRouteFilter {
+	return newURLRewriteFilter(gatewayv1.HTTPPathModifier{
+		Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+		ReplacePrefixMatch: ptr.To(normalizeRoutePath(path)),
+	})
+}
+
+func newURLRewriteFilter(pathModifier gatewayv1.HTTPPathModifier) gatewayv1.HTTPRouteFilter {
+	return gatewayv1.HTTPRouteFilter{
+		Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Path: &pathModifier},
+	}
+}
+
+func (h *httpRouteUpdater) upsertHTTPRoute(ctx context.Context, cesService resolvedCesService, service *corev1.Service, path string, backendName string, backendPort int32, filters ...gatewayv1.HTTPRouteFilter) error {
+	route := h.getHTTPRoute(cesService, service, path, backendName, backendPort, filters)
+
+	_, err := util.ServerSideApply[*gatewayv1.HTTPRoute](ctx, h.httpRouteInterface, route, h.eventRecorder)
+	if err != nil {
+		return fmt.Errorf("failed to upsert http route %s: %w", route.Name, err)
+	}
+
+	return nil
+}
+
+func (h *httpRouteUpdater) getHTTPRoute(cesService resolvedCesService, service *corev1.Service, path string, backendName string, backendPort int32, filters []gatewayv1.HTTPRouteFilter) *gatewayv1.HTTPRoute {
+	route := &gatewayv1.HTTPRoute{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.GatewayAPIVersion,
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      cesService.Name,
+			Namespace: h.namespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{util.NewGatewayParentReference(h.gatewayName, "")},
+			},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches:     getHTTPRouteMatches(cesService.CesService, path),
+				Filters:     filters,
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: util.NewGatewayServiceBackendRef(backendName, backendPort)}},
+			}},
+		},
+	}
+
+	// the tls certificate of the host is configured at the listeners of the gateway
+	if cesService.hasHost() {
+		route.Spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(cesService.Host)}
+	}
+
+	return route
+}
+
+// getHTTPRouteMatches returns the matches of the requests of the given path prefix and the method and header matchers
+// of the given ces service. A match accepts only a single method, so every method gets its own match.
+func getHTTPRouteMatches(cesService CesService, path string) []gatewayv1.HTTPRouteMatch {
+	match := util.NewGatewayPathPrefixMatch(normalizeRoutePath(path))
+	for _, name := range cesService.getSortedHeaderNames() {
+		match.Headers = append(match.Headers, gatewayv1.HTTPHeaderMatch{
+			Name:  gatewayv1.HTTPHeaderName(name),
+			Value: cesService.Headers[name],
+		})
+	}
+
+	if len(cesService.Methods) == 0 {
+		return []gatewayv1.HTTPRouteMatch{match}
+	}
+
+	matches := make([]gatewayv1.HTTPRouteMatch, 0, len(cesService.Methods))
+	for _, method := range cesService.Methods {
+		methodMatch := *match.DeepCopy()
+		methodMatch.Method = ptr.To(gatewayv1.HTTPMethod(method))
+		matches = append(matches, methodMatch)
+	}
+
+	return matches
+}
@@ -0,0 +1,481 @@
+package expose
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const testGatewayName = "ces-gateway"
+
+func TestNewHTTPRouteUpdater(t *testing.T) {
+	// given
+	httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+
+	// when
+	sut := NewHTTPRouteUpdater(IngressUpdaterDependencies{
+		Namespace:          testNamespace,
+		HTTPRouteInterface: httpRouteInterfaceMock,
+		GatewayName:        testGatewayName,
+	})
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, testNamespace, sut.namespace)
+	assert.Equal(t, httpRouteInterfaceMock, sut.httpRouteInterface)
+	assert.Equal(t, testGatewayName, sut.gatewayName)
+}
+
+func Test_httpRouteUpdater_UpsertIngressForService(t *testing.T) {
+	getService := func(cesServices []CesService) *corev1.Service {
+		cesServiceString, _ := json.Marshal(cesServices)
+		return &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   testNamespace,
+				UID:         "uid",
+				Annotations: map[string]string{CesServiceAnnotation: string(cesServiceString)},
+				Labels:      map[string]string{"dogu.name": "test"},
+			},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "testPort", Port: 55}}},
+		}
+	}
+	dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+
+	t.Run("should create http route with url rewrite filter", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/myLocation", Pass: "/myPass"}})
+		expectedRoute := getTestHTTPRoute("test", "/myLocation", service, "test", 55, newReplacePrefixFilter("/myPass"))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular http route for service [%s].", "test")
+		recorderMock.EXPECT().Eventf(service, "Warning", "IgnoredRoutingConfig", "Ignored routing config of ces service [%s] without gateway api equivalent: %s.", "test", "the error pages")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{}, nil)
+		expectApplyHTTPRoute(t, httpRouteInterfaceMock, expectedRoute)
+
+		sut := getTestHTTPRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.httpRouteInterface = httpRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create http route of rewrite config", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/v2", Pass: "/v2", Rewrite: "{\"pattern\":\"v2\",\"rewrite\":\"/nexus/v2\"}"}})
+		expectedRoute := getTestHTTPRoute("test", "/v2", service, "test", 55, newReplacePrefixFilter("/nexus/v2"))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular http route for service [%s].", "test")
+		recorderMock.EXPECT().Eventf(service, "Warning", "IgnoredRoutingConfig", "Ignored routing config of ces service [%s] without gateway api equivalent: %s.", "test", "the error pages")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{}, nil)
+		expectApplyHTTPRoute(t, httpRouteInterfaceMock, expectedRoute)
+
+		sut := getTestHTTPRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.httpRouteInterface = httpRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create maintenance http route", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}})
+		expectedRoute := getTestHTTPRoute("test", "/test", service, "k8s-ces-assets-service", 80, newURLRewriteFilter(gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: ptr.To("/errors/503.html"),
+		}))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Http route for service [%s] has been updated to maintenance mode.", "test")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{}, nil)
+		expectApplyHTTPRoute(t, httpRouteInterfaceMock, expectedRoute)
+
+		sut := getTestHTTPRouteUpdater(t, true)
+		sut.eventRecorder = recorderMock
+		sut.doguInterface = doguInterfaceMock
+		sut.httpRouteInterface = httpRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create dogu is starting http route", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}})
+		expectedRoute := getTestHTTPRoute("test", "/test", service, "k8s-ces-assets-service", 80, newURLRewriteFilter(gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: ptr.To("/errors/starting.html"),
+		}))
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(false, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{}, nil)
+		expectApplyHTTPRoute(t, httpRouteInterfaceMock, expectedRoute)
+
+		sut := getTestHTTPRouteUpdater(t, false)
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.httpRouteInterface = httpRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should delete stale http route of the service", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}})
+		expectedRoute := getTestHTTPRoute("test", "/test", service, "test", 55)
+		staleRoute := getTestHTTPRoute("old", "/old", service, "test", 55)
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular http route for service [%s].", "test")
+		recorderMock.EXPECT().Eventf(service, "Warning", "IgnoredRoutingConfig", "Ignored routing config of ces service [%s] without gateway api equivalent: %s.", "test", "the error pages")
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale %s [%s] as the ces service no longer exists.", "http route", "old")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{Items: []gatewayv1.HTTPRoute{*staleRoute}}, nil)
+		httpRouteInterfaceMock.EXPECT().Delete(testCtx, "old", metav1.DeleteOptions{}).Return(nil)
+		expectApplyHTTPRoute(t, httpRouteInterfaceMock, expectedRoute)
+
+		sut := getTestHTTPRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.httpRouteInterface = httpRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should delete the http routes of a service without ces services annotation", func(t *testing.T) {
+		// given
+		service := getService(nil)
+		delete(service.Annotations, CesServiceAnnotation)
+		staleRoute := getTestHTTPRoute("test", "/test", service, "test", 55)
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale %s [%s] as the ces service no longer exists.", "http route", "test")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{Items: []gatewayv1.HTTPRoute{*staleRoute}}, nil)
+		httpRouteInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(nil)
+
+		sut := &httpRouteUpdater{
+			ingressUpdater: &ingressUpdater{
+				namespace:          testNamespace,
+				maintenanceAdapter: getMaintenanceAdapterMock(t, false),
+				eventRecorder:      recorderMock,
+				doguInterface:      doguInterfaceMock,
+			},
+			httpRouteInterface: httpRouteInterfaceMock,
+			gatewayName:        testGatewayName,
+		}
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create http route with the header filters of the security headers and the nginx annotations", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test", ErrorPageStatus: "none"}})
+		service.Annotations[annotation.AdditionalIngressAnnotationsAnnotation] = `{"nginx.ingress.kubernetes.io/configuration-snippet":"more_set_headers \"X-Content-Type-Options: nosniff\"; proxy_set_header X-Forwarded-Prefix /test;"}`
+		expectedRoute := getTestHTTPRoute("test", "/test", service, "test", 55,
+			gatewayv1.HTTPRouteFilter{
+				Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{{Name: "X-Forwarded-Prefix", Value: "/test"}},
+				},
+			},
+			gatewayv1.HTTPRouteFilter{
+				Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{{Name: "X-Content-Type-Options", Value: "nosniff"}, {Name: "X-Frame-Options", Value: "SAMEORIGIN"}},
+				},
+			},
+		)
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular http route for service [%s].", "test")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{}, nil)
+		expectApplyHTTPRoute(t, httpRouteInterfaceMock, expectedRoute)
+
+		sut := &httpRouteUpdater{
+			ingressUpdater: &ingressUpdater{
+				namespace:              testNamespace,
+				maintenanceAdapter:     getMaintenanceAdapterMock(t, false),
+				doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+				globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN, "ingress/security/frame_options": "SAMEORIGIN"}),
+				eventRecorder:          recorderMock,
+				deploymentReadyChecker: deploymentReadyChecker,
+				doguInterface:          doguInterfaceMock,
+			},
+			httpRouteInterface: httpRouteInterfaceMock,
+			gatewayName:        testGatewayName,
+		}
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should refuse to route a ces service whose ip allowlist the gateway api can't enforce", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}})
+		existingRoute := getTestHTTPRoute("test", "/test", service, "test", 55)
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Warning", "RefusedRouting", "Refused to route ces service [%s]: the gateway api can't enforce %s.", "test", "the global ip allowlist")
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale %s [%s] as the ces service no longer exists.", "http route", "test")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{Items: []gatewayv1.HTTPRoute{*existingRoute}}, nil)
+		httpRouteInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(nil)
+
+		sut := &httpRouteUpdater{
+			ingressUpdater: &ingressUpdater{
+				namespace:              testNamespace,
+				maintenanceAdapter:     getMaintenanceAdapterMock(t, false),
+				doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+				globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN, "ingress/security/ip_allowlist": "10.0.0.0/8"}),
+				eventRecorder:          recorderMock,
+				doguInterface:          doguInterfaceMock,
+			},
+			httpRouteInterface: httpRouteInterfaceMock,
+			gatewayName:        testGatewayName,
+		}
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to list http routes", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}})
+
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(nil, assert.AnError)
+
+		sut := getTestHTTPRouteUpdater(t, false)
+		sut.httpRouteInterface = httpRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list http routes")
+	})
+	t.Run("should fail to apply http route", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}})
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		httpRouteInterfaceMock := newMockHttpRouteInterface(t)
+		httpRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&gatewayv1.HTTPRouteList{}, nil)
+		httpRouteInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(nil, assert.AnError)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Warning", "IgnoredRoutingConfig", "Ignored routing config of ces service [%s] without gateway api equivalent: %s.", "test", "the error pages")
+
+		sut := getTestHTTPRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.httpRouteInterface = httpRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create http route for ces service")
+		assert.ErrorContains(t, err, "failed to upsert http route test")
+	})
+}
+
+func Test_newRouteOfHTTPRoute(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nexus", UID: "uid"}}
+
+	t.Run("should create route of http route", func(t *testing.T) {
+		// given
+		httpRoute := getTestHTTPRoute("nexus-docker", "/v2", service, "nexus", 8082)
+		httpRoute.Spec.Hostnames = []gatewayv1.Hostname{"registry.ces.example.com"}
+
+		// when
+		actual, ok := newRouteOfHTTPRoute(*httpRoute)
+
+		// then
+		require.True(t, ok)
+		assert.Equal(t, ingressRoute{ingressName: "nexus-docker", serviceName: "nexus", host: "registry.ces.example.com", path: "/v2"}, actual)
+	})
+	t.Run("should ignore http route without owner", func(t *testing.T) {
+		// given
+		httpRoute := getTestHTTPRoute("nexus", "/nexus", service, "nexus", 8082)
+		httpRoute.OwnerReferences = nil
+
+		// when
+		_, ok := newRouteOfHTTPRoute(*httpRoute)
+
+		// then
+		assert.False(t, ok)
+	})
+	t.Run("should ignore http route without path match", func(t *testing.T) {
+		// given
+		httpRoute := getTestHTTPRoute("nexus", "/nexus", service, "nexus", 8082)
+		httpRoute.Spec.Rules[0].Matches = nil
+
+		// when
+		_, ok := newRouteOfHTTPRoute(*httpRoute)
+
+		// then
+		assert.False(t, ok)
+	})
+}
+
+func Test_getHTTPRouteMatches(t *testing.T) {
+	t.Run("should match the path prefix without matchers", func(t *testing.T) {
+		// when
+		actual := getHTTPRouteMatches(CesService{Name: "nexus"}, "/nexus")
+
+		// then
+		assert.Equal(t, []gatewayv1.HTTPRouteMatch{util.NewGatewayPathPrefixMatch("/nexus")}, actual)
+	})
+	t.Run("should create a match per method with the header matchers", func(t *testing.T) {
+		// given
+		cesService := CesService{Name: "nexus", Methods: []string{"GET", "POST"}, Headers: map[string]string{"X-Api-Version": "2"}}
+		getMatch := func(method gatewayv1.HTTPMethod) gatewayv1.HTTPRouteMatch {
+			match := util.NewGatewayPathPrefixMatch("/nexus")
+			match.Headers = []gatewayv1.HTTPHeaderMatch{{Name: "X-Api-Version", Value: "2"}}
+			match.Method = ptr.To(method)
+			return match
+		}
+
+		// when
+		actual := getHTTPRouteMatches(cesService, "/nexus")
+
+		// then
+		assert.Equal(t, []gatewayv1.HTTPRouteMatch{getMatch(gatewayv1.HTTPMethodGet), getMatch(gatewayv1.HTTPMethodPost)}, actual)
+	})
+}
+
+func getTestHTTPRouteUpdater(t *testing.T, maintenanceMode bool) *httpRouteUpdater {
+	return &httpRouteUpdater{
+		ingressUpdater: &ingressUpdater{
+			namespace:              testNamespace,
+			maintenanceAdapter:     getMaintenanceAdapterMock(t, maintenanceMode),
+			doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
+		},
+		gatewayName: testGatewayName,
+	}
+}
+
+func getTestHTTPRoute(routeName string, path string, service *corev1.Service, targetServiceName string, targetPort int32, filters ...gatewayv1.HTTPRouteFilter) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeName,
+			Namespace: testNamespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{util.NewGatewayParentReference(testGatewayName, "")},
+			},
+			Hostnames: []gatewayv1.Hostname{testFQDN},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches:     []gatewayv1.HTTPRouteMatch{util.NewGatewayPathPrefixMatch(path)},
+				Filters:     filters,
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: util.NewGatewayServiceBackendRef(targetServiceName, targetPort)}},
+			}},
+		},
+	}
+}
+
+func expectApplyHTTPRoute(t *testing.T, httpRouteInterfaceMock *mockHttpRouteInterface, expectedRoute *gatewayv1.HTTPRoute) {
+	httpRouteInterfaceMock.EXPECT().Get(testCtx, expectedRoute.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedRoute.Name))
+	httpRouteInterfaceMock.EXPECT().Patch(testCtx, expectedRoute.Name, types.ApplyPatchType, mock.Anything, metav1.PatchOptions{FieldManager: "k8s-service-discovery"}).
+		Return(nil, nil).
+		Run(func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+			appliedRoute := &gatewayv1.HTTPRoute{}
+			require.NoError(t, json.Unmarshal(data, appliedRoute))
+			assert.Equal(t, expectedRoute, appliedRoute)
+		})
+}
@@ -0,0 +1,281 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	ingressConflictEventReason = "IngressConflict"
+	// routerPriorityAnnotation defines the priority of the traefik router generated for an ingress object.
+	routerPriorityAnnotation = "traefik.ingress.kubernetes.io/router.priority"
+	// routerPriorityBase lifts the priorities of the dogu routers above the default priorities of traefik, which are
+	// derived from the length of the router rule.
+	routerPriorityBase = 1000
+	// replacePathRegexSuffix is appended to the ingress path of ces services using a managed path replacement.
+	replacePathRegexSuffix = "(/|$)(.*)"
+)
+
+// ingressRoute identifies the external route of an ingress object.
+type ingressRoute struct {
+	ingressName string
+	serviceName string
+	host        string
+	// path is the normalized path of the route, e.g., `/nexus`.
+	path string
+}
+
+func newRouteOfCesService(service *corev1.Service, cesService resolvedCesService) ingressRoute {
+	path := cesService.Location
+	if rewriteCfg, err := cesService.getRewriteConfig(); err == nil {
+		path = rewriteCfg.Pattern
+	}
+
+	return ingressRoute{
+		ingressName: cesService.Name,
+		serviceName: service.Name,
+		host:        cesService.Host,
+		path:        normalizeRoutePath(path),
+	}
+}
+
+func newRouteOfIngress(ingress networking.Ingress) (ingressRoute, bool) {
+	if len(ingress.OwnerReferences) == 0 || len(ingress.Spec.Rules) == 0 {
+		return ingressRoute{}, false
+	}
+
+	rule := ingress.Spec.Rules[0]
+	if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+		return ingressRoute{}, false
+	}
+
+	return ingressRoute{
+		ingressName: ingress.Name,
+		serviceName: ingress.OwnerReferences[0].Name,
+		host:        rule.Host,
+		path:        normalizeRoutePath(rule.HTTP.Paths[0].Path),
+	}, true
+}
+
+// normalizeRoutePath removes the regex suffix of path replacements and surplus slashes from the given ingress path.
+func normalizeRoutePath(path string) string {
+	path = strings.TrimSuffix(path, replacePathRegexSuffix)
+	path = strings.Trim(path, "/")
+
+	return "/" + path
+}
+
+// getRouterPriority derives the router priority from the specificity of the given ingress path. Longer paths are
+// more specific, so a path always takes precedence over the paths it is nested in, e.g., `/nexus/v2` over `/nexus`.
+func getRouterPriority(path string) string {
+	return strconv.Itoa(getRouterPriorityValue(path))
+}
+
+func getRouterPriorityValue(path string) int {
+	return routerPriorityBase + len(normalizeRoutePath(path))
+}
+
+// conflictsWith returns true if both routes use the same ingress name or route the same host and path.
+func (r ingressRoute) conflictsWith(other ingressRoute) bool {
+	return r.ingressName == other.ingressName || (r.host == other.host && r.path == other.path)
+}
+
+// shadows returns true if the route is nested in the path of the other route, so it takes over a part of the other
+// route. Routes on the root path are not considered to be shadowed.
+func (r ingressRoute) shadows(other ingressRoute) bool {
+	return r.host == other.host && other.path != "/" && strings.HasPrefix(r.path, other.path+"/")
+}
+
+// winsAgainst decides deterministically which of two conflicting routes is exposed. The rules are applied in order:
+//  1. A route whose ingress is named like its service wins, e.g., the ces service `nexus` of the service `nexus`.
+//  2. A route whose path starts with the name of its service wins, e.g., `/nexus` of the service `nexus`.
+//  3. The route of the alphabetically first service wins.
+//  4. The route of the alphabetically first ingress wins.
+func (r ingressRoute) winsAgainst(other ingressRoute) bool {
+	if r.ownsName() != other.ownsName() {
+		return r.ownsName()
+	}
+
+	if r.ownsPath() != other.ownsPath() {
+		return r.ownsPath()
+	}
+
+	if r.serviceName != other.serviceName {
+		return r.serviceName < other.serviceName
+	}
+
+	return r.ingressName < other.ingressName
+}
+
+func (r ingressRoute) ownsName() bool {
+	return r.ingressName == r.serviceName
+}
+
+func (r ingressRoute) ownsPath() bool {
+	firstSegment, _, _ := strings.Cut(strings.TrimPrefix(r.path, "/"), "/")
+	return firstSegment == r.serviceName
+}
+
+// resolveIngressConflicts detects conflicts between the given ces services and the ingress objects of other services,
+// e.g., duplicate ingress names, identical paths and paths shadowing each other. It returns the ces services which
+// win all their conflicts and records a warning event for the dogu which lost a conflict.
+//
+// The ingress objects of other services which lost a conflict against a ces service are deleted, so the result
+// does not depend on the order of the reconciliations. Shadowed paths are resolved by the router priorities.
+func (i *ingressUpdater) resolveIngressConflicts(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService, ingresses []networking.Ingress) ([]resolvedCesService, error) {
+	var otherRoutes []ingressRoute
+	for _, ingress := range ingresses {
+		route, ok := newRouteOfIngress(ingress)
+		if ok && !isOwnedByService(ingress.GetOwnerReferences(), service) {
+			otherRoutes = append(otherRoutes, route)
+		}
+	}
+
+	return i.resolveRouteConflicts(ctx, service, cesServices, otherRoutes, i.deleteIngress)
+}
+
+func (i *ingressUpdater) deleteIngress(ctx context.Context, name string) error {
+	return i.ingressInterface.Delete(ctx, name, v1.DeleteOptions{})
+}
+
+// resolveRouteConflicts resolves the conflicts between the given ces services and the routes of other services
+// independent of the kind of the routing objects. Routing objects of other services which lost a conflict are
+// removed with the given delete function.
+func (i *ingressUpdater) resolveRouteConflicts(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService, otherRoutes []ingressRoute, deleteRoute func(ctx context.Context, name string) error) ([]resolvedCesService, error) {
+	ownRoutes := make([]ingressRoute, 0, len(cesServices))
+	for _, cesService := range cesServices {
+		ownRoutes = append(ownRoutes, newRouteOfCesService(service, cesService))
+	}
+
+	recorder := &conflictRecorder{updater: i, service: service, targets: map[string]runtime.Object{}}
+	var winners []resolvedCesService
+	for index := range ownRoutes {
+		won, err := i.resolveConflictsOfRoute(ctx, recorder, index, ownRoutes, otherRoutes, deleteRoute)
+		if err != nil {
+			return nil, err
+		}
+
+		if won {
+			winners = append(winners, cesServices[index])
+		}
+	}
+
+	return winners, nil
+}
+
+func (i *ingressUpdater) resolveConflictsOfRoute(ctx context.Context, recorder *conflictRecorder, routeIndex int, ownRoutes []ingressRoute, otherRoutes []ingressRoute, deleteRoute func(ctx context.Context, name string) error) (bool, error) {
+	route := ownRoutes[routeIndex]
+	for index, ownRoute := range ownRoutes {
+		if index != routeIndex && route.conflictsWith(ownRoute) && ownRoute.winsAgainst(route) {
+			return false, recorder.record(ctx, route.serviceName, "Ingress [%s] for path [%s] is not created as it conflicts with ingress [%s] of service [%s].", route.ingressName, route.path, ownRoute.ingressName, ownRoute.serviceName)
+		}
+	}
+
+	for _, otherRoute := range otherRoutes {
+		if !route.conflictsWith(otherRoute) {
+			continue
+		}
+
+		if otherRoute.winsAgainst(route) {
+			return false, recorder.record(ctx, route.serviceName, "Ingress [%s] for path [%s] is not created as it conflicts with ingress [%s] of service [%s].", route.ingressName, route.path, otherRoute.ingressName, otherRoute.serviceName)
+		}
+
+		if otherRoute.ingressName == route.ingressName {
+			// the ingress object is taken over by applying the ingress of the winning route
+			err := recorder.record(ctx, otherRoute.serviceName, "Ingress [%s] is taken over by service [%s] as both expose a ces service with this name.", otherRoute.ingressName, route.serviceName)
+			if err != nil {
+				return false, err
+			}
+
+			continue
+		}
+
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("ingress [%s] of service [%s] conflicts with ingress [%s] -> delete ingress object", otherRoute.ingressName, otherRoute.serviceName, route.ingressName))
+		err := deleteRoute(ctx, otherRoute.ingressName)
+		if err != nil && !errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete conflicting ingress %s: %w", otherRoute.ingressName, err)
+		}
+
+		err = recorder.record(ctx, otherRoute.serviceName, "Ingress [%s] for path [%s] is deleted as it conflicts with ingress [%s] of service [%s].", otherRoute.ingressName, otherRoute.path, route.ingressName, route.serviceName)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, otherRoute := range otherRoutes {
+		// shadowed paths are resolved by the router priorities and persist across reconciliations, so they are only
+		// logged instead of recording the same event on every reconciliation
+		if otherRoute.shadows(route) {
+			ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("path [%s] of ingress [%s] is shadowed by the more specific path [%s] of ingress [%s] of service [%s]", route.path, route.ingressName, otherRoute.path, otherRoute.ingressName, otherRoute.serviceName))
+		}
+	}
+
+	return true, nil
+}
+
+// conflictRecorder records conflict events for dogus and caches the targets of the events.
+type conflictRecorder struct {
+	updater *ingressUpdater
+	// service is the reconciled service.
+	service *corev1.Service
+	targets map[string]runtime.Object
+}
+
+// record records a conflict event for the dogu of the given service. Services which don't belong to a dogu, e.g., the
+// owners of labelled ingress objects created by hand, receive the event themselves. The conflict is only logged if
+// the service can't be found either.
+func (cr *conflictRecorder) record(ctx context.Context, serviceName string, messageFmt string, args ...interface{}) error {
+	target, ok := cr.targets[serviceName]
+	if !ok {
+		var err error
+		target, err = cr.getTarget(ctx, serviceName)
+		if err != nil {
+			return err
+		}
+
+		cr.targets[serviceName] = target
+	}
+
+	if target == nil {
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("no dogu or service [%s] found for the conflict event: %s", serviceName, fmt.Sprintf(messageFmt, args...)))
+		return nil
+	}
+
+	cr.updater.eventRecorder.Eventf(target, corev1.EventTypeWarning, ingressConflictEventReason, messageFmt, args...)
+	return nil
+}
+
+// getTarget returns the dogu of the given service, the service itself if it doesn't belong to a dogu or nil if
+// neither exists.
+func (cr *conflictRecorder) getTarget(ctx context.Context, serviceName string) (runtime.Object, error) {
+	dogu, err := cr.updater.doguInterface.Get(ctx, serviceName, v1.GetOptions{})
+	if err == nil {
+		return dogu, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get dogu for service [%s]: %w", serviceName, err)
+	}
+
+	if serviceName == cr.service.Name {
+		return cr.service, nil
+	}
+
+	service, err := cr.updater.serviceInterface.Get(ctx, serviceName, v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service [%s]: %w", serviceName, err)
+	}
+
+	return service, nil
+}
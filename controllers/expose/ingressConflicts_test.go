@@ -0,0 +1,382 @@
+package expose
+
+import (
+	"testing"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_normalizeRoutePath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "root path", path: "/", want: "/"},
+		{name: "empty path", path: "", want: "/"},
+		{name: "simple path", path: "/nexus", want: "/nexus"},
+		{name: "trailing slash", path: "/nexus/", want: "/nexus"},
+		{name: "missing leading slash", path: "nexus/v2", want: "/nexus/v2"},
+		{name: "path replacement", path: "/nexus(/|$)(.*)", want: "/nexus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeRoutePath(tt.path))
+		})
+	}
+}
+
+func Test_getRouterPriority(t *testing.T) {
+	assert.Equal(t, "1001", getRouterPriority("/"))
+	assert.Equal(t, "1006", getRouterPriority("/nexus"))
+	assert.Equal(t, "1006", getRouterPriority("/nexus(/|$)(.*)"))
+	assert.Equal(t, "1009", getRouterPriority("/nexus/v2"))
+}
+
+func Test_ingressRoute_winsAgainst(t *testing.T) {
+	tests := []struct {
+		name  string
+		route ingressRoute
+		other ingressRoute
+		want  bool
+	}{
+		{
+			name:  "route named like its service wins",
+			route: ingressRoute{ingressName: "nexus", serviceName: "nexus", path: "/repo"},
+			other: ingressRoute{ingressName: "nexus", serviceName: "artifactory", path: "/artifactory"},
+			want:  true,
+		},
+		{
+			name:  "route not named like its service loses",
+			route: ingressRoute{ingressName: "nexus", serviceName: "artifactory", path: "/artifactory"},
+			other: ingressRoute{ingressName: "nexus", serviceName: "nexus", path: "/repo"},
+			want:  false,
+		},
+		{
+			name:  "route with path of its service wins",
+			route: ingressRoute{ingressName: "docker", serviceName: "nexus", path: "/nexus/v2"},
+			other: ingressRoute{ingressName: "registry", serviceName: "artifactory", path: "/nexus/v2"},
+			want:  true,
+		},
+		{
+			name:  "route of the alphabetically first service wins",
+			route: ingressRoute{ingressName: "registry", serviceName: "artifactory", path: "/v2"},
+			other: ingressRoute{ingressName: "docker", serviceName: "nexus", path: "/v2"},
+			want:  true,
+		},
+		{
+			name:  "route of the alphabetically first ingress wins",
+			route: ingressRoute{ingressName: "docker", serviceName: "nexus", path: "/v2"},
+			other: ingressRoute{ingressName: "registry", serviceName: "nexus", path: "/v2"},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.route.winsAgainst(tt.other))
+			assert.Equal(t, !tt.want, tt.other.winsAgainst(tt.route))
+		})
+	}
+}
+
+func Test_ingressRoute_shadows(t *testing.T) {
+	tests := []struct {
+		name  string
+		route ingressRoute
+		other ingressRoute
+		want  bool
+	}{
+		{
+			name:  "nested path shadows",
+			route: ingressRoute{host: testFQDN, path: "/nexus/v2"},
+			other: ingressRoute{host: testFQDN, path: "/nexus"},
+			want:  true,
+		},
+		{
+			name:  "path with same prefix does not shadow",
+			route: ingressRoute{host: testFQDN, path: "/nexus2"},
+			other: ingressRoute{host: testFQDN, path: "/nexus"},
+			want:  false,
+		},
+		{
+			name:  "path on other host does not shadow",
+			route: ingressRoute{host: "nexus." + testFQDN, path: "/nexus/v2"},
+			other: ingressRoute{host: testFQDN, path: "/nexus"},
+			want:  false,
+		},
+		{
+			name:  "root path is not shadowed",
+			route: ingressRoute{host: testFQDN, path: "/nexus"},
+			other: ingressRoute{host: testFQDN, path: "/"},
+			want:  false,
+		},
+		{
+			name:  "identical path does not shadow",
+			route: ingressRoute{host: testFQDN, path: "/nexus"},
+			other: ingressRoute{host: testFQDN, path: "/nexus"},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.route.shadows(tt.other))
+		})
+	}
+}
+
+func Test_ingressUpdater_resolveIngressConflicts(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: testNamespace, UID: "nexus-uid"}}
+	nexusDogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: testNamespace}}
+	otherDogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "artifactory", Namespace: testNamespace}}
+	newRoutedIngress := func(name, serviceName string, uid types.UID, path string) v1.Ingress {
+		return v1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, OwnerReferences: []metav1.OwnerReference{{Name: serviceName, UID: uid}}},
+			Spec: v1.IngressSpec{Rules: []v1.IngressRule{{
+				Host:             testFQDN,
+				IngressRuleValue: v1.IngressRuleValue{HTTP: &v1.HTTPIngressRuleValue{Paths: []v1.HTTPIngressPath{{Path: path}}}},
+			}}},
+		}
+	}
+
+	t.Run("should keep ces services without conflicts", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{
+			newRoutedIngress("nexus", "nexus", "nexus-uid", "/nexus"),
+			newRoutedIngress("artifactory", "artifactory", "artifactory-uid", "/artifactory"),
+			{ObjectMeta: metav1.ObjectMeta{Name: "ces-alternative-fqdn"}},
+		}
+
+		sut := ingressUpdater{}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices, actual)
+	})
+	t.Run("should skip ces service losing against the path of another service", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}},
+			{CesService: CesService{Name: "nexus-artifactory", Location: "/artifactory", Host: testFQDN}},
+		}
+		ingresses := []v1.Ingress{newRoutedIngress("artifactory", "artifactory", "artifactory-uid", "/artifactory")}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "nexus", metav1.GetOptions{}).Return(nexusDogu, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(nexusDogu, "Warning", "IngressConflict", "Ingress [%s] for path [%s] is not created as it conflicts with ingress [%s] of service [%s].", "nexus-artifactory", "/artifactory", "artifactory", "artifactory")
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock, eventRecorder: recorderMock}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices[:1], actual)
+	})
+	t.Run("should delete ingress of another service losing against the path", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("artifactory-nexus", "artifactory", "artifactory-uid", "/nexus")}
+
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "artifactory-nexus", metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "artifactory-nexus"))
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "artifactory", metav1.GetOptions{}).Return(otherDogu, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(otherDogu, "Warning", "IngressConflict", "Ingress [%s] for path [%s] is deleted as it conflicts with ingress [%s] of service [%s].", "artifactory-nexus", "/nexus", "nexus", "nexus")
+
+		sut := ingressUpdater{ingressInterface: ingressInterfaceMock, doguInterface: doguInterfaceMock, eventRecorder: recorderMock}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices, actual)
+	})
+	t.Run("should take over ingress of another service with the same name", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("nexus", "artifactory", "artifactory-uid", "/repository")}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "artifactory", metav1.GetOptions{}).Return(otherDogu, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(otherDogu, "Warning", "IngressConflict", "Ingress [%s] is taken over by service [%s] as both expose a ces service with this name.", "nexus", "nexus")
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock, eventRecorder: recorderMock}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices, actual)
+	})
+	t.Run("should keep shadowed path without event", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("artifactory-docker", "artifactory", "artifactory-uid", "/nexus/v2")}
+
+		sut := ingressUpdater{}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices, actual)
+	})
+	t.Run("should skip conflicting ces service of the same service", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "nexus-docker", Location: "/v2", Host: testFQDN}},
+			{CesService: CesService{Name: "nexus-registry", Location: "/v2/", Host: testFQDN}},
+		}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "nexus", metav1.GetOptions{}).Return(nexusDogu, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(nexusDogu, "Warning", "IngressConflict", "Ingress [%s] for path [%s] is not created as it conflicts with ingress [%s] of service [%s].", "nexus-registry", "/v2", "nexus-docker", "nexus")
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock, eventRecorder: recorderMock}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices[:1], actual)
+	})
+	t.Run("should record event for the service of another service without dogu", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("nexus", "docs", "docs-uid", "/docs")}
+		docsService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "docs", Namespace: testNamespace}}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "docs", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "docs"))
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Get(testCtx, "docs", metav1.GetOptions{}).Return(docsService, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(docsService, "Warning", "IngressConflict", "Ingress [%s] is taken over by service [%s] as both expose a ces service with this name.", "nexus", "nexus")
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock, serviceInterface: serviceInterfaceMock, eventRecorder: recorderMock}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices, actual)
+	})
+	t.Run("should record event for the reconciled service without dogu", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "nexus-docker", Location: "/v2", Host: testFQDN}},
+			{CesService: CesService{Name: "nexus-registry", Location: "/v2/", Host: testFQDN}},
+		}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "nexus", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "nexus"))
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(service, "Warning", "IngressConflict", "Ingress [%s] for path [%s] is not created as it conflicts with ingress [%s] of service [%s].", "nexus-registry", "/v2", "nexus-docker", "nexus")
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock, eventRecorder: recorderMock}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices[:1], actual)
+	})
+	t.Run("should only log the conflict without dogu and service", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("nexus", "docs", "docs-uid", "/docs")}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "docs", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "docs"))
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Get(testCtx, "docs", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "docs"))
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock, serviceInterface: serviceInterfaceMock}
+
+		// when
+		actual, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cesServices, actual)
+	})
+	t.Run("should fail to get service for event", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("nexus", "docs", "docs-uid", "/docs")}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "docs", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "docs"))
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Get(testCtx, "docs", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock, serviceInterface: serviceInterfaceMock}
+
+		// when
+		_, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get service [docs]")
+	})
+	t.Run("should fail to get dogu for event", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("nexus", "artifactory", "artifactory-uid", "/repository")}
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "artifactory", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := ingressUpdater{doguInterface: doguInterfaceMock}
+
+		// when
+		_, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get dogu for service [artifactory]")
+	})
+	t.Run("should fail to delete conflicting ingress", func(t *testing.T) {
+		// given
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "nexus", Location: "/nexus", Host: testFQDN}}}
+		ingresses := []v1.Ingress{newRoutedIngress("artifactory-nexus", "artifactory", "artifactory-uid", "/nexus")}
+
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "artifactory-nexus", metav1.DeleteOptions{}).Return(assert.AnError)
+
+		sut := ingressUpdater{ingressInterface: ingressInterfaceMock}
+
+		// when
+		_, err := sut.resolveIngressConflicts(testCtx, service, cesServices, ingresses)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete conflicting ingress artifactory-nexus")
+	})
+}
@@ -2,9 +2,10 @@ package ingressController
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/traefik"
-	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 const (
@@ -16,35 +17,59 @@ type Dependencies struct {
 	IngressInterface ingressInterface
 	IngressClassName string
 	TraefikInterface traefikInterface
+	Recorder         eventRecorder
 	Namespace        string
+	// HTTPRouteInterface, TCPRouteInterface and UDPRouteInterface are used by the gateway api controller.
+	HTTPRouteInterface httpRouteInterface
+	TCPRouteInterface  tcpRouteInterface
+	UDPRouteInterface  udpRouteInterface
+	// GatewayName is the name of the gateway the routes of the gateway api controller are attached to.
+	GatewayName string
+	// ConfigMapInterface is used by the ingress-nginx controller to expose tcp and udp ports.
+	ConfigMapInterface configMapInterface
+	// GlobalConfigRepository is used by the traefik controllers to attach the global middlewares to the redirect
+	// ingress of the alternative fqdns.
+	GlobalConfigRepository globalConfigRepository
 }
 
-func ParseIngressController(deps Dependencies) IngressController {
-	switch deps.Controller {
-	case traefik.GatewayControllerName:
-		return traefik.NewTraefikController(traefik.IngressControllerDependencies{
-			IngressInterface: deps.IngressInterface,
-			IngressClassName: deps.IngressClassName,
-			ControllerType:   traefik.GatewayControllerName,
-			TraefikInterface: deps.TraefikInterface,
-			Namespace:        deps.Namespace,
-		})
-	case traefik.IngressControllerName:
-		return traefik.NewTraefikController(traefik.IngressControllerDependencies{
-			IngressInterface: deps.IngressInterface,
-			IngressClassName: deps.IngressClassName,
-			ControllerType:   traefik.IngressControllerName,
-			TraefikInterface: deps.TraefikInterface,
-			Namespace:        deps.Namespace,
-		})
-	default:
-		ctrl.Log.WithName("k8s-service-discovery.ParseIngressController").Error(fmt.Errorf("could not parse ingress controller %q. using default: %q", deps.Controller, DefaultIngressController), "unknown ingress controller")
-		return traefik.NewTraefikController(traefik.IngressControllerDependencies{
-			IngressInterface: deps.IngressInterface,
-			IngressClassName: deps.IngressClassName,
-			ControllerType:   DefaultIngressController,
-			TraefikInterface: deps.TraefikInterface,
-			Namespace:        deps.Namespace,
-		})
+// Factory creates the ingress controller of a backend from the given dependencies.
+type Factory func(deps Dependencies) IngressController
+
+var registry = map[string]Factory{}
+
+// Register registers the factory of an ingress controller backend under the given name. Backends register themselves
+// on initialization, so registering a name twice is a programming error and panics.
+func Register(name string, factory Factory) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("ingress controller %q is already registered", name))
 	}
+
+	registry[name] = factory
+}
+
+// RegisteredControllers returns the sorted names of all registered ingress controllers.
+func RegisteredControllers() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// ParseIngressController creates the registered ingress controller with the name of the dependencies. If no name is
+// given, the default ingress controller is created. An unknown name results in an error.
+func ParseIngressController(deps Dependencies) (IngressController, error) {
+	name := deps.Controller
+	if name == "" {
+		name = DefaultIngressController
+	}
+
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown ingress controller %q, expected one of [%s]", name, strings.Join(RegisteredControllers(), ", "))
+	}
+
+	return factory(deps), nil
 }
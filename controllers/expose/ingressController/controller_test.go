@@ -3,24 +3,92 @@ package ingressController
 import (
 	"testing"
 
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/gatewayapi"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/nginx"
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/traefik"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestParseIngressController(t *testing.T) {
 	t.Run("should create traefik controller", func(t *testing.T) {
 		// when
-		controller := ParseIngressController(Dependencies{Controller: "traefik-ingress"})
+		controller, err := ParseIngressController(Dependencies{Controller: "traefik"})
 
 		// then
+		require.NoError(t, err)
 		require.IsType(t, &traefik.IngressController{}, controller)
+		assert.Equal(t, "traefik", controller.GetName())
 	})
 
-	t.Run("should create traefik controller as default", func(t *testing.T) {
+	t.Run("should create traefik controller of the ces gateway", func(t *testing.T) {
 		// when
-		controller := ParseIngressController(Dependencies{Controller: "does not exists in switch case"})
+		controller, err := ParseIngressController(Dependencies{Controller: "k8s-ces-gateway"})
 
 		// then
+		require.NoError(t, err)
 		require.IsType(t, &traefik.IngressController{}, controller)
+		assert.Equal(t, "k8s-ces-gateway", controller.GetName())
+	})
+
+	t.Run("should create gateway api controller", func(t *testing.T) {
+		// when
+		controller, err := ParseIngressController(Dependencies{Controller: "gateway-api", GatewayName: "ces-gateway"})
+
+		// then
+		require.NoError(t, err)
+		require.IsType(t, &gatewayapi.GatewayController{}, controller)
+		assert.Equal(t, "gateway-api", controller.GetName())
+	})
+
+	t.Run("should create ingress-nginx controller", func(t *testing.T) {
+		// when
+		controller, err := ParseIngressController(Dependencies{Controller: "ingress-nginx"})
+
+		// then
+		require.NoError(t, err)
+		require.IsType(t, &nginx.NginxController{}, controller)
+		assert.Equal(t, "ingress-nginx", controller.GetName())
+	})
+
+	t.Run("should create default controller without name", func(t *testing.T) {
+		// when
+		controller, err := ParseIngressController(Dependencies{})
+
+		// then
+		require.NoError(t, err)
+		require.IsType(t, &traefik.IngressController{}, controller)
+		assert.Equal(t, DefaultIngressController, controller.GetName())
+	})
+
+	t.Run("should fail on unknown controller", func(t *testing.T) {
+		// when
+		_, err := ParseIngressController(Dependencies{Controller: "does not exist"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `unknown ingress controller "does not exist", expected one of [gateway-api, ingress-nginx, k8s-ces-gateway, traefik]`)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("should panic if a controller is registered twice", func(t *testing.T) {
+		// when
+		register := func() {
+			Register(traefik.IngressControllerName, newTraefikFactory(traefik.IngressControllerName))
+		}
+
+		// then
+		assert.PanicsWithValue(t, `ingress controller "traefik" is already registered`, register)
+	})
+}
+
+func TestRegisteredControllers(t *testing.T) {
+	t.Run("should return the sorted names of all registered controllers", func(t *testing.T) {
+		// when
+		actual := RegisteredControllers()
+
+		// then
+		assert.Equal(t, []string{"gateway-api", "ingress-nginx", "k8s-ces-gateway", "traefik"}, actual)
 	})
 }
@@ -0,0 +1,17 @@
+package ingressController
+
+import (
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/gatewayapi"
+)
+
+func init() {
+	Register(gatewayapi.ControllerName, func(deps Dependencies) IngressController {
+		return gatewayapi.NewGatewayController(gatewayapi.ControllerDependencies{
+			HTTPRouteInterface: deps.HTTPRouteInterface,
+			TCPRouteInterface:  deps.TCPRouteInterface,
+			UDPRouteInterface:  deps.UDPRouteInterface,
+			Recorder:           deps.Recorder,
+			GatewayName:        deps.GatewayName,
+		})
+	})
+}
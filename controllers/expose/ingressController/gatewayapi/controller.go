@@ -0,0 +1,91 @@
+package gatewayapi
+
+import (
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+)
+
+const (
+	// ControllerName selects the Kubernetes Gateway API as ingress controller.
+	ControllerName = "gateway-api"
+	// DefaultGatewayName is the default name of the gateway to which all generated routes are attached.
+	DefaultGatewayName = "ces-gateway"
+
+	// gatewayNameLabel is set by the implementations of the Gateway API on the pods of a gateway.
+	gatewayNameLabel = "gateway.networking.k8s.io/gateway-name"
+)
+
+// GatewayController exposes dogus via the Kubernetes Gateway API. Instead of ingress objects and Traefik middlewares,
+// it generates HTTPRoutes with filters as well as TCPRoutes and UDPRoutes. All routes are attached to a gateway in
+// the namespace of the ecosystem.
+type GatewayController struct {
+	*PortExposer
+	*RouteRedirector
+	gatewayName string
+}
+
+type ControllerDependencies struct {
+	HTTPRouteInterface httpRouteInterface
+	TCPRouteInterface  tcpRouteInterface
+	UDPRouteInterface  udpRouteInterface
+	Recorder           eventRecorder
+	GatewayName        string
+}
+
+func NewGatewayController(deps ControllerDependencies) *GatewayController {
+	return &GatewayController{
+		PortExposer: &PortExposer{
+			httpRouteInterface: deps.HTTPRouteInterface,
+			tcpRouteInterface:  deps.TCPRouteInterface,
+			udpRouteInterface:  deps.UDPRouteInterface,
+			recorder:           deps.Recorder,
+			gatewayName:        deps.GatewayName,
+		},
+		RouteRedirector: &RouteRedirector{
+			httpRouteInterface: deps.HTTPRouteInterface,
+			recorder:           deps.Recorder,
+			gatewayName:        deps.GatewayName,
+		},
+		gatewayName: deps.GatewayName,
+	}
+}
+
+func (c *GatewayController) GetName() string {
+	return ControllerName
+}
+
+// GetRewriteAnnotationKey returns no key because the routes of the Gateway API rewrite paths with filters instead of
+// annotations.
+func (c *GatewayController) GetRewriteAnnotationKey() string {
+	return ""
+}
+
+func (c *GatewayController) GetSelector() map[string]string {
+	return map[string]string{gatewayNameLabel: c.gatewayName}
+}
+
+// NewRoutingUpdater creates an updater of http routes regardless of the traefik routing mode.
+func (c *GatewayController) NewRoutingUpdater(_ string, deps expose.IngressUpdaterDependencies) (expose.RoutingUpdater, error) {
+	return expose.NewHTTPRouteUpdater(deps), nil
+}
+
+// UsesMiddlewares returns false because the routes of the Gateway API rewrite paths with filters.
+func (c *GatewayController) UsesMiddlewares() bool {
+	return false
+}
+
+// GetPathRewriteAnnotations returns no annotations because the routes of the Gateway API rewrite paths with filters.
+func (c *GatewayController) GetPathRewriteAnnotations(_ string, _ string, _ string) map[string]string {
+	return nil
+}
+
+// GetMaintenanceModeAnnotations returns no annotations because the routes of the Gateway API serve the maintenance
+// page with filters.
+func (c *GatewayController) GetMaintenanceModeAnnotations(_ string) map[string]string {
+	return nil
+}
+
+// GetDoguStartingAnnotations returns no annotations because the routes of the Gateway API serve the page of starting
+// dogus with filters.
+func (c *GatewayController) GetDoguStartingAnnotations(_ string) map[string]string {
+	return nil
+}
@@ -0,0 +1,65 @@
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGatewayController(t *testing.T) {
+	// given
+	httpRouteMock := newMockHttpRouteInterface(t)
+	tcpRouteMock := newMockTcpRouteInterface(t)
+	udpRouteMock := newMockUdpRouteInterface(t)
+
+	// when
+	sut := NewGatewayController(ControllerDependencies{
+		HTTPRouteInterface: httpRouteMock,
+		TCPRouteInterface:  tcpRouteMock,
+		UDPRouteInterface:  udpRouteMock,
+		GatewayName:        testGatewayName,
+	})
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, httpRouteMock, sut.PortExposer.httpRouteInterface)
+	assert.Equal(t, tcpRouteMock, sut.PortExposer.tcpRouteInterface)
+	assert.Equal(t, udpRouteMock, sut.PortExposer.udpRouteInterface)
+	assert.Equal(t, testGatewayName, sut.PortExposer.gatewayName)
+	assert.Equal(t, httpRouteMock, sut.RouteRedirector.httpRouteInterface)
+	assert.Equal(t, testGatewayName, sut.RouteRedirector.gatewayName)
+}
+
+func TestGatewayController_GetName(t *testing.T) {
+	assert.Equal(t, "gateway-api", NewGatewayController(ControllerDependencies{}).GetName())
+}
+
+func TestGatewayController_GetRewriteAnnotationKey(t *testing.T) {
+	assert.Empty(t, NewGatewayController(ControllerDependencies{}).GetRewriteAnnotationKey())
+}
+
+func TestGatewayController_GetSelector(t *testing.T) {
+	sut := NewGatewayController(ControllerDependencies{GatewayName: testGatewayName})
+
+	assert.Equal(t, map[string]string{"gateway.networking.k8s.io/gateway-name": "ces-gateway"}, sut.GetSelector())
+}
+
+func TestGatewayController_NewRoutingUpdater(t *testing.T) {
+	// when
+	actual, err := NewGatewayController(ControllerDependencies{}).NewRoutingUpdater("ingressroute", expose.IngressUpdaterDependencies{})
+
+	// then
+	require.NoError(t, err)
+	assert.IsType(t, expose.NewHTTPRouteUpdater(expose.IngressUpdaterDependencies{}), actual)
+}
+
+func TestGatewayController_strategies(t *testing.T) {
+	sut := NewGatewayController(ControllerDependencies{})
+
+	assert.False(t, sut.UsesMiddlewares())
+	assert.Nil(t, sut.GetPathRewriteAnnotations("ecosystem", "nexus-replace-path", "/nexus/$2"))
+	assert.Nil(t, sut.GetMaintenanceModeAnnotations("ecosystem"))
+	assert.Nil(t, sut.GetDoguStartingAnnotations("ecosystem"))
+}
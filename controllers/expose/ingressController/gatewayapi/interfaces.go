@@ -0,0 +1,23 @@
+package gatewayapi
+
+import (
+	"k8s.io/client-go/tools/record"
+	gatewayv1client "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/typed/apis/v1"
+	gatewayv1alpha2client "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/typed/apis/v1alpha2"
+)
+
+type eventRecorder interface {
+	record.EventRecorder
+}
+
+type httpRouteInterface interface {
+	gatewayv1client.HTTPRouteInterface
+}
+
+type tcpRouteInterface interface {
+	gatewayv1alpha2client.TCPRouteInterface
+}
+
+type udpRouteInterface interface {
+	gatewayv1alpha2client.UDPRouteInterface
+}
@@ -0,0 +1,169 @@
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+type PortExposer struct {
+	httpRouteInterface httpRouteInterface
+	tcpRouteInterface  tcpRouteInterface
+	udpRouteInterface  udpRouteInterface
+	recorder           eventRecorder
+	gatewayName        string
+}
+
+// ExposePorts materializes the given TCP/UDP port forwards for the Gateway API by server-side applying a TCPRoute or
+// UDPRoute per exposed port. Each route is attached to the listener `tcp-<port>` or `udp-<port>` of the gateway.
+//
+// The given ports are treated as the complete desired state: labelled routes in the namespace which do not belong to
+// one of the given ports are deleted afterward.
+//
+// Only TCP and UDP protocols are supported. Any other protocol values are logged and ignored.
+func (p PortExposer) ExposePorts(ctx context.Context, namespace string, exposedPorts types.ExposedPorts) error {
+	logger := log.FromContext(ctx)
+
+	desiredTCPRoutes := make(map[string]struct{})
+	desiredUDPRoutes := make(map[string]struct{})
+
+	for _, port := range exposedPorts {
+		owner := p.getRouteOwner(ctx, port)
+
+		switch port.Protocol {
+		case corev1.ProtocolTCP:
+			route := p.createTCPRoute(namespace, port, owner)
+			desiredTCPRoutes[route.Name] = struct{}{}
+			if _, err := util.ServerSideApply[*gatewayv1alpha2.TCPRoute](ctx, p.tcpRouteInterface, route, p.recorder); err != nil {
+				return fmt.Errorf("failed to expose tcp port %s: failed to apply TCPRoute: %w", port.PortString(), err)
+			}
+		case corev1.ProtocolUDP:
+			route := p.createUDPRoute(namespace, port, owner)
+			desiredUDPRoutes[route.Name] = struct{}{}
+			if _, err := util.ServerSideApply[*gatewayv1alpha2.UDPRoute](ctx, p.udpRouteInterface, route, p.recorder); err != nil {
+				return fmt.Errorf("failed to expose udp port %s: failed to apply UDPRoute: %w", port.PortString(), err)
+			}
+		default:
+			logger.Info("unsupported protocol for exposed port, port will be ignored", "name", port.Name, "protocol", port.Protocol)
+		}
+	}
+
+	if err := p.deleteStaleTCPRoutes(ctx, desiredTCPRoutes); err != nil {
+		return fmt.Errorf("failed to delete stale tcp routes: %w", err)
+	}
+
+	if err := p.deleteStaleUDPRoutes(ctx, desiredUDPRoutes); err != nil {
+		return fmt.Errorf("failed to delete stale udp routes: %w", err)
+	}
+
+	return nil
+}
+
+func (p PortExposer) deleteStaleTCPRoutes(ctx context.Context, desiredRoutes map[string]struct{}) error {
+	routeList, err := p.tcpRouteInterface.List(ctx, metav1.ListOptions{LabelSelector: managedRouteSelector()})
+	if err != nil {
+		return fmt.Errorf("failed to list TCPRoutes: %w", err)
+	}
+
+	for _, route := range routeList.Items {
+		if _, desired := desiredRoutes[route.Name]; desired {
+			continue
+		}
+
+		log.FromContext(ctx).Info("port is no longer exposed, deleting TCPRoute", "name", route.Name)
+		if dErr := p.tcpRouteInterface.Delete(ctx, route.Name, metav1.DeleteOptions{}); dErr != nil && !apierrors.IsNotFound(dErr) {
+			return fmt.Errorf("failed to delete TCPRoute %s: %w", route.Name, dErr)
+		}
+	}
+
+	return nil
+}
+
+func (p PortExposer) deleteStaleUDPRoutes(ctx context.Context, desiredRoutes map[string]struct{}) error {
+	routeList, err := p.udpRouteInterface.List(ctx, metav1.ListOptions{LabelSelector: managedRouteSelector()})
+	if err != nil {
+		return fmt.Errorf("failed to list UDPRoutes: %w", err)
+	}
+
+	for _, route := range routeList.Items {
+		if _, desired := desiredRoutes[route.Name]; desired {
+			continue
+		}
+
+		log.FromContext(ctx).Info("port is no longer exposed, deleting UDPRoute", "name", route.Name)
+		if dErr := p.udpRouteInterface.Delete(ctx, route.Name, metav1.DeleteOptions{}); dErr != nil && !apierrors.IsNotFound(dErr) {
+			return fmt.Errorf("failed to delete UDPRoute %s: %w", route.Name, dErr)
+		}
+	}
+
+	return nil
+}
+
+func managedRouteSelector() string {
+	return labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).String()
+}
+
+func (p PortExposer) createTCPRoute(namespace string, port types.ExposedPort, ownerReferences []metav1.OwnerReference) *gatewayv1alpha2.TCPRoute {
+	return &gatewayv1alpha2.TCPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.GatewayAPIAlphaVersion,
+			Kind:       "TCPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-%s-tcp", port.ServiceName, port.PortString()),
+			Namespace:       namespace,
+			Labels:          util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{util.NewGatewayParentReference(p.gatewayName, fmt.Sprintf("tcp-%s", port.PortString()))},
+			},
+			Rules: []gatewayv1alpha2.TCPRouteRule{{
+				BackendRefs: []gatewayv1.BackendRef{util.NewGatewayServiceBackendRef(port.ServiceName, port.TargetPort)},
+			}},
+		},
+	}
+}
+
+func (p PortExposer) createUDPRoute(namespace string, port types.ExposedPort, ownerReferences []metav1.OwnerReference) *gatewayv1alpha2.UDPRoute {
+	return &gatewayv1alpha2.UDPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.GatewayAPIAlphaVersion,
+			Kind:       "UDPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-%s-udp", port.ServiceName, port.PortString()),
+			Namespace:       namespace,
+			Labels:          util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{util.NewGatewayParentReference(p.gatewayName, fmt.Sprintf("udp-%s", port.PortString()))},
+			},
+			Rules: []gatewayv1alpha2.UDPRouteRule{{
+				BackendRefs: []gatewayv1.BackendRef{util.NewGatewayServiceBackendRef(port.ServiceName, port.TargetPort)},
+			}},
+		},
+	}
+}
+
+// getRouteOwner returns the same owner references as the http route of the service of the given port. Might return nil.
+func (p PortExposer) getRouteOwner(ctx context.Context, port types.ExposedPort) []metav1.OwnerReference {
+	owner, err := p.httpRouteInterface.Get(ctx, port.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+
+	return owner.GetOwnerReferences()
+}
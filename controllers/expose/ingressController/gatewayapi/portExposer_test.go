@@ -0,0 +1,158 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	testNamespace   = "ecosystem"
+	testGatewayName = "ces-gateway"
+)
+
+var (
+	testRouteListOptions = metav1.ListOptions{LabelSelector: "app=ces,app.kubernetes.io/name=k8s-service-discovery"}
+	testApplyOptions     = metav1.PatchOptions{FieldManager: "k8s-service-discovery"}
+)
+
+func TestPortExposer_ExposePorts(t *testing.T) {
+	tcpPort := types.ExposedPort{Name: "svc-2222", ServiceName: "svc", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 22}
+	udpPort := types.ExposedPort{Name: "svc-5353", ServiceName: "svc", Protocol: corev1.ProtocolUDP, Port: 5353, TargetPort: 53}
+	owner := []metav1.OwnerReference{{APIVersion: "v1", Kind: "Service", Name: "svc", UID: "uid"}}
+
+	t.Run("should apply tcp and udp routes attached to the listeners of the gateway", func(t *testing.T) {
+		// given
+		httpRouteMock := newMockHttpRouteInterface(t)
+		httpRouteMock.EXPECT().Get(testCtx, "svc", metav1.GetOptions{}).Return(&gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{OwnerReferences: owner}}, nil).Times(2)
+
+		tcpRouteMock := newMockTcpRouteInterface(t)
+		tcpRouteMock.EXPECT().Get(testCtx, "svc-2222-tcp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-2222-tcp"))
+		tcpRouteMock.EXPECT().Patch(testCtx, "svc-2222-tcp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+			Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+				route := &gatewayv1alpha2.TCPRoute{}
+				require.NoError(t, json.Unmarshal(data, route))
+				assert.Equal(t, "TCPRoute", route.Kind)
+				assert.Equal(t, "gateway.networking.k8s.io/v1alpha2", route.APIVersion)
+				assert.Equal(t, owner, route.OwnerReferences)
+				assert.Equal(t, util.K8sCesServiceDiscoveryLabels, route.Labels)
+				assert.Equal(t, []gatewayv1.ParentReference{util.NewGatewayParentReference(testGatewayName, "tcp-2222")}, route.Spec.ParentRefs)
+				assert.Equal(t, []gatewayv1.BackendRef{util.NewGatewayServiceBackendRef("svc", 22)}, route.Spec.Rules[0].BackendRefs)
+			}).
+			Return(&gatewayv1alpha2.TCPRoute{}, nil)
+		tcpRouteMock.EXPECT().List(testCtx, testRouteListOptions).Return(&gatewayv1alpha2.TCPRouteList{}, nil)
+
+		udpRouteMock := newMockUdpRouteInterface(t)
+		udpRouteMock.EXPECT().Get(testCtx, "svc-5353-udp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-5353-udp"))
+		udpRouteMock.EXPECT().Patch(testCtx, "svc-5353-udp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+			Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+				route := &gatewayv1alpha2.UDPRoute{}
+				require.NoError(t, json.Unmarshal(data, route))
+				assert.Equal(t, "UDPRoute", route.Kind)
+				assert.Equal(t, []gatewayv1.ParentReference{util.NewGatewayParentReference(testGatewayName, "udp-5353")}, route.Spec.ParentRefs)
+				assert.Equal(t, []gatewayv1.BackendRef{util.NewGatewayServiceBackendRef("svc", 53)}, route.Spec.Rules[0].BackendRefs)
+			}).
+			Return(&gatewayv1alpha2.UDPRoute{}, nil)
+		udpRouteMock.EXPECT().List(testCtx, testRouteListOptions).Return(&gatewayv1alpha2.UDPRouteList{}, nil)
+
+		sut := PortExposer{httpRouteInterface: httpRouteMock, tcpRouteInterface: tcpRouteMock, udpRouteInterface: udpRouteMock, gatewayName: testGatewayName}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, types.ExposedPorts{tcpPort, udpPort})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should skip unchanged route and delete stale routes", func(t *testing.T) {
+		// given
+		httpRouteMock := newMockHttpRouteInterface(t)
+		httpRouteMock.EXPECT().Get(testCtx, "svc", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := PortExposer{httpRouteInterface: httpRouteMock, gatewayName: testGatewayName}
+
+		liveRoute := sut.createTCPRoute(testNamespace, tcpPort, nil)
+		liveRoute.ResourceVersion = "42"
+
+		tcpRouteMock := newMockTcpRouteInterface(t)
+		tcpRouteMock.EXPECT().Get(testCtx, "svc-2222-tcp", metav1.GetOptions{}).Return(liveRoute, nil)
+		tcpRouteMock.EXPECT().List(testCtx, testRouteListOptions).Return(&gatewayv1alpha2.TCPRouteList{Items: []gatewayv1alpha2.TCPRoute{*liveRoute, {ObjectMeta: metav1.ObjectMeta{Name: "old-2223-tcp"}}}}, nil)
+		tcpRouteMock.EXPECT().Delete(testCtx, "old-2223-tcp", metav1.DeleteOptions{}).Return(nil)
+		sut.tcpRouteInterface = tcpRouteMock
+
+		udpRouteMock := newMockUdpRouteInterface(t)
+		udpRouteMock.EXPECT().List(testCtx, testRouteListOptions).Return(&gatewayv1alpha2.UDPRouteList{Items: []gatewayv1alpha2.UDPRoute{{ObjectMeta: metav1.ObjectMeta{Name: "old-5354-udp"}}}}, nil)
+		udpRouteMock.EXPECT().Delete(testCtx, "old-5354-udp", metav1.DeleteOptions{}).Return(apierrors.NewNotFound(schema.GroupResource{}, "old-5354-udp"))
+		sut.udpRouteInterface = udpRouteMock
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, types.ExposedPorts{tcpPort})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to apply tcp route", func(t *testing.T) {
+		// given
+		httpRouteMock := newMockHttpRouteInterface(t)
+		httpRouteMock.EXPECT().Get(testCtx, "svc", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		tcpRouteMock := newMockTcpRouteInterface(t)
+		tcpRouteMock.EXPECT().Get(testCtx, "svc-2222-tcp", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := PortExposer{httpRouteInterface: httpRouteMock, tcpRouteInterface: tcpRouteMock, gatewayName: testGatewayName}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, types.ExposedPorts{tcpPort})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to expose tcp port 2222: failed to apply TCPRoute")
+	})
+	t.Run("should fail to list udp routes", func(t *testing.T) {
+		// given
+		tcpRouteMock := newMockTcpRouteInterface(t)
+		tcpRouteMock.EXPECT().List(testCtx, testRouteListOptions).Return(&gatewayv1alpha2.TCPRouteList{}, nil)
+
+		udpRouteMock := newMockUdpRouteInterface(t)
+		udpRouteMock.EXPECT().List(testCtx, testRouteListOptions).Return(nil, assert.AnError)
+
+		sut := PortExposer{tcpRouteInterface: tcpRouteMock, udpRouteInterface: udpRouteMock, gatewayName: testGatewayName}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete stale udp routes: failed to list UDPRoutes")
+	})
+	t.Run("should fail to delete stale tcp route", func(t *testing.T) {
+		// given
+		tcpRouteMock := newMockTcpRouteInterface(t)
+		tcpRouteMock.EXPECT().List(testCtx, testRouteListOptions).Return(&gatewayv1alpha2.TCPRouteList{Items: []gatewayv1alpha2.TCPRoute{{ObjectMeta: metav1.ObjectMeta{Name: "old-2223-tcp"}}}}, nil)
+		tcpRouteMock.EXPECT().Delete(testCtx, "old-2223-tcp", metav1.DeleteOptions{}).Return(assert.AnError)
+
+		sut := PortExposer{tcpRouteInterface: tcpRouteMock, gatewayName: testGatewayName}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete TCPRoute old-2223-tcp")
+	})
+}
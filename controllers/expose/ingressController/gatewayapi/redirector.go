@@ -0,0 +1,90 @@
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const redirectPath = "/"
+
+type RouteRedirector struct {
+	httpRouteInterface httpRouteInterface
+	recorder           eventRecorder
+	gatewayName        string
+}
+
+// RedirectAlternativeFQDN redirects all requests to the given alternative FQDNs to the primary FQDN by a http route
+// with a RequestRedirect filter.
+//
+// The certificates of the alternative FQDNs are not part of the route. They must be configured at the listeners of
+// the gateway.
+func (r RouteRedirector) RedirectAlternativeFQDN(ctx context.Context, namespace string, redirectObjectName string, fqdn string, altFQDNList []types.AlternativeFQDN, setOwner func(targetObject metav1.Object) error) error {
+	logger := log.FromContext(ctx)
+
+	if len(altFQDNList) == 0 {
+		if dErr := r.httpRouteInterface.Delete(ctx, redirectObjectName, metav1.DeleteOptions{}); dErr != nil && !apierrors.IsNotFound(dErr) {
+			return fmt.Errorf("failed to delete redirect http route: %w", dErr)
+		}
+		logger.Info("no alternative FQDN configured, cleared redirect http route")
+		return nil
+	}
+
+	redirectRoute := r.createRedirectRoute(namespace, redirectObjectName, fqdn, altFQDNList)
+
+	if oErr := setOwner(redirectRoute); oErr != nil {
+		return fmt.Errorf("failed to set owner for redirect http route: %w", oErr)
+	}
+
+	if _, err := util.ServerSideApply[*gatewayv1.HTTPRoute](ctx, r.httpRouteInterface, redirectRoute, r.recorder); err != nil {
+		return fmt.Errorf("failed to apply redirect http route: %w", err)
+	}
+
+	logger.Info("applied new redirect http route")
+
+	return nil
+}
+
+func (r RouteRedirector) createRedirectRoute(namespace string, objectName string, fqdn string, altFQDNList []types.AlternativeFQDN) *gatewayv1.HTTPRoute {
+	hostnames := make([]gatewayv1.Hostname, 0, len(altFQDNList))
+	for _, altFQDN := range altFQDNList {
+		hostnames = append(hostnames, gatewayv1.Hostname(altFQDN.FQDN))
+	}
+
+	return &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.GatewayAPIVersion,
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objectName,
+			Namespace: namespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{util.NewGatewayParentReference(r.gatewayName, "")},
+			},
+			Hostnames: hostnames,
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{util.NewGatewayPathPrefixMatch(redirectPath)},
+				Filters: []gatewayv1.HTTPRouteFilter{{
+					Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+						Scheme:     ptr.To("https"),
+						Hostname:   ptr.To(gatewayv1.PreciseHostname(fqdn)),
+						StatusCode: ptr.To(http.StatusMovedPermanently),
+					},
+				}},
+			}},
+		},
+	}
+}
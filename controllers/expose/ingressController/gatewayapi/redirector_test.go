@@ -0,0 +1,126 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var testCtx = context.Background()
+
+func TestRouteRedirector_RedirectAlternativeFQDN(t *testing.T) {
+	altFQDNs := []types.AlternativeFQDN{
+		{FQDN: "alt1.example.com", CertificateSecretName: "ecosystem-certificate"},
+		{FQDN: "alt2.example.com", CertificateSecretName: "alt2-certificate"},
+	}
+	noOwner := func(targetObject metav1.Object) error { return nil }
+
+	t.Run("should apply http route redirecting the alternative fqdns", func(t *testing.T) {
+		// given
+		httpRouteMock := newMockHttpRouteInterface(t)
+		httpRouteMock.EXPECT().Get(testCtx, "ces-alternative-fqdn", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "ces-alternative-fqdn"))
+		httpRouteMock.EXPECT().Patch(testCtx, "ces-alternative-fqdn", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+			Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+				route := &gatewayv1.HTTPRoute{}
+				require.NoError(t, json.Unmarshal(data, route))
+				assert.Equal(t, "HTTPRoute", route.Kind)
+				assert.Equal(t, "gateway.networking.k8s.io/v1", route.APIVersion)
+				assert.Equal(t, "owner", route.OwnerReferences[0].Name)
+				assert.Equal(t, []gatewayv1.ParentReference{util.NewGatewayParentReference(testGatewayName, "")}, route.Spec.ParentRefs)
+				assert.Equal(t, []gatewayv1.Hostname{"alt1.example.com", "alt2.example.com"}, route.Spec.Hostnames)
+				assert.Equal(t, []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{util.NewGatewayPathPrefixMatch("/")},
+					Filters: []gatewayv1.HTTPRouteFilter{{
+						Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+						RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+							Scheme:     ptr.To("https"),
+							Hostname:   ptr.To(gatewayv1.PreciseHostname("ces.example.com")),
+							StatusCode: ptr.To(301),
+						},
+					}},
+				}}, route.Spec.Rules)
+			}).
+			Return(&gatewayv1.HTTPRoute{}, nil)
+
+		sut := RouteRedirector{httpRouteInterface: httpRouteMock, gatewayName: testGatewayName}
+		setOwner := func(targetObject metav1.Object) error {
+			targetObject.SetOwnerReferences([]metav1.OwnerReference{{Name: "owner"}})
+			return nil
+		}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", altFQDNs, setOwner)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should delete http route without alternative fqdns", func(t *testing.T) {
+		// given
+		httpRouteMock := newMockHttpRouteInterface(t)
+		httpRouteMock.EXPECT().Delete(testCtx, "ces-alternative-fqdn", metav1.DeleteOptions{}).Return(apierrors.NewNotFound(schema.GroupResource{}, "ces-alternative-fqdn"))
+
+		sut := RouteRedirector{httpRouteInterface: httpRouteMock, gatewayName: testGatewayName}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", nil, noOwner)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete http route", func(t *testing.T) {
+		// given
+		httpRouteMock := newMockHttpRouteInterface(t)
+		httpRouteMock.EXPECT().Delete(testCtx, "ces-alternative-fqdn", metav1.DeleteOptions{}).Return(assert.AnError)
+
+		sut := RouteRedirector{httpRouteInterface: httpRouteMock, gatewayName: testGatewayName}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", nil, noOwner)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete redirect http route")
+	})
+	t.Run("should fail to set owner", func(t *testing.T) {
+		// given
+		sut := RouteRedirector{gatewayName: testGatewayName}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", altFQDNs, func(targetObject metav1.Object) error {
+			return assert.AnError
+		})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to set owner for redirect http route")
+	})
+	t.Run("should fail to apply http route", func(t *testing.T) {
+		// given
+		httpRouteMock := newMockHttpRouteInterface(t)
+		httpRouteMock.EXPECT().Get(testCtx, "ces-alternative-fqdn", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := RouteRedirector{httpRouteInterface: httpRouteMock, gatewayName: testGatewayName}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", altFQDNs, noOwner)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to apply redirect http route")
+	})
+}
@@ -3,11 +3,16 @@ package ingressController
 import (
 	"context"
 
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
 	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
 	"github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/generated/clientset/versioned/typed/traefikio/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	netv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+	"k8s.io/client-go/tools/record"
+	gatewayv1client "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/typed/apis/v1"
+	gatewayv1alpha2client "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/typed/apis/v1alpha2"
 )
 
 type configMapInterface interface {
@@ -22,6 +27,26 @@ type traefikInterface interface {
 	v1alpha1.TraefikV1alpha1Interface
 }
 
+type httpRouteInterface interface {
+	gatewayv1client.HTTPRouteInterface
+}
+
+type tcpRouteInterface interface {
+	gatewayv1alpha2client.TCPRouteInterface
+}
+
+type udpRouteInterface interface {
+	gatewayv1alpha2client.UDPRouteInterface
+}
+
+type globalConfigRepository interface {
+	Get(ctx context.Context) (libconfig.GlobalConfig, error)
+}
+
+type eventRecorder interface {
+	record.EventRecorder
+}
+
 type AlternativeFQDNRedirector interface {
 	RedirectAlternativeFQDN(ctx context.Context, namespace string, redirectObjectName string, fqdn string, altFQDNList []types.AlternativeFQDN, setOwner func(targetObject metav1.Object) error) error
 }
@@ -30,10 +55,40 @@ type PortExposer interface {
 	ExposePorts(ctx context.Context, namespace string, exposedPorts types.ExposedPorts) error
 }
 
+// RewriteStrategy defines how a backend rewrites the paths of the ingress objects of the dogus.
+type RewriteStrategy interface {
+	GetRewriteAnnotationKey() string
+	// UsesMiddlewares returns true if the backend rewrites paths with traefik middlewares, which have to be created
+	// before they are referenced by the ingress objects.
+	UsesMiddlewares() bool
+	// GetPathRewriteAnnotations returns the annotations of an ingress object with the regex path
+	// `<location>(/|$)(.*)` which rewrite the requests either by the given middleware or to the given target path.
+	GetPathRewriteAnnotations(namespace string, middlewareName string, targetPath string) map[string]string
+}
+
+// MaintenanceStrategy defines how a backend serves the static pages of the maintenance mode and of starting dogus.
+type MaintenanceStrategy interface {
+	// GetMaintenanceModeAnnotations returns the annotations of an ingress object of the static content backend
+	// which serve the maintenance page.
+	GetMaintenanceModeAnnotations(namespace string) map[string]string
+	// GetDoguStartingAnnotations returns the annotations of an ingress object of the static content backend which
+	// serve the page of starting dogus.
+	GetDoguStartingAnnotations(namespace string) map[string]string
+}
+
+// RoutingStrategy defines by which routing objects a backend exposes the dogus.
+type RoutingStrategy interface {
+	// NewRoutingUpdater creates the updater which exposes the dogus with the routing objects of the backend. Backends
+	// with several kinds of routing objects choose them by the given traefik routing mode.
+	NewRoutingUpdater(routingMode string, deps expose.IngressUpdaterDependencies) (expose.RoutingUpdater, error)
+}
+
 type IngressController interface {
 	GetName() string
-	GetRewriteAnnotationKey() string
 	GetSelector() map[string]string
+	RoutingStrategy
+	RewriteStrategy
+	MaintenanceStrategy
 	AlternativeFQDNRedirector
 	PortExposer
 }
@@ -5,6 +5,7 @@ package ingressController
 import (
 	context "context"
 
+	expose "github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
 	types "github.com/cloudogu/k8s-service-discovery/v2/internal/types"
 	mock "github.com/stretchr/testify/mock"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,6 +72,102 @@ func (_c *MockIngressController_ExposePorts_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// GetDoguStartingAnnotations provides a mock function with given fields: namespace
+func (_m *MockIngressController) GetDoguStartingAnnotations(namespace string) map[string]string {
+	ret := _m.Called(namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDoguStartingAnnotations")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// MockIngressController_GetDoguStartingAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDoguStartingAnnotations'
+type MockIngressController_GetDoguStartingAnnotations_Call struct {
+	*mock.Call
+}
+
+// GetDoguStartingAnnotations is a helper method to define mock.On call
+//   - namespace string
+func (_e *MockIngressController_Expecter) GetDoguStartingAnnotations(namespace interface{}) *MockIngressController_GetDoguStartingAnnotations_Call {
+	return &MockIngressController_GetDoguStartingAnnotations_Call{Call: _e.mock.On("GetDoguStartingAnnotations", namespace)}
+}
+
+func (_c *MockIngressController_GetDoguStartingAnnotations_Call) Run(run func(namespace string)) *MockIngressController_GetDoguStartingAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockIngressController_GetDoguStartingAnnotations_Call) Return(_a0 map[string]string) *MockIngressController_GetDoguStartingAnnotations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIngressController_GetDoguStartingAnnotations_Call) RunAndReturn(run func(string) map[string]string) *MockIngressController_GetDoguStartingAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMaintenanceModeAnnotations provides a mock function with given fields: namespace
+func (_m *MockIngressController) GetMaintenanceModeAnnotations(namespace string) map[string]string {
+	ret := _m.Called(namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaintenanceModeAnnotations")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// MockIngressController_GetMaintenanceModeAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaintenanceModeAnnotations'
+type MockIngressController_GetMaintenanceModeAnnotations_Call struct {
+	*mock.Call
+}
+
+// GetMaintenanceModeAnnotations is a helper method to define mock.On call
+//   - namespace string
+func (_e *MockIngressController_Expecter) GetMaintenanceModeAnnotations(namespace interface{}) *MockIngressController_GetMaintenanceModeAnnotations_Call {
+	return &MockIngressController_GetMaintenanceModeAnnotations_Call{Call: _e.mock.On("GetMaintenanceModeAnnotations", namespace)}
+}
+
+func (_c *MockIngressController_GetMaintenanceModeAnnotations_Call) Run(run func(namespace string)) *MockIngressController_GetMaintenanceModeAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockIngressController_GetMaintenanceModeAnnotations_Call) Return(_a0 map[string]string) *MockIngressController_GetMaintenanceModeAnnotations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIngressController_GetMaintenanceModeAnnotations_Call) RunAndReturn(run func(string) map[string]string) *MockIngressController_GetMaintenanceModeAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetName provides a mock function with no fields
 func (_m *MockIngressController) GetName() string {
 	ret := _m.Called()
@@ -116,6 +213,56 @@ func (_c *MockIngressController_GetName_Call) RunAndReturn(run func() string) *M
 	return _c
 }
 
+// GetPathRewriteAnnotations provides a mock function with given fields: namespace, middlewareName, targetPath
+func (_m *MockIngressController) GetPathRewriteAnnotations(namespace string, middlewareName string, targetPath string) map[string]string {
+	ret := _m.Called(namespace, middlewareName, targetPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPathRewriteAnnotations")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string, string, string) map[string]string); ok {
+		r0 = rf(namespace, middlewareName, targetPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// MockIngressController_GetPathRewriteAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPathRewriteAnnotations'
+type MockIngressController_GetPathRewriteAnnotations_Call struct {
+	*mock.Call
+}
+
+// GetPathRewriteAnnotations is a helper method to define mock.On call
+//   - namespace string
+//   - middlewareName string
+//   - targetPath string
+func (_e *MockIngressController_Expecter) GetPathRewriteAnnotations(namespace interface{}, middlewareName interface{}, targetPath interface{}) *MockIngressController_GetPathRewriteAnnotations_Call {
+	return &MockIngressController_GetPathRewriteAnnotations_Call{Call: _e.mock.On("GetPathRewriteAnnotations", namespace, middlewareName, targetPath)}
+}
+
+func (_c *MockIngressController_GetPathRewriteAnnotations_Call) Run(run func(namespace string, middlewareName string, targetPath string)) *MockIngressController_GetPathRewriteAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIngressController_GetPathRewriteAnnotations_Call) Return(_a0 map[string]string) *MockIngressController_GetPathRewriteAnnotations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIngressController_GetPathRewriteAnnotations_Call) RunAndReturn(run func(string, string, string) map[string]string) *MockIngressController_GetPathRewriteAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRewriteAnnotationKey provides a mock function with no fields
 func (_m *MockIngressController) GetRewriteAnnotationKey() string {
 	ret := _m.Called()
@@ -208,6 +355,65 @@ func (_c *MockIngressController_GetSelector_Call) RunAndReturn(run func() map[st
 	return _c
 }
 
+// NewRoutingUpdater provides a mock function with given fields: routingMode, deps
+func (_m *MockIngressController) NewRoutingUpdater(routingMode string, deps expose.IngressUpdaterDependencies) (expose.RoutingUpdater, error) {
+	ret := _m.Called(routingMode, deps)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewRoutingUpdater")
+	}
+
+	var r0 expose.RoutingUpdater
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, expose.IngressUpdaterDependencies) (expose.RoutingUpdater, error)); ok {
+		return rf(routingMode, deps)
+	}
+	if rf, ok := ret.Get(0).(func(string, expose.IngressUpdaterDependencies) expose.RoutingUpdater); ok {
+		r0 = rf(routingMode, deps)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(expose.RoutingUpdater)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, expose.IngressUpdaterDependencies) error); ok {
+		r1 = rf(routingMode, deps)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIngressController_NewRoutingUpdater_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewRoutingUpdater'
+type MockIngressController_NewRoutingUpdater_Call struct {
+	*mock.Call
+}
+
+// NewRoutingUpdater is a helper method to define mock.On call
+//   - routingMode string
+//   - deps expose.IngressUpdaterDependencies
+func (_e *MockIngressController_Expecter) NewRoutingUpdater(routingMode interface{}, deps interface{}) *MockIngressController_NewRoutingUpdater_Call {
+	return &MockIngressController_NewRoutingUpdater_Call{Call: _e.mock.On("NewRoutingUpdater", routingMode, deps)}
+}
+
+func (_c *MockIngressController_NewRoutingUpdater_Call) Run(run func(routingMode string, deps expose.IngressUpdaterDependencies)) *MockIngressController_NewRoutingUpdater_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(expose.IngressUpdaterDependencies))
+	})
+	return _c
+}
+
+func (_c *MockIngressController_NewRoutingUpdater_Call) Return(_a0 expose.RoutingUpdater, _a1 error) *MockIngressController_NewRoutingUpdater_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIngressController_NewRoutingUpdater_Call) RunAndReturn(run func(string, expose.IngressUpdaterDependencies) (expose.RoutingUpdater, error)) *MockIngressController_NewRoutingUpdater_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RedirectAlternativeFQDN provides a mock function with given fields: ctx, namespace, redirectObjectName, fqdn, altFQDNList, setOwner
 func (_m *MockIngressController) RedirectAlternativeFQDN(ctx context.Context, namespace string, redirectObjectName string, fqdn string, altFQDNList []types.AlternativeFQDN, setOwner func(v1.Object) error) error {
 	ret := _m.Called(ctx, namespace, redirectObjectName, fqdn, altFQDNList, setOwner)
@@ -259,6 +465,51 @@ func (_c *MockIngressController_RedirectAlternativeFQDN_Call) RunAndReturn(run f
 	return _c
 }
 
+// UsesMiddlewares provides a mock function with no fields
+func (_m *MockIngressController) UsesMiddlewares() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsesMiddlewares")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockIngressController_UsesMiddlewares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsesMiddlewares'
+type MockIngressController_UsesMiddlewares_Call struct {
+	*mock.Call
+}
+
+// UsesMiddlewares is a helper method to define mock.On call
+func (_e *MockIngressController_Expecter) UsesMiddlewares() *MockIngressController_UsesMiddlewares_Call {
+	return &MockIngressController_UsesMiddlewares_Call{Call: _e.mock.On("UsesMiddlewares")}
+}
+
+func (_c *MockIngressController_UsesMiddlewares_Call) Run(run func()) *MockIngressController_UsesMiddlewares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockIngressController_UsesMiddlewares_Call) Return(_a0 bool) *MockIngressController_UsesMiddlewares_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIngressController_UsesMiddlewares_Call) RunAndReturn(run func() bool) *MockIngressController_UsesMiddlewares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockIngressController creates a new instance of MockIngressController. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockIngressController(t interface {
@@ -0,0 +1,105 @@
+package nginx
+
+import (
+	"fmt"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+	networking "k8s.io/api/networking/v1"
+)
+
+const (
+	// ControllerName selects ingress-nginx as ingress controller.
+	ControllerName = "ingress-nginx"
+
+	rewriteTargetAnnotation        = "nginx.ingress.kubernetes.io/rewrite-target"
+	useRegexAnnotation             = "nginx.ingress.kubernetes.io/use-regex"
+	configurationSnippetAnnotation = "nginx.ingress.kubernetes.io/configuration-snippet"
+
+	staticContentMaintenancePath  = "/errors/503.html"
+	staticContentDoguStartingPath = "/errors/starting.html"
+)
+
+// selector matches the pods of the controller deployed by the ingress-nginx helm chart.
+var selector = map[string]string{
+	"app.kubernetes.io/name":      "ingress-nginx",
+	"app.kubernetes.io/component": "controller",
+}
+
+// NginxController exposes dogus via ingress-nginx for ecosystems which can not run Traefik. Paths are rewritten by
+// annotations of the ingress objects instead of middlewares and TCP/UDP ports are exposed by the `tcp-services` and
+// `udp-services` config maps of ingress-nginx.
+type NginxController struct {
+	*PortExposer
+	*IngressRedirector
+}
+
+type ControllerDependencies struct {
+	IngressInterface   ingressInterface
+	ConfigMapInterface configMapInterface
+	IngressClassName   string
+	Recorder           eventRecorder
+}
+
+func NewNginxController(deps ControllerDependencies) *NginxController {
+	return &NginxController{
+		PortExposer: &PortExposer{
+			configMapInterface: deps.ConfigMapInterface,
+			recorder:           deps.Recorder,
+		},
+		IngressRedirector: &IngressRedirector{
+			ingressClassName: deps.IngressClassName,
+			ingressInterface: deps.IngressInterface,
+			recorder:         deps.Recorder,
+		},
+	}
+}
+
+func (c *NginxController) GetName() string {
+	return ControllerName
+}
+
+func (c *NginxController) GetRewriteAnnotationKey() string {
+	return rewriteTargetAnnotation
+}
+
+func (c *NginxController) GetSelector() map[string]string {
+	return selector
+}
+
+// NewRoutingUpdater creates an updater of ingress objects regardless of the traefik routing mode. The rewritten paths
+// are regular expressions, which ingress-nginx rejects for the path type `Prefix` since the strict path validation
+// is enabled by default, so the ingress objects use the path type `ImplementationSpecific`.
+func (c *NginxController) NewRoutingUpdater(_ string, deps expose.IngressUpdaterDependencies) (expose.RoutingUpdater, error) {
+	deps.PathType = networking.PathTypeImplementationSpecific
+	return expose.NewIngressUpdater(deps), nil
+}
+
+// UsesMiddlewares returns false because ingress-nginx rewrites paths with annotations.
+func (c *NginxController) UsesMiddlewares() bool {
+	return false
+}
+
+// GetPathRewriteAnnotations rewrites the requests of the regex path of an ingress object to the target path, which
+// may reference the groups of the path, e.g., `/nexus/$2`.
+func (c *NginxController) GetPathRewriteAnnotations(_ string, _ string, targetPath string) map[string]string {
+	return map[string]string{
+		rewriteTargetAnnotation: targetPath,
+		useRegexAnnotation:      "true",
+	}
+}
+
+// GetMaintenanceModeAnnotations serves the maintenance page of the static content backend for all requests. The
+// snippet annotation requires `allow-snippet-annotations` and, since ingress-nginx 1.12, the annotations risk level
+// `Critical` in the config map of the controller.
+func (c *NginxController) GetMaintenanceModeAnnotations(_ string) map[string]string {
+	return getStaticContentAnnotations(staticContentMaintenancePath)
+}
+
+// GetDoguStartingAnnotations serves the page of starting dogus of the static content backend for all requests.
+func (c *NginxController) GetDoguStartingAnnotations(_ string) map[string]string {
+	return getStaticContentAnnotations(staticContentDoguStartingPath)
+}
+
+func getStaticContentAnnotations(path string) map[string]string {
+	return map[string]string{configurationSnippetAnnotation: fmt.Sprintf("rewrite ^ %s break;", path)}
+}
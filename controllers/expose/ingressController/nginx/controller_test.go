@@ -0,0 +1,83 @@
+package nginx
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNginxController(t *testing.T) {
+	// given
+	ingressMock := newMockIngressInterface(t)
+	configMapMock := newMockConfigMapInterface(t)
+
+	// when
+	sut := NewNginxController(ControllerDependencies{
+		IngressInterface:   ingressMock,
+		ConfigMapInterface: configMapMock,
+		IngressClassName:   testIngressClassName,
+	})
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, configMapMock, sut.PortExposer.configMapInterface)
+	assert.Equal(t, ingressMock, sut.IngressRedirector.ingressInterface)
+	assert.Equal(t, testIngressClassName, sut.IngressRedirector.ingressClassName)
+}
+
+func TestNginxController_GetName(t *testing.T) {
+	assert.Equal(t, "ingress-nginx", NewNginxController(ControllerDependencies{}).GetName())
+}
+
+func TestNginxController_GetRewriteAnnotationKey(t *testing.T) {
+	assert.Equal(t, "nginx.ingress.kubernetes.io/rewrite-target", NewNginxController(ControllerDependencies{}).GetRewriteAnnotationKey())
+}
+
+func TestNginxController_GetSelector(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"app.kubernetes.io/name":      "ingress-nginx",
+		"app.kubernetes.io/component": "controller",
+	}, NewNginxController(ControllerDependencies{}).GetSelector())
+}
+
+func TestNginxController_NewRoutingUpdater(t *testing.T) {
+	// when
+	actual, err := NewNginxController(ControllerDependencies{}).NewRoutingUpdater("ingressroute", expose.IngressUpdaterDependencies{})
+
+	// then
+	require.NoError(t, err)
+	assert.IsType(t, expose.NewIngressUpdater(expose.IngressUpdaterDependencies{}), actual)
+}
+
+func TestNginxController_UsesMiddlewares(t *testing.T) {
+	assert.False(t, NewNginxController(ControllerDependencies{}).UsesMiddlewares())
+}
+
+func TestNginxController_GetPathRewriteAnnotations(t *testing.T) {
+	// when
+	actual := NewNginxController(ControllerDependencies{}).GetPathRewriteAnnotations(testNamespace, "", "/nexus/$2")
+
+	// then
+	assert.Equal(t, map[string]string{
+		"nginx.ingress.kubernetes.io/rewrite-target": "/nexus/$2",
+		"nginx.ingress.kubernetes.io/use-regex":      "true",
+	}, actual)
+}
+
+func TestNginxController_GetMaintenanceModeAnnotations(t *testing.T) {
+	// when
+	actual := NewNginxController(ControllerDependencies{}).GetMaintenanceModeAnnotations(testNamespace)
+
+	// then
+	assert.Equal(t, map[string]string{"nginx.ingress.kubernetes.io/configuration-snippet": "rewrite ^ /errors/503.html break;"}, actual)
+}
+
+func TestNginxController_GetDoguStartingAnnotations(t *testing.T) {
+	// when
+	actual := NewNginxController(ControllerDependencies{}).GetDoguStartingAnnotations(testNamespace)
+
+	// then
+	assert.Equal(t, map[string]string{"nginx.ingress.kubernetes.io/configuration-snippet": "rewrite ^ /errors/starting.html break;"}, actual)
+}
@@ -0,0 +1,19 @@
+package nginx
+
+import (
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	netv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type eventRecorder interface {
+	record.EventRecorder
+}
+
+type ingressInterface interface {
+	netv1.IngressInterface
+}
+
+type configMapInterface interface {
+	corev1.ConfigMapInterface
+}
@@ -0,0 +1,84 @@
+package nginx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// TCPServicesConfigMapName is the config map ingress-nginx reads the exposed tcp ports from. It must be passed to
+	// the controller with the flag `--tcp-services-configmap`.
+	TCPServicesConfigMapName = "tcp-services"
+	// UDPServicesConfigMapName is the config map ingress-nginx reads the exposed udp ports from. It must be passed to
+	// the controller with the flag `--udp-services-configmap`.
+	UDPServicesConfigMapName = "udp-services"
+)
+
+type PortExposer struct {
+	configMapInterface configMapInterface
+	recorder           eventRecorder
+}
+
+// ExposePorts materializes the given TCP/UDP port forwards for ingress-nginx by server-side applying the config maps
+// `tcp-services` and `udp-services`. Each entry maps the exposed port to `<namespace>/<service>:<target port>`.
+//
+// The given ports are treated as the complete desired state: the config maps are applied with all exposed ports, so
+// entries of ports which are no longer exposed are removed.
+//
+// Only TCP and UDP protocols are supported. Any other protocol values are logged and ignored.
+func (p PortExposer) ExposePorts(ctx context.Context, namespace string, exposedPorts types.ExposedPorts) error {
+	logger := log.FromContext(ctx)
+
+	tcpServices := make(map[string]string)
+	udpServices := make(map[string]string)
+
+	for _, port := range exposedPorts {
+		target := fmt.Sprintf("%s/%s:%d", namespace, port.ServiceName, port.TargetPort)
+
+		switch port.Protocol {
+		case corev1.ProtocolTCP:
+			tcpServices[port.PortString()] = target
+		case corev1.ProtocolUDP:
+			udpServices[port.PortString()] = target
+		default:
+			logger.Info("unsupported protocol for exposed port, port will be ignored", "name", port.Name, "protocol", port.Protocol)
+		}
+	}
+
+	if err := p.applyServicesConfigMap(ctx, namespace, TCPServicesConfigMapName, tcpServices); err != nil {
+		return fmt.Errorf("failed to expose tcp ports: %w", err)
+	}
+
+	if err := p.applyServicesConfigMap(ctx, namespace, UDPServicesConfigMapName, udpServices); err != nil {
+		return fmt.Errorf("failed to expose udp ports: %w", err)
+	}
+
+	return nil
+}
+
+func (p PortExposer) applyServicesConfigMap(ctx context.Context, namespace string, name string, services map[string]string) error {
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+		},
+		Data: services,
+	}
+
+	if _, err := util.ServerSideApply[*corev1.ConfigMap](ctx, p.configMapInterface, configMap, p.recorder); err != nil {
+		return fmt.Errorf("failed to apply config map %s: %w", name, err)
+	}
+
+	return nil
+}
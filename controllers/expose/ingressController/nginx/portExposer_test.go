@@ -0,0 +1,118 @@
+package nginx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	testNamespace        = "ecosystem"
+	testIngressClassName = "k8s-ecosystem-ces-service"
+)
+
+var (
+	testCtx          = context.Background()
+	testApplyOptions = metav1.PatchOptions{FieldManager: "k8s-service-discovery"}
+)
+
+func TestPortExposer_ExposePorts(t *testing.T) {
+	exposedPorts := types.ExposedPorts{
+		{Name: "scm-2222", ServiceName: "scm", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 2222},
+		{Name: "nexus-8082", ServiceName: "nexus", Protocol: corev1.ProtocolTCP, Port: 8082, TargetPort: 8081},
+		{Name: "dns-53", ServiceName: "dns", Protocol: corev1.ProtocolUDP, Port: 53, TargetPort: 5353},
+		{Name: "sctp-99", ServiceName: "sctp", Protocol: corev1.ProtocolSCTP, Port: 99, TargetPort: 99},
+	}
+
+	t.Run("should apply tcp and udp services config maps", func(t *testing.T) {
+		// given
+		configMapMock := newMockConfigMapInterface(t)
+		expectApplyConfigMap(t, configMapMock, "tcp-services", map[string]string{
+			"2222": "ecosystem/scm:2222",
+			"8082": "ecosystem/nexus:8081",
+		})
+		expectApplyConfigMap(t, configMapMock, "udp-services", map[string]string{
+			"53": "ecosystem/dns:5353",
+		})
+
+		sut := PortExposer{configMapInterface: configMapMock}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, exposedPorts)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should apply empty config maps without exposed ports", func(t *testing.T) {
+		// given
+		configMapMock := newMockConfigMapInterface(t)
+		expectApplyConfigMap(t, configMapMock, "tcp-services", nil)
+		expectApplyConfigMap(t, configMapMock, "udp-services", nil)
+
+		sut := PortExposer{configMapInterface: configMapMock}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, nil)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to apply tcp services config map", func(t *testing.T) {
+		// given
+		configMapMock := newMockConfigMapInterface(t)
+		configMapMock.EXPECT().Get(testCtx, "tcp-services", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := PortExposer{configMapInterface: configMapMock}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, exposedPorts)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to expose tcp ports: failed to apply config map tcp-services")
+	})
+	t.Run("should fail to apply udp services config map", func(t *testing.T) {
+		// given
+		configMapMock := newMockConfigMapInterface(t)
+		expectApplyConfigMap(t, configMapMock, "tcp-services", map[string]string{
+			"2222": "ecosystem/scm:2222",
+			"8082": "ecosystem/nexus:8081",
+		})
+		configMapMock.EXPECT().Get(testCtx, "udp-services", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := PortExposer{configMapInterface: configMapMock}
+
+		// when
+		err := sut.ExposePorts(testCtx, testNamespace, exposedPorts)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to expose udp ports: failed to apply config map udp-services")
+	})
+}
+
+func expectApplyConfigMap(t *testing.T, configMapMock *mockConfigMapInterface, name string, expectedData map[string]string) {
+	configMapMock.EXPECT().Get(testCtx, name, metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, name))
+	configMapMock.EXPECT().Patch(testCtx, name, k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+		Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+			configMap := &corev1.ConfigMap{}
+			require.NoError(t, json.Unmarshal(data, configMap))
+			assert.Equal(t, testNamespace, configMap.Namespace)
+			assert.Equal(t, util.K8sCesServiceDiscoveryLabels, configMap.Labels)
+			assert.Equal(t, expectedData, configMap.Data)
+		}).
+		Return(&corev1.ConfigMap{}, nil)
+}
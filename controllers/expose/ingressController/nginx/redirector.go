@@ -0,0 +1,111 @@
+package nginx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	permanentRedirectAnnotation = "nginx.ingress.kubernetes.io/permanent-redirect"
+	redirectIngressPath         = "/"
+	redirectEndpointName        = "ces-loadbalancer"
+	redirectEndpointPort        = 443
+)
+
+type IngressRedirector struct {
+	ingressClassName string
+	ingressInterface ingressInterface
+	recorder         eventRecorder
+}
+
+// RedirectAlternativeFQDN redirects all requests of the alternative FQDNs permanently to the same path of the primary
+// FQDN. The redirect is done by the `permanent-redirect` annotation of an ingress object, so no middleware is required.
+func (i IngressRedirector) RedirectAlternativeFQDN(ctx context.Context, namespace string, redirectObjectName string, fqdn string, altFQDNList []types.AlternativeFQDN, setOwner func(targetObject metav1.Object) error) error {
+	logger := log.FromContext(ctx)
+
+	if len(altFQDNList) == 0 {
+		if dErr := i.ingressInterface.Delete(ctx, redirectObjectName, metav1.DeleteOptions{}); dErr != nil && !apierrors.IsNotFound(dErr) {
+			return fmt.Errorf("failed to delete redirect ingress: %w", dErr)
+		}
+		logger.Info("no alternative FQDN configured, cleared redirect ingress")
+		return nil
+	}
+
+	redirectIngress := i.createRedirectIngress(namespace, redirectObjectName, fqdn, altFQDNList)
+
+	if oErr := setOwner(redirectIngress); oErr != nil {
+		return fmt.Errorf("failed to set owner for redirect ingress: %w", oErr)
+	}
+
+	if _, err := util.ServerSideApply[*networking.Ingress](ctx, i.ingressInterface, redirectIngress, i.recorder); err != nil {
+		return fmt.Errorf("failed to upsert redirect ingress: %w", err)
+	}
+
+	logger.Info("applied new redirect ingress")
+
+	return nil
+}
+
+func (i IngressRedirector) createRedirectIngress(namespace string, objectName string, fqdn string, altFQDNList []types.AlternativeFQDN) *networking.Ingress {
+	pathType := networking.PathTypePrefix
+
+	var secretNames []string
+	hostsBySecretName := make(map[string][]string)
+	rules := make([]networking.IngressRule, 0, len(altFQDNList))
+	for _, altFQDN := range altFQDNList {
+		if _, ok := hostsBySecretName[altFQDN.CertificateSecretName]; !ok {
+			secretNames = append(secretNames, altFQDN.CertificateSecretName)
+		}
+		hostsBySecretName[altFQDN.CertificateSecretName] = append(hostsBySecretName[altFQDN.CertificateSecretName], altFQDN.FQDN)
+
+		rules = append(rules, networking.IngressRule{
+			Host: altFQDN.FQDN,
+			IngressRuleValue: networking.IngressRuleValue{
+				HTTP: &networking.HTTPIngressRuleValue{
+					Paths: []networking.HTTPIngressPath{{
+						Path:     redirectIngressPath,
+						PathType: &pathType,
+						Backend: networking.IngressBackend{
+							Service: &networking.IngressServiceBackend{
+								Name: redirectEndpointName,
+								Port: networking.ServiceBackendPort{Number: redirectEndpointPort},
+							},
+						},
+					}},
+				},
+			},
+		})
+	}
+
+	slices.Sort(secretNames)
+	tlsList := make([]networking.IngressTLS, 0, len(secretNames))
+	for _, secretName := range secretNames {
+		tlsList = append(tlsList, networking.IngressTLS{Hosts: hostsBySecretName[secretName], SecretName: secretName})
+	}
+
+	return &networking.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networking.SchemeGroupVersion.String(),
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        objectName,
+			Namespace:   namespace,
+			Annotations: map[string]string{permanentRedirectAnnotation: fmt.Sprintf("https://%s$request_uri", fqdn)},
+			Labels:      util.K8sCesServiceDiscoveryLabels,
+		},
+		Spec: networking.IngressSpec{
+			IngressClassName: &i.ingressClassName,
+			TLS:              tlsList,
+			Rules:            rules,
+		},
+	}
+}
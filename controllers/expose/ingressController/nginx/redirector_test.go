@@ -0,0 +1,116 @@
+package nginx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestIngressRedirector_RedirectAlternativeFQDN(t *testing.T) {
+	altFQDNs := []types.AlternativeFQDN{
+		{FQDN: "alt1.example.com", CertificateSecretName: "ecosystem-certificate"},
+		{FQDN: "alt2.example.com", CertificateSecretName: "alt2-certificate"},
+		{FQDN: "alt3.example.com", CertificateSecretName: "ecosystem-certificate"},
+	}
+	noOwner := func(targetObject metav1.Object) error { return nil }
+
+	t.Run("should apply ingress redirecting the alternative fqdns", func(t *testing.T) {
+		// given
+		ingressMock := newMockIngressInterface(t)
+		ingressMock.EXPECT().Get(testCtx, "ces-alternative-fqdn", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "ces-alternative-fqdn"))
+		ingressMock.EXPECT().Patch(testCtx, "ces-alternative-fqdn", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+			Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+				ingress := &networking.Ingress{}
+				require.NoError(t, json.Unmarshal(data, ingress))
+				assert.Equal(t, "owner", ingress.OwnerReferences[0].Name)
+				assert.Equal(t, map[string]string{"nginx.ingress.kubernetes.io/permanent-redirect": "https://ces.example.com$request_uri"}, ingress.Annotations)
+				assert.Equal(t, testIngressClassName, *ingress.Spec.IngressClassName)
+				assert.Equal(t, []networking.IngressTLS{
+					{Hosts: []string{"alt2.example.com"}, SecretName: "alt2-certificate"},
+					{Hosts: []string{"alt1.example.com", "alt3.example.com"}, SecretName: "ecosystem-certificate"},
+				}, ingress.Spec.TLS)
+				require.Len(t, ingress.Spec.Rules, 3)
+				assert.Equal(t, "alt1.example.com", ingress.Spec.Rules[0].Host)
+				assert.Equal(t, "ces-loadbalancer", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+			}).
+			Return(&networking.Ingress{}, nil)
+
+		sut := IngressRedirector{ingressInterface: ingressMock, ingressClassName: testIngressClassName}
+		setOwner := func(targetObject metav1.Object) error {
+			targetObject.SetOwnerReferences([]metav1.OwnerReference{{Name: "owner"}})
+			return nil
+		}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", altFQDNs, setOwner)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should delete ingress without alternative fqdns", func(t *testing.T) {
+		// given
+		ingressMock := newMockIngressInterface(t)
+		ingressMock.EXPECT().Delete(testCtx, "ces-alternative-fqdn", metav1.DeleteOptions{}).Return(apierrors.NewNotFound(schema.GroupResource{}, "ces-alternative-fqdn"))
+
+		sut := IngressRedirector{ingressInterface: ingressMock}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", nil, noOwner)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete ingress", func(t *testing.T) {
+		// given
+		ingressMock := newMockIngressInterface(t)
+		ingressMock.EXPECT().Delete(testCtx, "ces-alternative-fqdn", metav1.DeleteOptions{}).Return(assert.AnError)
+
+		sut := IngressRedirector{ingressInterface: ingressMock}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", nil, noOwner)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete redirect ingress")
+	})
+	t.Run("should fail to set owner", func(t *testing.T) {
+		// given
+		sut := IngressRedirector{ingressInterface: newMockIngressInterface(t)}
+		failingOwner := func(targetObject metav1.Object) error { return assert.AnError }
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", altFQDNs, failingOwner)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to set owner for redirect ingress")
+	})
+	t.Run("should fail to apply ingress", func(t *testing.T) {
+		// given
+		ingressMock := newMockIngressInterface(t)
+		ingressMock.EXPECT().Get(testCtx, "ces-alternative-fqdn", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := IngressRedirector{ingressInterface: ingressMock}
+
+		// when
+		err := sut.RedirectAlternativeFQDN(testCtx, testNamespace, "ces-alternative-fqdn", "ces.example.com", altFQDNs, noOwner)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to upsert redirect ingress")
+	})
+}
@@ -0,0 +1,16 @@
+package ingressController
+
+import (
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/nginx"
+)
+
+func init() {
+	Register(nginx.ControllerName, func(deps Dependencies) IngressController {
+		return nginx.NewNginxController(nginx.ControllerDependencies{
+			IngressInterface:   deps.IngressInterface,
+			ConfigMapInterface: deps.ConfigMapInterface,
+			IngressClassName:   deps.IngressClassName,
+			Recorder:           deps.Recorder,
+		})
+	})
+}
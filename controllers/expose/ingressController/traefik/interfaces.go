@@ -1,10 +1,22 @@
 package traefik
 
 import (
+	"context"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
 	traefikv1alpha1 "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/generated/clientset/versioned/typed/traefikio/v1alpha1"
 	netv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+	"k8s.io/client-go/tools/record"
 )
 
+type globalConfigRepository interface {
+	Get(ctx context.Context) (libconfig.GlobalConfig, error)
+}
+
+type eventRecorder interface {
+	record.EventRecorder
+}
+
 type ingressInterface interface {
 	netv1.IngressInterface
 }
@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -17,11 +18,15 @@ import (
 type PortExposer struct {
 	traefikInterface traefikInterface
 	ingressInterface ingressInterface
+	recorder         eventRecorder
 	namespace        string
 }
 
 // ExposePorts materializes the given TCP/UDP port forwards for Traefik by
-// creating or updating IngressRouteTCP / IngressRouteUDP CRDs per exposed port.
+// server-side applying IngressRouteTCP / IngressRouteUDP CRDs per exposed port.
+//
+// The given ports are treated as the complete desired state: labelled routes in the namespace which do not belong to
+// one of the given ports are deleted afterward.
 //
 // This function is safe to call repeatedly (upsert semantics).
 //
@@ -29,20 +34,26 @@ type PortExposer struct {
 func (p PortExposer) ExposePorts(ctx context.Context, namespace string, exposedPorts types.ExposedPorts) error {
 	logger := log.FromContext(ctx)
 
+	tcpClient := p.traefikInterface.IngressRouteTCPs(namespace)
+	udpClient := p.traefikInterface.IngressRouteUDPs(namespace)
+
+	desiredTCPRoutes := make(map[string]struct{})
+	desiredUDPRoutes := make(map[string]struct{})
+
 	for _, port := range exposedPorts {
 		owner := getIngressRouteOwner(ctx, p.ingressInterface, port)
 
 		switch port.Protocol {
 		case corev1.ProtocolTCP:
-			client := p.traefikInterface.IngressRouteTCPs(namespace)
 			route := createIngressRouteTCP(namespace, port, owner)
-			if err := p.upsertIngressRouteTCP(ctx, route, client); err != nil {
+			desiredTCPRoutes[route.Name] = struct{}{}
+			if err := p.upsertIngressRouteTCP(ctx, route, tcpClient); err != nil {
 				return fmt.Errorf("failed to expose tcp port %s: %w", port.PortString(), err)
 			}
 		case corev1.ProtocolUDP:
-			client := p.traefikInterface.IngressRouteUDPs(namespace)
 			route := createIngressRouteUDP(namespace, port, owner)
-			if err := p.upsertIngressRouteUDP(ctx, route, client); err != nil {
+			desiredUDPRoutes[route.Name] = struct{}{}
+			if err := p.upsertIngressRouteUDP(ctx, route, udpClient); err != nil {
 				return fmt.Errorf("failed to expose udp port %s: %w", port.PortString(), err)
 			}
 		default:
@@ -50,52 +61,72 @@ func (p PortExposer) ExposePorts(ctx context.Context, namespace string, exposedP
 		}
 	}
 
+	if err := p.deleteStaleIngressRouteTCPs(ctx, tcpClient, desiredTCPRoutes); err != nil {
+		return fmt.Errorf("failed to delete stale tcp routes: %w", err)
+	}
+
+	if err := p.deleteStaleIngressRouteUDPs(ctx, udpClient, desiredUDPRoutes); err != nil {
+		return fmt.Errorf("failed to delete stale udp routes: %w", err)
+	}
+
 	return nil
 }
 
-func (p PortExposer) upsertIngressRouteTCP(ctx context.Context, route *traefikv1alpha1.IngressRouteTCP, client ingressrouteTcpInterface) error {
-	_, err := client.Create(ctx, route, metav1.CreateOptions{})
-	if err == nil {
-		return nil
+func (p PortExposer) deleteStaleIngressRouteTCPs(ctx context.Context, client ingressrouteTcpInterface, desiredRoutes map[string]struct{}) error {
+	routeList, err := client.List(ctx, metav1.ListOptions{LabelSelector: managedRouteSelector()})
+	if err != nil {
+		return fmt.Errorf("failed to list IngressRouteTCPs: %w", err)
 	}
 
-	if !apierrors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create IngressRouteTCP: %w", err)
+	for _, route := range routeList.Items {
+		if _, desired := desiredRoutes[route.Name]; desired {
+			continue
+		}
+
+		log.FromContext(ctx).Info("port is no longer exposed, deleting IngressRouteTCP", "name", route.Name)
+		if dErr := client.Delete(ctx, route.Name, metav1.DeleteOptions{}); dErr != nil && !apierrors.IsNotFound(dErr) {
+			return fmt.Errorf("failed to delete IngressRouteTCP %s: %w", route.Name, dErr)
+		}
 	}
 
-	existing, gErr := client.Get(ctx, route.Name, metav1.GetOptions{})
-	if gErr != nil {
-		return fmt.Errorf("failed to get existing IngressRouteTCP: %w", gErr)
+	return nil
+}
+
+func (p PortExposer) deleteStaleIngressRouteUDPs(ctx context.Context, client ingressrouteUdpInterface, desiredRoutes map[string]struct{}) error {
+	routeList, err := client.List(ctx, metav1.ListOptions{LabelSelector: managedRouteSelector()})
+	if err != nil {
+		return fmt.Errorf("failed to list IngressRouteUDPs: %w", err)
 	}
 
-	route.ResourceVersion = existing.ResourceVersion
-	_, uErr := client.Update(ctx, route, metav1.UpdateOptions{})
-	if uErr != nil {
-		return fmt.Errorf("failed to update IngressRouteTCP: %w", uErr)
+	for _, route := range routeList.Items {
+		if _, desired := desiredRoutes[route.Name]; desired {
+			continue
+		}
+
+		log.FromContext(ctx).Info("port is no longer exposed, deleting IngressRouteUDP", "name", route.Name)
+		if dErr := client.Delete(ctx, route.Name, metav1.DeleteOptions{}); dErr != nil && !apierrors.IsNotFound(dErr) {
+			return fmt.Errorf("failed to delete IngressRouteUDP %s: %w", route.Name, dErr)
+		}
 	}
 
 	return nil
 }
 
-func (p PortExposer) upsertIngressRouteUDP(ctx context.Context, route *traefikv1alpha1.IngressRouteUDP, client ingressrouteUdpInterface) error {
-	_, err := client.Create(ctx, route, metav1.CreateOptions{})
-	if err == nil {
-		return nil
-	}
+func managedRouteSelector() string {
+	return labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).String()
+}
 
-	if !apierrors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create IngressRouteUDP: %w", err)
+func (p PortExposer) upsertIngressRouteTCP(ctx context.Context, route *traefikv1alpha1.IngressRouteTCP, client ingressrouteTcpInterface) error {
+	if _, err := util.ServerSideApply[*traefikv1alpha1.IngressRouteTCP](ctx, client, route, p.recorder); err != nil {
+		return fmt.Errorf("failed to apply IngressRouteTCP: %w", err)
 	}
 
-	existing, gErr := client.Get(ctx, route.Name, metav1.GetOptions{})
-	if gErr != nil {
-		return fmt.Errorf("failed to get existing IngressRouteUDP: %w", gErr)
-	}
+	return nil
+}
 
-	route.ResourceVersion = existing.ResourceVersion
-	_, uErr := client.Update(ctx, route, metav1.UpdateOptions{})
-	if uErr != nil {
-		return fmt.Errorf("failed to update IngressRouteUDP: %w", uErr)
+func (p PortExposer) upsertIngressRouteUDP(ctx context.Context, route *traefikv1alpha1.IngressRouteUDP, client ingressrouteUdpInterface) error {
+	if _, err := util.ServerSideApply[*traefikv1alpha1.IngressRouteUDP](ctx, client, route, p.recorder); err != nil {
+		return fmt.Errorf("failed to apply IngressRouteUDP: %w", err)
 	}
 
 	return nil
@@ -103,6 +134,10 @@ func (p PortExposer) upsertIngressRouteUDP(ctx context.Context, route *traefikv1
 
 func createIngressRouteTCP(namespace string, port types.ExposedPort, ownerReferences []metav1.OwnerReference) *traefikv1alpha1.IngressRouteTCP {
 	route := &traefikv1alpha1.IngressRouteTCP{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       "IngressRouteTCP",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-%s-tcp", port.ServiceName, port.PortString()),
 			Namespace: namespace,
@@ -134,6 +169,10 @@ func createIngressRouteTCP(namespace string, port types.ExposedPort, ownerRefere
 
 func createIngressRouteUDP(namespace string, port types.ExposedPort, ownerReferences []metav1.OwnerReference) *traefikv1alpha1.IngressRouteUDP {
 	route := &traefikv1alpha1.IngressRouteUDP{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       "IngressRouteUDP",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-%s-udp", port.ServiceName, port.PortString()),
 			Namespace: namespace,
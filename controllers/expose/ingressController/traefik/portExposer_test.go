@@ -2,6 +2,7 @@ package traefik
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -23,6 +25,27 @@ const (
 	testNamespace = "ecosystem"
 )
 
+var (
+	testRouteListOptions = metav1.ListOptions{LabelSelector: "app=ces,app.kubernetes.io/name=k8s-service-discovery"}
+	testApplyOptions     = metav1.PatchOptions{FieldManager: "k8s-service-discovery"}
+)
+
+func expectNoTCPRoutes(t *testing.T, traefikMock *mockTraefikInterface) {
+	t.Helper()
+
+	tcpClientMock := newMockIngressrouteTcpInterface(t)
+	tcpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteTCPList{}, nil)
+	traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
+}
+
+func expectNoUDPRoutes(t *testing.T, traefikMock *mockTraefikInterface) {
+	t.Helper()
+
+	udpClientMock := newMockIngressrouteUdpInterface(t)
+	udpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteUDPList{}, nil)
+	traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(udpClientMock)
+}
+
 func TestPortExposer_ExposePorts(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -32,7 +55,7 @@ func TestPortExposer_ExposePorts(t *testing.T) {
 		expErrStr      string
 	}{
 		{
-			name: "successfully create TCP and UDP IngressRoutes",
+			name: "successfully apply TCP and UDP IngressRoutes",
 			inExposedPorts: types.ExposedPorts{
 				{Name: "svc-2222", ServiceName: "svc", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 2222},
 				{Name: "svc-5353", ServiceName: "svc", Protocol: corev1.ProtocolUDP, Port: 5353, TargetPort: 5353},
@@ -41,19 +64,27 @@ func TestPortExposer_ExposePorts(t *testing.T) {
 				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError).Times(2)
 
 				tcpClientMock := newMockIngressrouteTcpInterface(t)
-				tcpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Run(func(ctx context.Context, route *traefikv1alpha1.IngressRouteTCP, opts metav1.CreateOptions) {
+				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-2222-tcp"))
+				tcpClientMock.EXPECT().Patch(mock.Anything, "svc-2222-tcp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+					Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+						route := &traefikv1alpha1.IngressRouteTCP{}
+						require.NoError(t, json.Unmarshal(data, route))
 						assertIngressRouteTCP(t, route, "svc", 2222, 2222)
 					}).
 					Return(&traefikv1alpha1.IngressRouteTCP{}, nil)
+				tcpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteTCPList{}, nil)
 				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
 
 				udpClientMock := newMockIngressrouteUdpInterface(t)
-				udpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Run(func(ctx context.Context, route *traefikv1alpha1.IngressRouteUDP, opts metav1.CreateOptions) {
+				udpClientMock.EXPECT().Get(mock.Anything, "svc-5353-udp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-5353-udp"))
+				udpClientMock.EXPECT().Patch(mock.Anything, "svc-5353-udp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+					Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+						route := &traefikv1alpha1.IngressRouteUDP{}
+						require.NoError(t, json.Unmarshal(data, route))
 						assertIngressRouteUDP(t, route, "svc", 5353, 5353)
 					}).
 					Return(&traefikv1alpha1.IngressRouteUDP{}, nil)
+				udpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteUDPList{}, nil)
 				traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(udpClientMock)
 			},
 			expErr: false,
@@ -68,59 +99,31 @@ func TestPortExposer_ExposePorts(t *testing.T) {
 				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError).Times(2)
 
 				tcpClientMock := newMockIngressrouteTcpInterface(t)
-				tcpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-2222-tcp"))
+				tcpClientMock.EXPECT().Patch(mock.Anything, "svc-2222-tcp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
 					Return(&traefikv1alpha1.IngressRouteTCP{}, nil)
+				tcpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteTCPList{}, nil)
 				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
+				expectNoUDPRoutes(t, traefikMock)
 			},
 			expErr: false,
 		},
 		{
-			name: "update IngressRouteTCP when it already exists",
+			name: "skip applying unchanged IngressRouteTCP",
 			inExposedPorts: types.ExposedPorts{
 				{Name: "svc-2222", ServiceName: "svc", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 2222},
 			},
 			setupMocks: func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {
 				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError)
 
-				existing := &traefikv1alpha1.IngressRouteTCP{}
-				existing.ResourceVersion = "1"
+				liveRoute := createIngressRouteTCP(testNamespace, types.ExposedPort{ServiceName: "svc", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 2222}, nil)
+				liveRoute.ResourceVersion = "42"
 
 				tcpClientMock := newMockIngressrouteTcpInterface(t)
-				tcpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, apierrors.NewAlreadyExists(schema.GroupResource{}, "svc-2222-tcp"))
-				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", mock.Anything).
-					Return(existing, nil)
-				tcpClientMock.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
-					Run(func(ctx context.Context, route *traefikv1alpha1.IngressRouteTCP, opts metav1.UpdateOptions) {
-						require.Equal(t, "1", route.ResourceVersion)
-					}).
-					Return(&traefikv1alpha1.IngressRouteTCP{}, nil)
+				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", metav1.GetOptions{}).Return(liveRoute, nil)
+				tcpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteTCPList{}, nil)
 				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
-			},
-			expErr: false,
-		},
-		{
-			name: "update IngressRouteUDP when it already exists",
-			inExposedPorts: types.ExposedPorts{
-				{Name: "svc-5353", ServiceName: "svc", Protocol: corev1.ProtocolUDP, Port: 5353, TargetPort: 5353},
-			},
-			setupMocks: func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {
-				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError)
-
-				existing := &traefikv1alpha1.IngressRouteUDP{}
-				existing.ResourceVersion = "42"
-
-				udpClientMock := newMockIngressrouteUdpInterface(t)
-				udpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, apierrors.NewAlreadyExists(schema.GroupResource{}, "svc-5353-udp"))
-				udpClientMock.EXPECT().Get(mock.Anything, "svc-5353-udp", mock.Anything).
-					Return(existing, nil)
-				udpClientMock.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
-					Run(func(ctx context.Context, route *traefikv1alpha1.IngressRouteUDP, opts metav1.UpdateOptions) {
-						require.Equal(t, "42", route.ResourceVersion)
-					}).
-					Return(&traefikv1alpha1.IngressRouteUDP{}, nil)
-				traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(udpClientMock)
+				expectNoUDPRoutes(t, traefikMock)
 			},
 			expErr: false,
 		},
@@ -137,18 +140,23 @@ func TestPortExposer_ExposePorts(t *testing.T) {
 				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(ingressObj, nil)
 
 				tcpClientMock := newMockIngressrouteTcpInterface(t)
-				tcpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Run(func(ctx context.Context, route *traefikv1alpha1.IngressRouteTCP, opts metav1.CreateOptions) {
+				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-2222-tcp"))
+				tcpClientMock.EXPECT().Patch(mock.Anything, "svc-2222-tcp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+					Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+						route := &traefikv1alpha1.IngressRouteTCP{}
+						require.NoError(t, json.Unmarshal(data, route))
 						require.Len(t, route.GetOwnerReferences(), 1)
 						require.Equal(t, "some-owner", route.GetOwnerReferences()[0].Name)
 					}).
 					Return(&traefikv1alpha1.IngressRouteTCP{}, nil)
+				tcpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteTCPList{}, nil)
 				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
+				expectNoUDPRoutes(t, traefikMock)
 			},
 			expErr: false,
 		},
 		{
-			name: "return error when IngressRouteTCP cannot be created",
+			name: "return error when IngressRouteTCP cannot be applied",
 			inExposedPorts: types.ExposedPorts{
 				{Name: "svc-2222", ServiceName: "svc", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 2222},
 			},
@@ -156,75 +164,104 @@ func TestPortExposer_ExposePorts(t *testing.T) {
 				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError)
 
 				tcpClientMock := newMockIngressrouteTcpInterface(t)
-				tcpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-2222-tcp"))
+				tcpClientMock.EXPECT().Patch(mock.Anything, "svc-2222-tcp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
 					Return(nil, assert.AnError)
 				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
+				traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(newMockIngressrouteUdpInterface(t))
 			},
 			expErr:    true,
 			expErrStr: "failed to expose tcp port",
 		},
 		{
-			name: "return error when IngressRouteTCP already exists but cannot be fetched for update",
+			name: "return error when IngressRouteUDP cannot be applied",
 			inExposedPorts: types.ExposedPorts{
-				{Name: "svc-2222", ServiceName: "svc", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 2222},
+				{Name: "svc-5353", ServiceName: "svc", Protocol: corev1.ProtocolUDP, Port: 5353, TargetPort: 5353},
 			},
 			setupMocks: func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {
 				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError)
 
-				tcpClientMock := newMockIngressrouteTcpInterface(t)
-				tcpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, apierrors.NewAlreadyExists(schema.GroupResource{}, "svc-2222-tcp"))
-				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", mock.Anything).
+				udpClientMock := newMockIngressrouteUdpInterface(t)
+				udpClientMock.EXPECT().Get(mock.Anything, "svc-5353-udp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-5353-udp"))
+				udpClientMock.EXPECT().Patch(mock.Anything, "svc-5353-udp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
 					Return(nil, assert.AnError)
-				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
+				traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(udpClientMock)
+				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(newMockIngressrouteTcpInterface(t))
 			},
 			expErr:    true,
-			expErrStr: "failed to expose tcp port",
+			expErrStr: "failed to expose udp port",
+		},
+		{
+			name:           "handle empty exposed ports list",
+			inExposedPorts: types.ExposedPorts{},
+			setupMocks: func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {
+				expectNoTCPRoutes(t, traefikMock)
+				expectNoUDPRoutes(t, traefikMock)
+			},
+			expErr: false,
 		},
 		{
-			name: "return error when IngressRouteTCP cannot be updated",
+			name: "delete routes of ports which are no longer exposed",
 			inExposedPorts: types.ExposedPorts{
 				{Name: "svc-2222", ServiceName: "svc", Protocol: corev1.ProtocolTCP, Port: 2222, TargetPort: 2222},
 			},
 			setupMocks: func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {
 				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError)
 
-				existing := &traefikv1alpha1.IngressRouteTCP{}
-				existing.ResourceVersion = "1"
+				tcpClientMock := newMockIngressrouteTcpInterface(t)
+				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "svc-2222-tcp"))
+				tcpClientMock.EXPECT().Patch(mock.Anything, "svc-2222-tcp", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+					Return(&traefikv1alpha1.IngressRouteTCP{}, nil)
+				tcpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteTCPList{
+					Items: []traefikv1alpha1.IngressRouteTCP{
+						{ObjectMeta: metav1.ObjectMeta{Name: "svc-2222-tcp"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "svc-3333-tcp"}},
+					},
+				}, nil)
+				tcpClientMock.EXPECT().Delete(mock.Anything, "svc-3333-tcp", metav1.DeleteOptions{}).Return(nil)
+				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
 
+				udpClientMock := newMockIngressrouteUdpInterface(t)
+				udpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteUDPList{
+					Items: []traefikv1alpha1.IngressRouteUDP{
+						{ObjectMeta: metav1.ObjectMeta{Name: "svc-5353-udp"}},
+					},
+				}, nil)
+				udpClientMock.EXPECT().Delete(mock.Anything, "svc-5353-udp", metav1.DeleteOptions{}).
+					Return(apierrors.NewNotFound(schema.GroupResource{}, "svc-5353-udp"))
+				traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(udpClientMock)
+			},
+			expErr: false,
+		},
+		{
+			name:           "return error when IngressRouteTCPs cannot be listed",
+			inExposedPorts: types.ExposedPorts{},
+			setupMocks: func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {
 				tcpClientMock := newMockIngressrouteTcpInterface(t)
-				tcpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, apierrors.NewAlreadyExists(schema.GroupResource{}, "svc-2222-tcp"))
-				tcpClientMock.EXPECT().Get(mock.Anything, "svc-2222-tcp", mock.Anything).
-					Return(existing, nil)
-				tcpClientMock.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, assert.AnError)
+				tcpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(nil, assert.AnError)
 				traefikMock.EXPECT().IngressRouteTCPs(testNamespace).Return(tcpClientMock)
+				traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(newMockIngressrouteUdpInterface(t))
 			},
 			expErr:    true,
-			expErrStr: "failed to expose tcp port",
+			expErrStr: "failed to delete stale tcp routes: failed to list IngressRouteTCPs",
 		},
 		{
-			name: "return error when IngressRouteUDP cannot be created",
-			inExposedPorts: types.ExposedPorts{
-				{Name: "svc-5353", ServiceName: "svc", Protocol: corev1.ProtocolUDP, Port: 5353, TargetPort: 5353},
-			},
+			name:           "return error when stale IngressRouteUDP cannot be deleted",
+			inExposedPorts: types.ExposedPorts{},
 			setupMocks: func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {
-				ingressMock.EXPECT().Get(mock.Anything, "svc", mock.Anything).Return(nil, assert.AnError)
+				expectNoTCPRoutes(t, traefikMock)
 
 				udpClientMock := newMockIngressrouteUdpInterface(t)
-				udpClientMock.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, assert.AnError)
+				udpClientMock.EXPECT().List(mock.Anything, testRouteListOptions).Return(&traefikv1alpha1.IngressRouteUDPList{
+					Items: []traefikv1alpha1.IngressRouteUDP{
+						{ObjectMeta: metav1.ObjectMeta{Name: "svc-5353-udp"}},
+					},
+				}, nil)
+				udpClientMock.EXPECT().Delete(mock.Anything, "svc-5353-udp", metav1.DeleteOptions{}).Return(assert.AnError)
 				traefikMock.EXPECT().IngressRouteUDPs(testNamespace).Return(udpClientMock)
 			},
 			expErr:    true,
-			expErrStr: "failed to expose udp port",
-		},
-		{
-			name:           "handle empty exposed ports list",
-			inExposedPorts: types.ExposedPorts{},
-			setupMocks:     func(traefikMock *mockTraefikInterface, ingressMock *mockIngressInterface) {},
-			expErr:         false,
+			expErrStr: "failed to delete stale udp routes: failed to delete IngressRouteUDP svc-5353-udp",
 		},
 	}
 
@@ -257,6 +294,8 @@ func assertIngressRouteTCP(t *testing.T, route *traefikv1alpha1.IngressRouteTCP,
 	t.Helper()
 
 	require.NotNil(t, route)
+	require.Equal(t, "traefik.io/v1alpha1", route.APIVersion)
+	require.Equal(t, "IngressRouteTCP", route.Kind)
 	require.Equal(t, testNamespace, route.Namespace)
 	require.Equal(t, util.K8sCesServiceDiscoveryLabels, route.Labels)
 	require.Equal(t, fmt.Sprintf("%s-%d-tcp", serviceName, port), route.Name)
@@ -278,6 +317,8 @@ func assertIngressRouteUDP(t *testing.T, route *traefikv1alpha1.IngressRouteUDP,
 	t.Helper()
 
 	require.NotNil(t, route)
+	require.Equal(t, "traefik.io/v1alpha1", route.APIVersion)
+	require.Equal(t, "IngressRouteUDP", route.Kind)
 	require.Equal(t, testNamespace, route.Namespace)
 	require.Equal(t, util.K8sCesServiceDiscoveryLabels, route.Labels)
 	require.Equal(t, fmt.Sprintf("%s-%d-udp", serviceName, port), route.Name)
@@ -3,6 +3,9 @@ package traefik
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
@@ -23,10 +26,12 @@ const (
 )
 
 type IngressRedirector struct {
-	ingressClassName string
-	ingressInterface ingressInterface
-	traefikInterface traefikInterface
-	namespace        string
+	ingressClassName       string
+	ingressInterface       ingressInterface
+	traefikInterface       traefikInterface
+	globalConfigRepository globalConfigRepository
+	recorder               eventRecorder
+	namespace              string
 }
 
 func (i IngressRedirector) RedirectAlternativeFQDN(ctx context.Context, namespace string, redirectObjectName string, fqdn string, altFQDNList []types.AlternativeFQDN, setOwner func(targetObject metav1.Object) error) error {
@@ -40,7 +45,12 @@ func (i IngressRedirector) RedirectAlternativeFQDN(ctx context.Context, namespac
 		return nil
 	}
 
-	redirectIngress := i.createRedirectIngress(namespace, redirectObjectName, groupFQDNsBySecretName(altFQDNList))
+	globalMiddlewares, err := i.getGlobalMiddlewares(ctx)
+	if err != nil {
+		return err
+	}
+
+	redirectIngress := i.createRedirectIngress(namespace, redirectObjectName, groupFQDNsBySecretName(altFQDNList), globalMiddlewares)
 
 	if oErr := setOwner(redirectIngress); oErr != nil {
 		return fmt.Errorf("failed to set owner for redirect ingress: %w", oErr)
@@ -51,9 +61,9 @@ func (i IngressRedirector) RedirectAlternativeFQDN(ctx context.Context, namespac
 		return fmt.Errorf("failed to upsert redirect ingress: %w", uErr)
 	}
 
-	middlewareManager := expose.NewMiddlewareManager(i.traefikInterface, i.namespace)
+	middlewareManager := expose.NewMiddlewareManager(i.traefikInterface, i.namespace, i.recorder)
 
-	err := createRedirectMiddleware(ctx, fqdn, altFQDNList, upsertedIngress, middlewareManager)
+	err = createRedirectMiddleware(ctx, fqdn, altFQDNList, upsertedIngress, middlewareManager)
 	if err != nil {
 		return fmt.Errorf("failed to create alternative fqdn redirect middleware: %w", err)
 	}
@@ -63,13 +73,32 @@ func (i IngressRedirector) RedirectAlternativeFQDN(ctx context.Context, namespac
 	return nil
 }
 
-func (i IngressRedirector) createRedirectIngress(namespace string, objectName string, altFQDNMap map[string][]string) *networking.Ingress {
+// getGlobalMiddlewares returns the references of the global middlewares of the global config, e.g., the security
+// headers, which are applied before the redirect.
+func (i IngressRedirector) getGlobalMiddlewares(ctx context.Context) ([]string, error) {
+	globalConfig, err := i.globalConfigRepository.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global config: %w", err)
+	}
+
+	globalMiddlewares, err := expose.GetGlobalMiddlewareRefs(i.namespace, globalConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global middlewares: %w", err)
+	}
+
+	return globalMiddlewares, nil
+}
+
+func (i IngressRedirector) createRedirectIngress(namespace string, objectName string, altFQDNMap map[string][]string, globalMiddlewares []string) *networking.Ingress {
+	middlewares := append(slices.Clone(globalMiddlewares), redirectMiddlewareName)
 	annotations := map[string]string{
-		traefikMiddlewareAnnotation: redirectMiddlewareName,
+		traefikMiddlewareAnnotation: strings.Join(middlewares, ","),
 	}
+	// the certificates and hosts are sorted, as the order of a map changes and the apply is only skipped for equal lists
 	fdns := make([]string, 0, len(altFQDNMap))
 	tlsList := make([]networking.IngressTLS, 0, len(altFQDNMap))
-	for certificateName, fqdnList := range altFQDNMap {
+	for _, certificateName := range slices.Sorted(maps.Keys(altFQDNMap)) {
+		fqdnList := slices.Sorted(slices.Values(altFQDNMap[certificateName]))
 		fdns = append(fdns, fqdnList...)
 
 		tlsIngress := networking.IngressTLS{
@@ -81,6 +110,10 @@ func (i IngressRedirector) createRedirectIngress(namespace string, objectName st
 	}
 
 	return &networking.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networking.SchemeGroupVersion.String(),
+			Kind:       "Ingress",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        objectName,
 			Namespace:   namespace,
@@ -96,21 +129,12 @@ func (i IngressRedirector) createRedirectIngress(namespace string, objectName st
 }
 
 func (i IngressRedirector) upsertIngress(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error) {
-	createdIngress, cErr := i.ingressInterface.Create(ctx, ingress, metav1.CreateOptions{})
-	if cErr == nil {
-		return createdIngress, nil
-	}
-
-	if !apierrors.IsAlreadyExists(cErr) {
-		return nil, fmt.Errorf("failed to create redirect ingress: %w", cErr)
-	}
-
-	updatedIngress, uErr := i.ingressInterface.Update(ctx, ingress, metav1.UpdateOptions{})
-	if uErr != nil {
-		return nil, fmt.Errorf("failed to update redirect ingress: %w", uErr)
+	appliedIngress, err := util.ServerSideApply[*networking.Ingress](ctx, i.ingressInterface, ingress, i.recorder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply redirect ingress: %w", err)
 	}
 
-	return updatedIngress, nil
+	return appliedIngress, nil
 }
 
 func createIngressRules(hostList []string) []networking.IngressRule {
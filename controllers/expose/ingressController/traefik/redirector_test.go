@@ -2,10 +2,12 @@ package traefik
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"testing"
 
+	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
 	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
@@ -16,6 +18,8 @@ import (
 	v1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -41,7 +45,7 @@ func TestIngressRedirector_RedirectAlternativeFQDN(t *testing.T) {
 				{FQDN: "test.testFqdn", CertificateSecretName: defaultCertificateName},
 			},
 			inSetOwner: func(targetObject metav1.Object) error { return nil },
-			setupMock:  expectCreateWithAssertion(t),
+			setupMock:  expectApplyWithAssertion(t),
 			expErr:     false,
 		},
 		{
@@ -52,7 +56,7 @@ func TestIngressRedirector_RedirectAlternativeFQDN(t *testing.T) {
 				{FQDN: "test3.testFqdn", CertificateSecretName: defaultCertificateName},
 			},
 			inSetOwner: func(targetObject metav1.Object) error { return nil },
-			setupMock:  expectCreateWithAssertion(t),
+			setupMock:  expectApplyWithAssertion(t),
 			expErr:     false,
 		},
 		{
@@ -63,18 +67,7 @@ func TestIngressRedirector_RedirectAlternativeFQDN(t *testing.T) {
 				{FQDN: "test3.testFqdn", CertificateSecretName: "testCertificate3"},
 			},
 			inSetOwner: func(targetObject metav1.Object) error { return nil },
-			setupMock:  expectCreateWithAssertion(t),
-			expErr:     false,
-		},
-		{
-			name: "update redirect ingress when already exists",
-			inAltFQDNList: []types.AlternativeFQDN{
-				{FQDN: "test.testFqdn", CertificateSecretName: defaultCertificateName},
-				{FQDN: "test2.testFqdn", CertificateSecretName: "testCertificate2"},
-				{FQDN: "test3.testFqdn", CertificateSecretName: "testCertificate3"},
-			},
-			inSetOwner: func(targetObject metav1.Object) error { return nil },
-			setupMock:  expectUpdateAlreadyExists(t),
+			setupMock:  expectApplyWithAssertion(t),
 			expErr:     false,
 		},
 		{
@@ -96,26 +89,14 @@ func TestIngressRedirector_RedirectAlternativeFQDN(t *testing.T) {
 			expErr: false,
 		},
 		{
-			name: "return error when redirect ingress cannot be created",
-			inAltFQDNList: []types.AlternativeFQDN{
-				{FQDN: "test.testFqdn", CertificateSecretName: defaultCertificateName},
-			},
-			inSetOwner: func(targetObject metav1.Object) error { return nil },
-			setupMock: func(m *mockIngressInterface, t *mockTraefikInterface, l []types.AlternativeFQDN) {
-				m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
-			},
-			expErr: true,
-			errMsg: "failed to upsert redirect ingress",
-		},
-		{
-			name: "return error when redirect ingress cannot be updated",
+			name: "return error when redirect ingress cannot be applied",
 			inAltFQDNList: []types.AlternativeFQDN{
 				{FQDN: "test.testFqdn", CertificateSecretName: defaultCertificateName},
 			},
 			inSetOwner: func(targetObject metav1.Object) error { return nil },
 			setupMock: func(m *mockIngressInterface, t *mockTraefikInterface, l []types.AlternativeFQDN) {
-				m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewAlreadyExists(v1.Resource("ingress"), ingressName))
-				m.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				m.EXPECT().Get(mock.Anything, ingressName, metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, ingressName))
+				m.EXPECT().Patch(mock.Anything, ingressName, k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).Return(nil, assert.AnError)
 			},
 			expErr: true,
 			errMsg: "failed to upsert redirect ingress",
@@ -147,12 +128,15 @@ func TestIngressRedirector_RedirectAlternativeFQDN(t *testing.T) {
 			ingressMock := newMockIngressInterface(t)
 			traefikMock := newMockTraefikInterface(t)
 			tt.setupMock(ingressMock, traefikMock, tt.inAltFQDNList)
+			globalConfigRepoMock := newMockGlobalConfigRepository(t)
+			globalConfigRepoMock.EXPECT().Get(mock.Anything).Return(config.CreateGlobalConfig(config.Entries{}), nil).Maybe()
 
 			redirector := &IngressRedirector{
-				ingressClassName: ingressClassName,
-				ingressInterface: ingressMock,
-				traefikInterface: traefikMock,
-				namespace:        namespace,
+				ingressClassName:       ingressClassName,
+				ingressInterface:       ingressMock,
+				traefikInterface:       traefikMock,
+				globalConfigRepository: globalConfigRepoMock,
+				namespace:              namespace,
 			}
 
 			err := redirector.RedirectAlternativeFQDN(context.TODO(), namespace, ingressName, testFqdn, tt.inAltFQDNList, tt.inSetOwner)
@@ -170,12 +154,47 @@ func TestIngressRedirector_RedirectAlternativeFQDN(t *testing.T) {
 	}
 }
 
+func TestIngressRedirector_RedirectAlternativeFQDN_globalMiddlewares(t *testing.T) {
+	altFQDNList := []types.AlternativeFQDN{{FQDN: "test.testFqdn", CertificateSecretName: defaultCertificateName}}
+	setOwner := func(targetObject metav1.Object) error { return nil }
+
+	t.Run("return error when global config cannot be read", func(t *testing.T) {
+		// given
+		globalConfigRepoMock := newMockGlobalConfigRepository(t)
+		globalConfigRepoMock.EXPECT().Get(mock.Anything).Return(config.GlobalConfig{}, assert.AnError)
+
+		redirector := &IngressRedirector{globalConfigRepository: globalConfigRepoMock, namespace: namespace}
+
+		// when
+		err := redirector.RedirectAlternativeFQDN(context.TODO(), namespace, ingressName, testFqdn, altFQDNList, setOwner)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get global config")
+	})
+	t.Run("return error when global middlewares are invalid", func(t *testing.T) {
+		// given
+		globalConfigRepoMock := newMockGlobalConfigRepository(t)
+		globalConfigRepoMock.EXPECT().Get(mock.Anything).Return(config.CreateGlobalConfig(config.Entries{"ingress/security/hsts_max_age": "sometimes"}), nil)
+
+		redirector := &IngressRedirector{globalConfigRepository: globalConfigRepoMock, namespace: namespace}
+
+		// when
+		err := redirector.RedirectAlternativeFQDN(context.TODO(), namespace, ingressName, testFqdn, altFQDNList, setOwner)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get global middlewares")
+	})
+}
+
 func TestIngressRedirector_createRedirectIngress(t *testing.T) {
 	tests := []struct {
-		name       string
-		objectName string
-		altFQDNMap map[string][]string
-		validateFn func(*testing.T, *v1.Ingress)
+		name              string
+		objectName        string
+		altFQDNMap        map[string][]string
+		globalMiddlewares []string
+		validateFn        func(*testing.T, *v1.Ingress)
 	}{
 		{
 			name:       "create ingress with single certificate and single fqdn",
@@ -205,6 +224,41 @@ func TestIngressRedirector_createRedirectIngress(t *testing.T) {
 				assert.Len(t, ingress.Spec.Rules, 3)
 			},
 		},
+		{
+			name:       "create ingress with sorted certificates and hosts",
+			objectName: "test-ingress",
+			altFQDNMap: map[string][]string{
+				"cert3": {"fqdn5.example.com"},
+				"cert1": {"fqdn2.example.com", "fqdn1.example.com"},
+				"cert2": {"fqdn4.example.com", "fqdn3.example.com"},
+			},
+			validateFn: func(t *testing.T, ingress *v1.Ingress) {
+				require.NotNil(t, ingress)
+				assert.Equal(t, []v1.IngressTLS{
+					{Hosts: []string{"fqdn1.example.com", "fqdn2.example.com"}, SecretName: "cert1"},
+					{Hosts: []string{"fqdn3.example.com", "fqdn4.example.com"}, SecretName: "cert2"},
+					{Hosts: []string{"fqdn5.example.com"}, SecretName: "cert3"},
+				}, ingress.Spec.TLS)
+
+				var hosts []string
+				for _, rule := range ingress.Spec.Rules {
+					hosts = append(hosts, rule.Host)
+				}
+				assert.Equal(t, []string{"fqdn1.example.com", "fqdn2.example.com", "fqdn3.example.com", "fqdn4.example.com", "fqdn5.example.com"}, hosts)
+			},
+		},
+		{
+			name:       "create ingress with global middlewares before the redirect",
+			objectName: "test-ingress",
+			altFQDNMap: map[string][]string{
+				"cert1": {"fqdn1.example.com"},
+			},
+			globalMiddlewares: []string{"testNamespace-global-ip-allowlist@kubernetescrd", "testNamespace-global-security-headers@kubernetescrd"},
+			validateFn: func(t *testing.T, ingress *v1.Ingress) {
+				require.NotNil(t, ingress)
+				assert.Equal(t, "testNamespace-global-ip-allowlist@kubernetescrd,testNamespace-global-security-headers@kubernetescrd,alternative-fqdn@kubernetescrd", ingress.Annotations[traefikMiddlewareAnnotation])
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -213,7 +267,7 @@ func TestIngressRedirector_createRedirectIngress(t *testing.T) {
 				ingressClassName: ingressClassName,
 			}
 
-			ingress := redirector.createRedirectIngress(namespace, tt.objectName, tt.altFQDNMap)
+			ingress := redirector.createRedirectIngress(namespace, tt.objectName, tt.altFQDNMap, tt.globalMiddlewares)
 
 			tt.validateFn(t, ingress)
 		})
@@ -228,36 +282,21 @@ func TestIngressRedirector_upsertIngress(t *testing.T) {
 		errMsg    string
 	}{
 		{
-			name: "create ingress successfully",
-			setupMock: func(m *mockIngressInterface) {
-				m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(&v1.Ingress{}, nil)
-			},
-			expErr: false,
-		},
-		{
-			name: "update ingress when already exists",
+			name: "apply ingress successfully",
 			setupMock: func(m *mockIngressInterface) {
-				m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewAlreadyExists(v1.Resource("ingress"), ingressName))
-				m.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).Return(&v1.Ingress{}, nil)
+				m.EXPECT().Get(mock.Anything, ingressName, metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, ingressName))
+				m.EXPECT().Patch(mock.Anything, ingressName, k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).Return(&v1.Ingress{}, nil)
 			},
 			expErr: false,
 		},
 		{
-			name: "return error on create failure",
+			name: "return error on apply failure",
 			setupMock: func(m *mockIngressInterface) {
-				m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				m.EXPECT().Get(mock.Anything, ingressName, metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, ingressName))
+				m.EXPECT().Patch(mock.Anything, ingressName, k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).Return(nil, assert.AnError)
 			},
 			expErr: true,
-			errMsg: "failed to create redirect ingress",
-		},
-		{
-			name: "return error on update failure",
-			setupMock: func(m *mockIngressInterface) {
-				m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewAlreadyExists(v1.Resource("ingress"), ingressName))
-				m.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
-			},
-			expErr: true,
-			errMsg: "failed to update redirect ingress",
+			errMsg: "failed to apply redirect ingress",
 		},
 	}
 
@@ -408,22 +447,22 @@ func Test_createRedirectMiddleware(t *testing.T) {
 					},
 					Spec: traefikapi.MiddlewareSpec{},
 				}
-				mwi.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(middleware, nil)
-				mwi.EXPECT().Get(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewNotFound(v1.Resource("middleware"), "test"))
+				mwi.EXPECT().Get(mock.Anything, "alternative-fqdn", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "alternative-fqdn"))
+				mwi.EXPECT().Patch(mock.Anything, "alternative-fqdn", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).Return(middleware, nil)
 				m.EXPECT().Middlewares(namespace).Return(mwi)
 			},
 			expErr: false,
 		},
 		{
-			name:        "return error when middleware creation fails",
+			name:        "return error when middleware cannot be applied",
 			primaryFqdn: "primary.example.com",
 			fqdnList: []types.AlternativeFQDN{
 				{FQDN: "alt1.example.com", CertificateSecretName: "cert1"},
 			},
 			setupMock: func(m *mockTraefikInterface) {
 				mwi := newMockMiddlewareInterface(t)
-				mwi.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
-				mwi.EXPECT().Get(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewNotFound(v1.Resource("middleware"), "test"))
+				mwi.EXPECT().Get(mock.Anything, "alternative-fqdn", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "alternative-fqdn"))
+				mwi.EXPECT().Patch(mock.Anything, "alternative-fqdn", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).Return(nil, assert.AnError)
 				m.EXPECT().Middlewares(namespace).Return(mwi)
 			},
 			expErr: true,
@@ -443,7 +482,7 @@ func Test_createRedirectMiddleware(t *testing.T) {
 				},
 			}
 
-			middlewareManager := expose.NewMiddlewareManager(traefikMock, namespace)
+			middlewareManager := expose.NewMiddlewareManager(traefikMock, namespace, nil)
 			err := createRedirectMiddleware(context.TODO(), tt.primaryFqdn, tt.fqdnList, owner, middlewareManager)
 
 			if tt.expErr {
@@ -530,47 +569,20 @@ func assertRedirectIngress(t *testing.T, ingress *v1.Ingress, altFQDNList []type
 	}
 }
 
-func expectCreateWithAssertion(t *testing.T) func(m *mockIngressInterface, tr *mockTraefikInterface, inAltFQDNList []types.AlternativeFQDN) {
-	return func(m *mockIngressInterface, tr *mockTraefikInterface, inAltFQDNList []types.AlternativeFQDN) {
-		ingress := &v1.Ingress{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: ingressName,
-				UID:  "test-uid",
-			},
-		}
-		m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
-			Run(func(ctx context.Context, ingress *v1.Ingress, opts metav1.CreateOptions) {
-				assertRedirectIngress(t, ingress, inAltFQDNList)
-			}).
-			Return(ingress, nil)
-
-		mwi := newMockMiddlewareInterface(t)
-		middleware := &traefikapi.Middleware{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test",
-				Namespace: "test",
-			},
-			Spec: traefikapi.MiddlewareSpec{},
-		}
-		mwi.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(middleware, nil)
-		mwi.EXPECT().Get(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewNotFound(v1.Resource("middleware"), "test"))
-		tr.EXPECT().Middlewares(namespace).Return(mwi)
-	}
-}
-
-func expectUpdateWithAssertion(t *testing.T) func(m *mockIngressInterface, tr *mockTraefikInterface, inAltFQDNList []types.AlternativeFQDN) {
+func expectApplyWithAssertion(t *testing.T) func(m *mockIngressInterface, tr *mockTraefikInterface, inAltFQDNList []types.AlternativeFQDN) {
 	return func(m *mockIngressInterface, tr *mockTraefikInterface, inAltFQDNList []types.AlternativeFQDN) {
-		m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewAlreadyExists(v1.Resource("ingress"), ingressName))
-
 		ingress := &v1.Ingress{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: ingressName,
 				UID:  "test-uid",
 			},
 		}
-		m.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
-			Run(func(ctx context.Context, ingress *v1.Ingress, opts metav1.UpdateOptions) {
-				assertRedirectIngress(t, ingress, inAltFQDNList)
+		m.EXPECT().Get(mock.Anything, ingressName, metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, ingressName))
+		m.EXPECT().Patch(mock.Anything, ingressName, k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+			Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+				appliedIngress := &v1.Ingress{}
+				require.NoError(t, json.Unmarshal(data, appliedIngress))
+				assertRedirectIngress(t, appliedIngress, inAltFQDNList)
 			}).
 			Return(ingress, nil)
 
@@ -582,39 +594,15 @@ func expectUpdateWithAssertion(t *testing.T) func(m *mockIngressInterface, tr *m
 			},
 			Spec: traefikapi.MiddlewareSpec{},
 		}
-		mwi.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).Return(middleware, nil)
-		mwi.EXPECT().Get(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewNotFound(v1.Resource("middleware"), "test"))
-		tr.EXPECT().Middlewares(namespace).Return(mwi)
-	}
-}
-
-func expectUpdateAlreadyExists(t *testing.T) func(m *mockIngressInterface, tr *mockTraefikInterface, inAltFQDNList []types.AlternativeFQDN) {
-	return func(m *mockIngressInterface, tr *mockTraefikInterface, inAltFQDNList []types.AlternativeFQDN) {
-		m.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewAlreadyExists(v1.Resource("ingress"), ingressName))
-
-		ingress := &v1.Ingress{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: ingressName,
-				UID:  "test-uid",
-			},
-		}
-		m.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
-			Run(func(ctx context.Context, ingress *v1.Ingress, opts metav1.UpdateOptions) {
-				assertRedirectIngress(t, ingress, inAltFQDNList)
+		mwi.EXPECT().Get(mock.Anything, "alternative-fqdn", metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "alternative-fqdn"))
+		mwi.EXPECT().Patch(mock.Anything, "alternative-fqdn", k8stypes.ApplyPatchType, mock.Anything, testApplyOptions).
+			Run(func(ctx context.Context, name string, pt k8stypes.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+				appliedMiddleware := &traefikapi.Middleware{}
+				require.NoError(t, json.Unmarshal(data, appliedMiddleware))
+				require.Len(t, appliedMiddleware.OwnerReferences, 1)
+				assert.Equal(t, k8stypes.UID("test-uid"), appliedMiddleware.OwnerReferences[0].UID)
 			}).
-			Return(ingress, nil)
-
-		mwi := newMockMiddlewareInterface(t)
-		middleware := &traefikapi.Middleware{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test",
-				Namespace: "test",
-			},
-			Spec: traefikapi.MiddlewareSpec{},
-		}
-		mwi.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(middleware, nil)
-
-		mwi.EXPECT().Get(mock.Anything, mock.Anything, mock.Anything).Return(nil, apierrors.NewNotFound(v1.Resource("middleware"), "test"))
+			Return(middleware, nil)
 		tr.EXPECT().Middlewares(namespace).Return(mwi)
 	}
 }
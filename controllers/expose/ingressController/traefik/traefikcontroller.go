@@ -1,7 +1,10 @@
 package traefik
 
 import (
+	"fmt"
+
 	k8sv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
 )
 
 const (
@@ -9,6 +12,9 @@ const (
 	IngressControllerName          = "traefik"
 	GatewayControllerName          = "k8s-ces-gateway"
 
+	maintenanceModeMiddlewareName = "maintenance-mode"
+	doguStartingMiddlewareName    = "dogu-starting"
+
 	componentLabelKey = "k8s.cloudogu.com/component.name"
 )
 
@@ -46,7 +52,10 @@ type IngressControllerDependencies struct {
 	IngressClassName string
 	ControllerType   string
 	TraefikInterface traefikInterface
+	Recorder         eventRecorder
 	Namespace        string
+	// GlobalConfigRepository is used to attach the global middlewares to the redirect ingress.
+	GlobalConfigRepository globalConfigRepository
 }
 
 func NewTraefikController(deps IngressControllerDependencies) *IngressController {
@@ -54,13 +63,16 @@ func NewTraefikController(deps IngressControllerDependencies) *IngressController
 		PortExposer: &PortExposer{
 			traefikInterface: deps.TraefikInterface,
 			ingressInterface: deps.IngressInterface,
+			recorder:         deps.Recorder,
 			namespace:        deps.Namespace,
 		},
 		IngressRedirector: &IngressRedirector{
-			ingressClassName: deps.IngressClassName,
-			ingressInterface: deps.IngressInterface,
-			traefikInterface: deps.TraefikInterface,
-			namespace:        deps.Namespace,
+			ingressClassName:       deps.IngressClassName,
+			ingressInterface:       deps.IngressInterface,
+			traefikInterface:       deps.TraefikInterface,
+			globalConfigRepository: deps.GlobalConfigRepository,
+			recorder:               deps.Recorder,
+			namespace:              deps.Namespace,
 		},
 		controllerType: mapStringToControllerType(deps.ControllerType),
 	}
@@ -78,6 +90,43 @@ func (c *IngressController) GetSelector() map[string]string {
 	return selectorMap[c.controllerType]
 }
 
+// NewRoutingUpdater creates an updater of ingress objects or, in the routing mode ingressroute, of traefik ingress
+// routes.
+func (c *IngressController) NewRoutingUpdater(routingMode string, deps expose.IngressUpdaterDependencies) (expose.RoutingUpdater, error) {
+	switch routingMode {
+	case "", expose.TraefikRoutingModeIngress:
+		return expose.NewIngressUpdater(deps), nil
+	case expose.TraefikRoutingModeIngressRoute:
+		return expose.NewIngressRouteUpdater(deps), nil
+	default:
+		return nil, fmt.Errorf("unknown traefik routing mode [%s], expected one of [%s, %s]", routingMode, expose.TraefikRoutingModeIngress, expose.TraefikRoutingModeIngressRoute)
+	}
+}
+
+// UsesMiddlewares returns true because traefik rewrites paths with middlewares referenced by the ingress objects.
+func (c *IngressController) UsesMiddlewares() bool {
+	return true
+}
+
+// GetPathRewriteAnnotations references the middleware rewriting the requests of an ingress object.
+func (c *IngressController) GetPathRewriteAnnotations(namespace string, middlewareName string, _ string) map[string]string {
+	return getMiddlewareAnnotations(namespace, middlewareName)
+}
+
+// GetMaintenanceModeAnnotations references the middleware `maintenance-mode` deployed with the service discovery.
+func (c *IngressController) GetMaintenanceModeAnnotations(namespace string) map[string]string {
+	return getMiddlewareAnnotations(namespace, maintenanceModeMiddlewareName)
+}
+
+// GetDoguStartingAnnotations references the middleware `dogu-starting` deployed with the service discovery.
+func (c *IngressController) GetDoguStartingAnnotations(namespace string) map[string]string {
+	return getMiddlewareAnnotations(namespace, doguStartingMiddlewareName)
+}
+
+func getMiddlewareAnnotations(namespace string, middlewareName string) map[string]string {
+	return map[string]string{ingressRewriteTargetAnnotation: fmt.Sprintf("%s-%s@kubernetescrd", namespace, middlewareName)}
+}
+
 func mapStringToControllerType(s string) controllerType {
 	switch s {
 	case GatewayControllerName:
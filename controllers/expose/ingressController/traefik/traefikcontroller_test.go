@@ -3,6 +3,7 @@ package traefik
 import (
 	"testing"
 
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -91,6 +92,77 @@ func Test_controller_GetRewriteAnnotationKey(t *testing.T) {
 	})
 }
 
+func TestIngressController_NewRoutingUpdater(t *testing.T) {
+	t.Run("should create ingress updater by default", func(t *testing.T) {
+		// when
+		actual, err := (&IngressController{}).NewRoutingUpdater("", expose.IngressUpdaterDependencies{})
+
+		// then
+		require.NoError(t, err)
+		assert.IsType(t, expose.NewIngressUpdater(expose.IngressUpdaterDependencies{}), actual)
+	})
+	t.Run("should create ingress route updater", func(t *testing.T) {
+		// given
+		traefikInterfaceMock := newMockTraefikInterface(t)
+		traefikInterfaceMock.EXPECT().IngressRoutes("ecosystem").Return(nil)
+		traefikInterfaceMock.EXPECT().TraefikServices("ecosystem").Return(nil)
+		traefikInterfaceMock.EXPECT().ServersTransports("ecosystem").Return(nil)
+		deps := expose.IngressUpdaterDependencies{Namespace: "ecosystem", TraefikInterface: traefikInterfaceMock}
+
+		// when
+		actual, err := (&IngressController{}).NewRoutingUpdater("ingressroute", deps)
+
+		// then
+		require.NoError(t, err)
+		assert.IsType(t, expose.NewIngressRouteUpdater(deps), actual)
+	})
+	t.Run("should fail on unknown routing mode", func(t *testing.T) {
+		// when
+		_, err := (&IngressController{}).NewRoutingUpdater("gatewayroute", expose.IngressUpdaterDependencies{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown traefik routing mode [gatewayroute]")
+	})
+}
+
+func TestIngressController_UsesMiddlewares(t *testing.T) {
+	assert.True(t, (&IngressController{}).UsesMiddlewares())
+}
+
+func TestIngressController_GetPathRewriteAnnotations(t *testing.T) {
+	// given
+	sut := &IngressController{}
+
+	// when
+	annotations := sut.GetPathRewriteAnnotations("ecosystem", "nexus-replace-path", "/nexus/$2")
+
+	// then
+	assert.Equal(t, map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "ecosystem-nexus-replace-path@kubernetescrd"}, annotations)
+}
+
+func TestIngressController_GetMaintenanceModeAnnotations(t *testing.T) {
+	// given
+	sut := &IngressController{}
+
+	// when
+	annotations := sut.GetMaintenanceModeAnnotations("ecosystem")
+
+	// then
+	assert.Equal(t, map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "ecosystem-maintenance-mode@kubernetescrd"}, annotations)
+}
+
+func TestIngressController_GetDoguStartingAnnotations(t *testing.T) {
+	// given
+	sut := &IngressController{}
+
+	// when
+	annotations := sut.GetDoguStartingAnnotations("ecosystem")
+
+	// then
+	assert.Equal(t, map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "ecosystem-dogu-starting@kubernetescrd"}, annotations)
+}
+
 func TestIngressController_GetSelector(t *testing.T) {
 	tests := []struct {
 		name             string
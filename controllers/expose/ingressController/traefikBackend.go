@@ -0,0 +1,25 @@
+package ingressController
+
+import (
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/traefik"
+)
+
+func init() {
+	Register(traefik.GatewayControllerName, newTraefikFactory(traefik.GatewayControllerName))
+	Register(traefik.IngressControllerName, newTraefikFactory(traefik.IngressControllerName))
+}
+
+func newTraefikFactory(controllerType string) Factory {
+	return func(deps Dependencies) IngressController {
+		return traefik.NewTraefikController(traefik.IngressControllerDependencies{
+			IngressInterface: deps.IngressInterface,
+			IngressClassName: deps.IngressClassName,
+			ControllerType:   controllerType,
+			TraefikInterface: deps.TraefikInterface,
+			Recorder:         deps.Recorder,
+			Namespace:        deps.Namespace,
+			// the global middlewares are part of the redirect ingress of the alternative fqdns
+			GlobalConfigRepository: deps.GlobalConfigRepository,
+		})
+	}
+}
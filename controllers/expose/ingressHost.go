@@ -0,0 +1,154 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cesErrors "github.com/cloudogu/ces-commons-lib/errors"
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ecosystemCertificateSecretName is the tls secret containing the certificate of the ecosystem.
+	ecosystemCertificateSecretName = "ecosystem-certificate"
+	globalConfigFQDNKey            = "fqdn"
+	rootPath                       = "/"
+)
+
+const (
+	// doguConfigIngressHostKey is the dogu config key which overrides the host of a ces service, e.g., `ingress/nexus/host`.
+	doguConfigIngressHostKey = "ingress/%s/host"
+	// doguConfigIngressTLSSecretNameKey is the dogu config key which overrides the tls secret of a ces service, e.g., `ingress/nexus/tls_secret_name`.
+	doguConfigIngressTLSSecretNameKey = "ingress/%s/tls_secret_name"
+)
+
+// hasHost returns true if the ces service is served on a specific host.
+func (cs CesService) hasHost() bool {
+	return cs.Host != ""
+}
+
+// getTLSSecretName returns the name of the tls secret used for the host of the ces service. Defaults to the
+// ecosystem certificate.
+func (cs CesService) getTLSSecretName() string {
+	if cs.TLSSecretName == "" {
+		return ecosystemCertificateSecretName
+	}
+
+	return cs.TLSSecretName
+}
+
+// globalRoutingConfig contains the entries of the global config which affect the routing of all ces services.
+type globalRoutingConfig struct {
+	fqdn string
+	// globalMiddlewares are the references of the global middlewares of the service discovery, e.g., the security
+	// headers.
+	globalMiddlewares []string
+	// globalMiddlewareSpecs are the specs of the global middlewares, which are translated into the filters of the http
+	// routes of the gateway api.
+	globalMiddlewareSpecs []globalMiddleware
+	// httpsRedirect is true if all http requests are redirected to https by the catch-all route of the http
+	// entrypoint.
+	httpsRedirect bool
+	// defaultMiddlewares are the comma separated middlewares of all dogus.
+	defaultMiddlewares string
+	// ipAllowListDepth is the default depth of the ip allowlists of the dogus.
+	ipAllowListDepth int
+	// forwardAuthMiddleware is the reference of the forward auth middleware. Empty if no auth endpoint is configured.
+	forwardAuthMiddleware string
+}
+
+// resolveHost returns the given ces service with the host on which it is served. Ces services without an own host are
+// served on the fqdn of the ecosystem.
+//
+// Own hosts are defined by the ces service or overridden by the dogu config. A ces service with an own host is served
+// at the root path of this host. Hosts without a dot are treated as subdomains of the fqdn, e.g., `nexus` becomes
+// `nexus.<fqdn>`.
+func resolveHost(cesService CesService, doguConfig libconfig.DoguConfig, fqdn string) CesService {
+	if host, ok := doguConfig.Get(libconfig.Key(fmt.Sprintf(doguConfigIngressHostKey, cesService.Name))); ok {
+		cesService.Host = host.String()
+	}
+	if secretName, ok := doguConfig.Get(libconfig.Key(fmt.Sprintf(doguConfigIngressTLSSecretNameKey, cesService.Name))); ok {
+		cesService.TLSSecretName = secretName.String()
+	}
+
+	if !cesService.hasHost() {
+		cesService.Host = fqdn
+		return cesService
+	}
+
+	if !strings.Contains(cesService.Host, ".") {
+		cesService.Host = fmt.Sprintf("%s.%s", cesService.Host, fqdn)
+	}
+
+	// the dogu owns the whole host, so sub-path rewrites are replaced by a path replacement of the root path
+	cesService.Location = rootPath
+	cesService.Rewrite = ""
+	return cesService
+}
+
+func (i *ingressUpdater) getDoguConfig(ctx context.Context, service *corev1.Service) (libconfig.DoguConfig, error) {
+	if !util.HasDoguLabel(service) {
+		return libconfig.DoguConfig{}, nil
+	}
+
+	doguConfig, err := i.doguConfigRepository.Get(ctx, cescommons.SimpleName(service.Name))
+	if err != nil {
+		if cesErrors.IsNotFoundError(err) {
+			return libconfig.DoguConfig{}, nil
+		}
+
+		return libconfig.DoguConfig{}, fmt.Errorf("failed to get dogu config of service [%s]: %w", service.Name, err)
+	}
+
+	return doguConfig, nil
+}
+
+func (i *ingressUpdater) getGlobalRoutingConfig(ctx context.Context) (globalRoutingConfig, error) {
+	globalConfig, err := i.globalConfigRepository.Get(ctx)
+	if err != nil {
+		return globalRoutingConfig{}, fmt.Errorf("failed to get global config: %w", err)
+	}
+
+	fqdn, ok := globalConfig.Get(globalConfigFQDNKey)
+	if !ok || fqdn.String() == "" {
+		return globalRoutingConfig{}, fmt.Errorf("fqdn not found in global config")
+	}
+
+	globalMiddlewares, err := getGlobalMiddlewares(globalConfig)
+	if err != nil {
+		return globalRoutingConfig{}, fmt.Errorf("failed to get global middlewares: %w", err)
+	}
+
+	httpsRedirect, err := getHTTPSRedirectMiddleware(globalConfig)
+	if err != nil {
+		return globalRoutingConfig{}, fmt.Errorf("failed to get https redirect middleware: %w", err)
+	}
+
+	forwardAuthMiddleware, err := GetForwardAuthMiddlewareRef(i.namespace, globalConfig)
+	if err != nil {
+		return globalRoutingConfig{}, fmt.Errorf("failed to get forward auth middleware: %w", err)
+	}
+
+	// the global ip allowlist was already validated with the global middlewares
+	globalAllowList, _ := getGlobalIPAllowList(globalConfig)
+
+	routingConfig := globalRoutingConfig{
+		fqdn:                  fqdn.String(),
+		globalMiddlewareSpecs: globalMiddlewares,
+		httpsRedirect:         httpsRedirect != nil,
+		ipAllowListDepth:      globalAllowList.depth,
+		forwardAuthMiddleware: forwardAuthMiddleware,
+	}
+	for _, middleware := range globalMiddlewares {
+		routingConfig.globalMiddlewares = append(routingConfig.globalMiddlewares, getCRDMiddlewareRef(i.namespace, middleware.name))
+	}
+	if middlewares, ok := globalConfig.Get(GlobalConfigMiddlewaresKey); ok {
+		routingConfig.defaultMiddlewares = middlewares.String()
+	}
+
+	return routingConfig, nil
+}
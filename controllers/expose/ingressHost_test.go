@@ -0,0 +1,223 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testFQDN = "ces.example.com"
+
+func Test_resolveHost(t *testing.T) {
+	t.Run("should serve ces service without host on the fqdn", func(t *testing.T) {
+		// when
+		actual := resolveHost(CesService{Name: "nexus", Location: "/nexus", Pass: "/nexus"}, config.DoguConfig{}, testFQDN)
+
+		// then
+		assert.Equal(t, CesService{Name: "nexus", Location: "/nexus", Pass: "/nexus", Host: testFQDN}, actual)
+	})
+	t.Run("should expand subdomain with the fqdn and serve the root path", func(t *testing.T) {
+		// given
+		cesService := CesService{Name: "nexus-docker", Location: "/v2", Pass: "/v2", Rewrite: "{\"pattern\":\"v2\",\"rewrite\":\"\"}", Host: "registry"}
+
+		// when
+		actual := resolveHost(cesService, config.DoguConfig{}, testFQDN)
+
+		// then
+		assert.Equal(t, CesService{Name: "nexus-docker", Location: "/", Pass: "/v2", Host: "registry.ces.example.com"}, actual)
+	})
+	t.Run("should apply host and tls secret overrides from the dogu config", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/host":            "repo.example.com",
+			"ingress/nexus/tls_secret_name": "repo-certificate",
+		})
+
+		// when
+		actual := resolveHost(CesService{Name: "nexus", Location: "/nexus", Pass: "/nexus"}, doguConfig, testFQDN)
+
+		// then
+		assert.Equal(t, CesService{Name: "nexus", Location: "/", Pass: "/nexus", Host: "repo.example.com", TLSSecretName: "repo-certificate"}, actual)
+	})
+}
+
+func Test_ingressUpdater_getGlobalRoutingConfig(t *testing.T) {
+	t.Run("should get fqdn from the global config", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN})}
+
+		// when
+		actual, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, globalRoutingConfig{fqdn: testFQDN}, actual)
+	})
+	t.Run("should get default middlewares from the global config", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{
+			"fqdn":                testFQDN,
+			"ingress/middlewares": "compress@file",
+		})}
+
+		// when
+		actual, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, globalRoutingConfig{fqdn: testFQDN, defaultMiddlewares: "compress@file"}, actual)
+	})
+	t.Run("should get global middlewares from the global config", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{namespace: testNamespace, globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{
+			"fqdn":                            testFQDN,
+			"ingress/security/https_redirect": "true",
+			"ingress/security/frame_options":  "SAMEORIGIN",
+		})}
+
+		// when
+		actual, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, globalRoutingConfig{
+			fqdn: testFQDN,
+			globalMiddlewares: []string{
+				"my-namespace-global-security-headers@kubernetescrd",
+			},
+			globalMiddlewareSpecs: []globalMiddleware{
+				{name: "global-security-headers", spec: traefikapi.MiddlewareSpec{Headers: &dynamic.Headers{CustomFrameOptionsValue: "SAMEORIGIN"}}},
+			},
+			httpsRedirect: true,
+		}, actual)
+	})
+	t.Run("should get the depth of the global ip allowlist", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{namespace: testNamespace, globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{
+			"fqdn":                                testFQDN,
+			"ingress/security/ip_allowlist_depth": "2",
+		})}
+
+		// when
+		actual, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, globalRoutingConfig{fqdn: testFQDN, ipAllowListDepth: 2}, actual)
+	})
+	t.Run("should get the forward auth middleware of the global config", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{namespace: testNamespace, globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{
+			"fqdn":                                  testFQDN,
+			"ingress/security/forward_auth_address": "http://localhost:4181",
+		})}
+
+		// when
+		actual, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, globalRoutingConfig{fqdn: testFQDN, forwardAuthMiddleware: "my-namespace-forward-auth@kubernetescrd"}, actual)
+	})
+	t.Run("should fail for invalid global middleware config", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{namespace: testNamespace, globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{
+			"fqdn":                          testFQDN,
+			"ingress/security/hsts_max_age": "one year",
+		})}
+
+		// when
+		_, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get global middlewares")
+		assert.ErrorContains(t, err, "invalid value [one year] of global config key [ingress/security/hsts_max_age]")
+	})
+	t.Run("should fail to get global config", func(t *testing.T) {
+		// given
+		globalConfigRepoMock := NewMockGlobalConfigRepository(t)
+		globalConfigRepoMock.EXPECT().Get(testCtx).Return(config.GlobalConfig{}, assert.AnError)
+
+		sut := ingressUpdater{globalConfigRepository: globalConfigRepoMock}
+
+		// when
+		_, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get global config")
+	})
+	t.Run("should fail if fqdn is not set", func(t *testing.T) {
+		// given
+		sut := ingressUpdater{globalConfigRepository: getGlobalConfigRepositoryMock(t, nil)}
+
+		// when
+		_, err := sut.getGlobalRoutingConfig(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "fqdn not found in global config")
+	})
+}
+
+func Test_ingressUpdater_getIngress(t *testing.T) {
+	service := corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nexus", UID: "uid"}}
+	sut := ingressUpdater{namespace: testNamespace, ingressClassName: testIngressClassName}
+
+	t.Run("should create host-less rule for ces service without host", func(t *testing.T) {
+		// when
+		actual := sut.getIngress(resolvedCesService{CesService: CesService{Name: "nexus"}}, service.ObjectMeta, service.TypeMeta, "/nexus", "nexus", 8082, nil)
+
+		// then
+		assert.Equal(t, getTestIngress("nexus", "/nexus", service, "nexus", 8082, nil), actual)
+	})
+	t.Run("should create host rule and tls entry with the ecosystem certificate", func(t *testing.T) {
+		// when
+		actual := sut.getIngress(resolvedCesService{CesService: CesService{Name: "nexus", Host: "nexus.ces.example.com"}}, service.ObjectMeta, service.TypeMeta, "/", "nexus", 8082, nil)
+
+		// then
+		assert.Equal(t, "nexus.ces.example.com", actual.Spec.Rules[0].Host)
+		assert.Equal(t, []v1.IngressTLS{{Hosts: []string{"nexus.ces.example.com"}, SecretName: "ecosystem-certificate"}}, actual.Spec.TLS)
+	})
+	t.Run("should create tls entry with the named secret", func(t *testing.T) {
+		// when
+		actual := sut.getIngress(resolvedCesService{CesService: CesService{Name: "nexus", Host: "repo.example.com", TLSSecretName: "repo-certificate"}}, service.ObjectMeta, service.TypeMeta, "/", "nexus", 8082, nil)
+
+		// then
+		assert.Equal(t, "repo.example.com", actual.Spec.Rules[0].Host)
+		assert.Equal(t, []v1.IngressTLS{{Hosts: []string{"repo.example.com"}, SecretName: "repo-certificate"}}, actual.Spec.TLS)
+	})
+	t.Run("should set router priority by the specificity of the path", func(t *testing.T) {
+		// when
+		actual := sut.getIngress(resolvedCesService{CesService: CesService{Name: "nexus"}}, service.ObjectMeta, service.TypeMeta, "/nexus", "nexus", 8082, nil)
+
+		// then
+		assert.Equal(t, "1006", actual.Annotations["traefik.ingress.kubernetes.io/router.priority"])
+	})
+	t.Run("should keep router priority of the passed annotations", func(t *testing.T) {
+		// when
+		actual := sut.getIngress(resolvedCesService{CesService: CesService{Name: "nexus"}}, service.ObjectMeta, service.TypeMeta, "/nexus", "nexus", 8082, map[string]string{"traefik.ingress.kubernetes.io/router.priority": "42"})
+
+		// then
+		assert.Equal(t, "42", actual.Annotations["traefik.ingress.kubernetes.io/router.priority"])
+	})
+	t.Run("should use the path type of the ingress controller", func(t *testing.T) {
+		// given
+		nginxSut := ingressUpdater{namespace: testNamespace, ingressClassName: testIngressClassName, pathType: v1.PathTypeImplementationSpecific}
+
+		// when
+		actual := nginxSut.getIngress(resolvedCesService{CesService: CesService{Name: "nexus"}}, service.ObjectMeta, service.TypeMeta, "/nexus(/|$)(.*)", "nexus", 8082, nil)
+
+		// then
+		assert.Equal(t, v1.PathTypeImplementationSpecific, *actual.Spec.Rules[0].HTTP.Paths[0].PathType)
+	})
+}
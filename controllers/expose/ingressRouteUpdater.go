@@ -0,0 +1,465 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// TraefikRoutingModeIngress routes the dogus via ingress objects with traefik annotations.
+	TraefikRoutingModeIngress = "ingress"
+	// TraefikRoutingModeIngressRoute routes the dogus via traefik ingress routes and traefik services.
+	TraefikRoutingModeIngressRoute = "ingressroute"
+)
+
+const (
+	ingressRouterMiddlewaresAnnotation = "traefik.ingress.kubernetes.io/router.middlewares"
+	maintenanceModeMiddlewareName      = "maintenance-mode"
+	doguStartingMiddlewareName         = "dogu-starting"
+	kubernetesCRDProviderSuffix        = "@kubernetescrd"
+	ingressRouteKind                   = "ingress route"
+	traefikServiceKind                 = "TraefikService"
+	serversTransportKind               = "ServersTransport"
+)
+
+const failedIngressRouteUpdateErrMsg = "failed to update ingress route: %w"
+
+var (
+	hostMatcherRegex       = regexp.MustCompile("Host\\(`([^`]*)`\\)")
+	pathPrefixMatcherRegex = regexp.MustCompile("PathPrefix\\(`([^`]*)`\\)")
+)
+
+// ingressRouteUpdater exposes the ces services as traefik ingress routes instead of ingress objects. The middlewares
+// of a route are a typed list and the priority of a route is explicit, so no annotations are required. Every route
+// forwards the requests to a traefik service named like the ces service.
+type ingressRouteUpdater struct {
+	*ingressUpdater
+	ingressRouteInterface   ingressRouteInterface
+	traefikServiceInterface traefikServiceInterface
+	// podInterface lists the pods of the dogu versions of a canary.
+	podInterface podInterface
+	// deploymentInterface pauses the rollouts of the dogus during their canaries.
+	deploymentInterface deploymentInterface
+}
+
+// NewIngressRouteUpdater creates a new instance responsible for updating the traefik ingress routes.
+func NewIngressRouteUpdater(deps IngressUpdaterDependencies) *ingressRouteUpdater {
+	return &ingressRouteUpdater{
+		ingressUpdater:          NewIngressUpdater(deps),
+		ingressRouteInterface:   deps.TraefikInterface.IngressRoutes(deps.Namespace),
+		traefikServiceInterface: deps.TraefikInterface.TraefikServices(deps.Namespace),
+		podInterface:            deps.PodInterface,
+		deploymentInterface:     deps.DeploymentInterface,
+	}
+}
+
+// UpsertIngressForService creates or updates the ingress routes and traefik services of the given service.
+func (r *ingressRouteUpdater) UpsertIngressForService(ctx context.Context, service *corev1.Service) error {
+	_, isMaintenanceMode, err := r.maintenanceAdapter.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	cesServices, err := r.getRoutedCesServices(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	selector := labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).String()
+	routeList, err := r.ingressRouteInterface.List(ctx, v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list ingress routes: %w", err)
+	}
+
+	var otherRoutes []ingressRoute
+	routes := make([]v1.Object, 0, len(routeList.Items))
+	for index, traefikRoute := range routeList.Items {
+		routes = append(routes, &routeList.Items[index])
+
+		route, ok := newRouteOfIngressRoute(traefikRoute)
+		if ok && !isOwnedByService(traefikRoute.GetOwnerReferences(), service) {
+			otherRoutes = append(otherRoutes, route)
+		}
+	}
+
+	cesServices, err = r.resolveRouteConflicts(ctx, service, cesServices, otherRoutes, r.deleteIngressRoute)
+	if err != nil {
+		return fmt.Errorf("failed to resolve ingress route conflicts of service [%s]: %w", service.Name, err)
+	}
+
+	for _, cesService := range cesServices {
+		upsertErr := r.upsertIngressRouteForCesService(ctx, cesService, service, isMaintenanceMode)
+		if upsertErr != nil {
+			return fmt.Errorf("failed to create ingress route for ces service [%+v]: %w", cesService, upsertErr)
+		}
+	}
+
+	err = r.deleteStaleRoutes(ctx, service, cesServices, routes, ingressRouteKind, r.deleteIngressRoute)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale ingress routes of service [%s]: %w", service.Name, err)
+	}
+
+	err = r.deleteReplacedIngresses(ctx, service)
+	if err != nil {
+		return fmt.Errorf("failed to delete replaced ingress objects of service [%s]: %w", service.Name, err)
+	}
+
+	err = r.middlewareManager.deleteOrphanedMiddlewares(ctx, service, cesServices)
+	if err != nil {
+		return fmt.Errorf("failed to delete orphaned middlewares of service [%s]: %w", service.Name, err)
+	}
+
+	return nil
+}
+
+func newRouteOfIngressRoute(traefikRoute traefikapi.IngressRoute) (ingressRoute, bool) {
+	if len(traefikRoute.OwnerReferences) == 0 || len(traefikRoute.Spec.Routes) == 0 {
+		return ingressRoute{}, false
+	}
+
+	match := traefikRoute.Spec.Routes[0].Match
+	pathMatch := pathPrefixMatcherRegex.FindStringSubmatch(match)
+	if pathMatch == nil {
+		return ingressRoute{}, false
+	}
+
+	route := ingressRoute{
+		ingressName: traefikRoute.Name,
+		serviceName: traefikRoute.OwnerReferences[0].Name,
+		path:        normalizeRoutePath(pathMatch[1]),
+	}
+
+	if hostMatch := hostMatcherRegex.FindStringSubmatch(match); hostMatch != nil {
+		route.host = hostMatch[1]
+	}
+
+	return route, true
+}
+
+// deleteIngressRoute deletes the ingress route with the given name and the traefik services and servers transport of
+// the route.
+func (r *ingressRouteUpdater) deleteIngressRoute(ctx context.Context, name string) error {
+	err := r.ingressRouteInterface.Delete(ctx, name, v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	err = r.deleteServersTransport(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = r.deleteTraefikService(ctx, getMirroringTraefikServiceName(name))
+	if err != nil {
+		return err
+	}
+
+	return r.traefikServiceInterface.Delete(ctx, name, v1.DeleteOptions{})
+}
+
+// deleteReplacedIngresses deletes the ingress objects of the given service, which are replaced by the ingress routes
+// after switching the routing mode.
+func (r *ingressRouteUpdater) deleteReplacedIngresses(ctx context.Context, service *corev1.Service) error {
+	selector := labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).String()
+	ingressList, err := r.ingressInterface.List(ctx, v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list ingress objects: %w", err)
+	}
+
+	for _, ingress := range ingressList.Items {
+		if !isOwnedByService(ingress.GetOwnerReferences(), service) {
+			continue
+		}
+
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("ingress [%s] of service [%s] is replaced by an ingress route -> delete ingress object", ingress.Name, service.Name))
+		err = r.ingressInterface.Delete(ctx, ingress.Name, v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ingress %s: %w", ingress.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ingressRouteUpdater) upsertIngressRouteForCesService(ctx context.Context, cesService resolvedCesService, service *corev1.Service, isMaintenanceMode bool) error {
+	dogu, err := r.doguInterface.Get(ctx, service.Name, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get dogu for service [%s]: %w", service.Name, err)
+	}
+
+	if isMaintenanceMode {
+		return r.upsertMaintenanceModeIngressRoute(ctx, cesService, service, dogu)
+	}
+
+	if util.HasDoguLabel(service) {
+		isReady, err := r.deploymentReadyChecker.IsReady(ctx, service.Name)
+		if err != nil {
+			return err
+		}
+
+		if !isReady {
+			return r.upsertDoguIsStartingIngressRoute(ctx, cesService, service)
+		}
+	}
+
+	err = r.upsertDoguIngressRoute(ctx, cesService, service, dogu)
+	if err != nil {
+		return err
+	}
+
+	r.eventRecorder.Eventf(dogu, corev1.EventTypeNormal, ingressCreationEventReason, "Created regular ingress route for service [%s].", cesService.Name)
+	return nil
+}
+
+func (r *ingressRouteUpdater) upsertMaintenanceModeIngressRoute(ctx context.Context, cesService resolvedCesService, service *corev1.Service, dogu *doguv2.Dogu) error {
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("system is in maintenance mode -> create maintenance ingress route for service [%s]", service.GetName()))
+
+	chain := getStaticPageMiddlewareChain(cesService, getCRDMiddlewareRef(r.namespace, maintenanceModeMiddlewareName))
+	route := r.getIngressRoute(cesService, service, cesService.Location, getStaticContentService(), r.parseMiddlewareRefs(chain.String()))
+	err := r.applyIngressRoute(ctx, route)
+	if err != nil {
+		return fmt.Errorf(failedIngressRouteUpdateErrMsg, err)
+	}
+
+	r.eventRecorder.Eventf(dogu, corev1.EventTypeNormal, ingressCreationEventReason, "Ingress route for service [%s] has been updated to maintenance mode.", cesService.Name)
+	return nil
+}
+
+func (r *ingressRouteUpdater) upsertDoguIsStartingIngressRoute(ctx context.Context, cesService resolvedCesService, service *corev1.Service) error {
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("dogu is still starting -> create dogu is starting ingress route for service [%s]", service.GetName()))
+
+	chain := getStaticPageMiddlewareChain(cesService, getCRDMiddlewareRef(r.namespace, doguStartingMiddlewareName))
+	route := r.getIngressRoute(cesService, service, cesService.Location, getStaticContentService(), r.parseMiddlewareRefs(chain.String()))
+	err := r.applyIngressRoute(ctx, route)
+	if err != nil {
+		return fmt.Errorf(failedIngressRouteUpdateErrMsg, err)
+	}
+
+	return nil
+}
+
+func (r *ingressRouteUpdater) upsertDoguIngressRoute(ctx context.Context, cesService resolvedCesService, service *corev1.Service, dogu *doguv2.Dogu) error {
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("dogu is ready -> update ces service ingress route for service [%s]", service.GetName()))
+
+	ownerReferences := []v1.OwnerReference{{
+		APIVersion: service.APIVersion,
+		Kind:       service.Kind,
+		Name:       service.Name,
+		UID:        service.UID,
+	}}
+
+	additionalAnnotations, err := getAdditionalIngressAnnotations(service)
+	if err != nil {
+		return err
+	}
+
+	translation, additionalAnnotations, err := r.translateAdditionalAnnotations(ctx, cesService, service, dogu, additionalAnnotations)
+	if err != nil {
+		return err
+	}
+
+	translatedMiddlewares, err := r.createTranslatedMiddlewares(ctx, cesService, service, translation, ownerReferences)
+	if err != nil {
+		return err
+	}
+
+	serviceMiddlewares, err := r.createServiceMiddlewares(ctx, cesService, service, ownerReferences)
+	if err != nil {
+		return err
+	}
+
+	routePath := cesService.Location
+	var rewriteMiddlewares []string
+
+	if cesService.needsReplacePathMiddleware() || translation.hasRewrite() {
+		pathPrefix, _, err := cesService.getReplacePath()
+		if err != nil {
+			return fmt.Errorf("error getting rewrite-config from ces-service: %w", err)
+		}
+
+		if !translation.hasRewrite() {
+			middlewareName, err := r.middlewareManager.createOrUpdateReplacePathMiddleware(ctx, service.Name, cesService, ownerReferences)
+			if err != nil {
+				return fmt.Errorf("failed to create/update middleware: %w", err)
+			}
+
+			rewriteMiddlewares = append(rewriteMiddlewares, getCRDMiddlewareRef(r.namespace, middlewareName))
+		}
+
+		routePath = normalizeRoutePath(pathPrefix)
+	}
+
+	// the translated rewrite target of the dogu replaces the managed path rewrite and comes first
+	for _, name := range translatedMiddlewares {
+		rewriteMiddlewares = append(rewriteMiddlewares, getCRDMiddlewareRef(r.namespace, name))
+	}
+
+	chain := r.getMiddlewareChain(cesService, serviceMiddlewares, rewriteMiddlewares, additionalAnnotations[ingressRouterMiddlewaresAnnotation])
+	delete(additionalAnnotations, ingressRouterMiddlewaresAnnotation)
+
+	timeouts := cesService.backend.getForwardingTimeouts(translation.forwardingTimeouts)
+	serversTransportName, err := r.upsertServersTransport(ctx, cesService.Name, timeouts, ownerReferences)
+	if err != nil {
+		return err
+	}
+
+	traefikService := r.getTraefikService(cesService, service, serversTransportName, ownerReferences)
+	_, err = util.ServerSideApply[*traefikapi.TraefikService](ctx, r.traefikServiceInterface, traefikService, r.eventRecorder)
+	if err != nil {
+		return fmt.Errorf("failed to upsert traefik service %s: %w", traefikService.Name, err)
+	}
+
+	// the mirroring traefik service wraps the traefik service of the ces service
+	targetServiceName, err := r.upsertMirroring(ctx, cesService, ownerReferences)
+	if err != nil {
+		return err
+	}
+
+	targetService := traefikapi.Service{LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+		Name:      targetServiceName,
+		Kind:      traefikServiceKind,
+		Namespace: r.namespace,
+	}}
+
+	route := r.getIngressRoute(cesService, service, routePath, targetService, r.parseMiddlewareRefs(chain.String()))
+	r.applyAdditionalAnnotations(ctx, service, route, additionalAnnotations)
+
+	err = r.applyIngressRoute(ctx, route)
+	if err != nil {
+		return fmt.Errorf(failedIngressRouteUpdateErrMsg, err)
+	}
+
+	return nil
+}
+
+// applyAdditionalAnnotations transfers the additional ingress annotations of a dogu to the typed fields of the given
+// ingress route. The middlewares of the dogu are part of the middleware chain of the route. Annotations without an
+// equivalent in ingress routes are ignored.
+func (r *ingressRouteUpdater) applyAdditionalAnnotations(ctx context.Context, service *corev1.Service, route *traefikapi.IngressRoute, annotations doguv2.IngressAnnotations) {
+	for key, value := range annotations {
+		switch key {
+		case routerPriorityAnnotation:
+			priority, err := strconv.Atoi(value)
+			if err != nil {
+				ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("invalid router priority [%s] of service [%s] -> ignore annotation", value, service.Name))
+				continue
+			}
+
+			route.Spec.Routes[0].Priority = priority
+		default:
+			ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("additional ingress annotation [%s] of service [%s] is not supported by ingress routes -> ignore annotation", key, service.Name))
+		}
+	}
+}
+
+// parseMiddlewareRefs converts the comma separated middlewares of the traefik router annotation into middleware
+// references. Middlewares of the kubernetes crd provider in the namespace of the route are referenced by their name,
+// all other middlewares keep their provider suffix, e.g., `compress@file`.
+func (r *ingressRouteUpdater) parseMiddlewareRefs(value string) []traefikapi.MiddlewareRef {
+	var refs []traefikapi.MiddlewareRef
+	for _, middleware := range strings.Split(value, ",") {
+		middleware = strings.TrimSpace(middleware)
+		if middleware == "" {
+			continue
+		}
+
+		namespacePrefix := r.namespace + "-"
+		if strings.HasSuffix(middleware, kubernetesCRDProviderSuffix) && strings.HasPrefix(middleware, namespacePrefix) {
+			name := strings.TrimSuffix(strings.TrimPrefix(middleware, namespacePrefix), kubernetesCRDProviderSuffix)
+			refs = append(refs, r.getMiddlewareRef(name))
+			continue
+		}
+
+		refs = append(refs, traefikapi.MiddlewareRef{Name: middleware})
+	}
+
+	return refs
+}
+
+func (r *ingressRouteUpdater) getMiddlewareRef(name string) traefikapi.MiddlewareRef {
+	return traefikapi.MiddlewareRef{Name: name, Namespace: r.namespace}
+}
+
+func getStaticContentService() traefikapi.Service {
+	return traefikapi.Service{LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+		Name: staticContentBackendName,
+		Port: intstr.FromInt32(staticContentBackendPort),
+	}}
+}
+
+func (r *ingressRouteUpdater) applyIngressRoute(ctx context.Context, route *traefikapi.IngressRoute) error {
+	_, err := util.ServerSideApply[*traefikapi.IngressRoute](ctx, r.ingressRouteInterface, route, r.eventRecorder)
+	if err != nil {
+		return fmt.Errorf("failed to upsert ingress route %s: %w", route.Name, err)
+	}
+
+	return nil
+}
+
+func (r *ingressRouteUpdater) getIngressRoute(cesService resolvedCesService, service *corev1.Service, path string, targetService traefikapi.Service, middlewares []traefikapi.MiddlewareRef) *traefikapi.IngressRoute {
+	route := &traefikapi.IngressRoute{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       "IngressRoute",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      cesService.Name,
+			Namespace: r.namespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: traefikapi.IngressRouteSpec{
+			Routes: []traefikapi.Route{{
+				Match:       getRouteMatch(cesService.CesService, path),
+				Kind:        "Rule",
+				Priority:    getRouterPriorityValue(path),
+				Services:    []traefikapi.Service{targetService},
+				Middlewares: middlewares,
+			}},
+		},
+	}
+
+	if cesService.hasHost() {
+		route.Spec.TLS = &traefikapi.TLS{SecretName: cesService.getTLSSecretName()}
+	}
+
+	return route
+}
+
+func (r *ingressRouteUpdater) getTraefikService(cesService resolvedCesService, service *corev1.Service, serversTransportName string, ownerReferences []v1.OwnerReference) *traefikapi.TraefikService {
+	return &traefikapi.TraefikService{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       traefikServiceKind,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:            cesService.Name,
+			Namespace:       r.namespace,
+			Labels:          util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: traefikapi.TraefikServiceSpec{
+			Weighted: &traefikapi.WeightedRoundRobin{
+				Services: r.getWeightedServices(cesService, service, serversTransportName),
+			},
+		},
+	}
+}
@@ -0,0 +1,766 @@
+package expose
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewIngressRouteUpdater(t *testing.T) {
+	// given
+	ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+	traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+	serversTransportInterfaceMock := newMockServersTransportInterface(t)
+	traefikInterfaceMock := newMockTraefikInterface(t)
+	traefikInterfaceMock.EXPECT().IngressRoutes(testNamespace).Return(ingressRouteInterfaceMock)
+	traefikInterfaceMock.EXPECT().TraefikServices(testNamespace).Return(traefikServiceInterfaceMock)
+	traefikInterfaceMock.EXPECT().ServersTransports(testNamespace).Return(serversTransportInterfaceMock)
+
+	// when
+	sut := NewIngressRouteUpdater(IngressUpdaterDependencies{
+		Namespace:        testNamespace,
+		TraefikInterface: traefikInterfaceMock,
+	})
+
+	// then
+	require.NotNil(t, sut)
+	assert.Equal(t, testNamespace, sut.namespace)
+	assert.Equal(t, ingressRouteInterfaceMock, sut.ingressRouteInterface)
+	assert.Equal(t, traefikServiceInterfaceMock, sut.traefikServiceInterface)
+	assert.Equal(t, serversTransportInterfaceMock, sut.serversTransportInterface)
+}
+
+func Test_ingressRouteUpdater_UpsertIngressForService(t *testing.T) {
+	getService := func(cesServices []CesService, additionalAnnotations string) *corev1.Service {
+		cesServiceString, _ := json.Marshal(cesServices)
+		service := &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   testNamespace,
+				UID:         "uid",
+				Annotations: map[string]string{CesServiceAnnotation: string(cesServiceString)},
+				Labels:      map[string]string{"dogu.name": "test"},
+			},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "testPort", Port: 55}}},
+		}
+		if additionalAnnotations != "" {
+			service.Annotations[annotation.AdditionalIngressAnnotationsAnnotation] = additionalAnnotations
+		}
+		return service
+	}
+	dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+
+	t.Run("should create ingress route with replace path middleware and traefik service", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/myLocation", Pass: "/myPass"}}, "")
+		expectedRoute := getTestIngressRoute("test", "/myLocation", service, getTestTraefikServiceRef("test"), getTestMiddlewareRef("test-replace-path"), getTestMiddlewareRef("test-test-errors"))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress route for service [%s].", "test")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, "test", mock.Anything, mock.Anything).Return("test-replace-path", nil)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := getEmptyTraefikServiceInterfaceMock(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, getTestTraefikService("test", service, 55))
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create ingress route with a managed middleware for the rewrite config", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/v2", Pass: "/v2", Rewrite: "{\"pattern\":\"v2\",\"rewrite\":\"\"}"}}, "")
+		expectedRoute := getTestIngressRoute("test", "/v2", service, getTestTraefikServiceRef("test"), getTestMiddlewareRef("test-test-rewrite"), getTestMiddlewareRef("test-test-errors"))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress route for service [%s].", "test")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, "test", mock.Anything, mock.Anything).Return("test-test-rewrite", nil)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := getEmptyTraefikServiceInterfaceMock(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, getTestTraefikService("test", service, 55))
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create ingress route with the middlewares and priority of the additional annotations", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}},
+			`{"traefik.ingress.kubernetes.io/router.middlewares":"my-namespace-auth@kubernetescrd, compress@file","traefik.ingress.kubernetes.io/router.priority":"42"}`)
+		expectedRoute := getTestIngressRoute("test", "/test", service, getTestTraefikServiceRef("test"),
+			getTestMiddlewareRef("auth"), traefikapi.MiddlewareRef{Name: "compress@file"}, getTestMiddlewareRef("test-test-errors"))
+		expectedRoute.Spec.Routes[0].Priority = 42
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress route for service [%s].", "test")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := getEmptyTraefikServiceInterfaceMock(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, getTestTraefikService("test", service, 55))
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create ingress route with the translated middlewares and servers transport of nginx annotations", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}},
+			`{"nginx.ingress.kubernetes.io/proxy-body-size":"1g","nginx.ingress.kubernetes.io/rewrite-target":"/api/$2","nginx.ingress.kubernetes.io/proxy-read-timeout":"600","nginx.ingress.kubernetes.io/server-snippet":"listen 8080;"}`)
+		expectedRoute := getTestIngressRoute("test", "/test", service, getTestTraefikServiceRef("test"),
+			getTestMiddlewareRef("test-test-rewrite"), getTestMiddlewareRef("test-test-buffering"), getTestMiddlewareRef("test-test-errors"))
+		expectedTraefikService := getTestTraefikService("test", service, 55)
+		expectedTraefikService.Spec.Weighted.Services[0].ServersTransport = "test"
+		readTimeout := intstr.FromString("600s")
+		expectedServersTransport := getTestServersTransport("test", service, &traefikapi.ForwardingTimeouts{ResponseHeaderTimeout: &readTimeout})
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Warning", "IngressAnnotationTranslation", "Ignored nginx ingress annotation of service [%s]: %s.", "test", "annotation [nginx.ingress.kubernetes.io/server-snippet] has no traefik equivalent")
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress route for service [%s].", "test")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-rewrite", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-buffering", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := getEmptyTraefikServiceInterfaceMock(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, expectedTraefikService)
+		serversTransportInterfaceMock := newMockServersTransportInterface(t)
+		expectApplyServersTransport(t, serversTransportInterfaceMock, expectedServersTransport)
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "test", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"k8s-service-discovery.cloudogu.com/ignored-nginx-annotations":"{\"test\":[\"annotation [nginx.ingress.kubernetes.io/server-snippet] has no traefik equivalent\"]}"}}}`),
+			metav1.PatchOptions{}).Return(service, nil)
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+		sut.serversTransportInterface = serversTransportInterfaceMock
+		sut.serviceInterface = serviceInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create ingress route with the method and header matchers of the ces service", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test", Methods: []string{"GET", "POST"}, Headers: map[string]string{"X-Api-Version": "2"}}}, "")
+		expectedRoute := getTestIngressRoute("test", "/test", service, getTestTraefikServiceRef("test"), getTestMiddlewareRef("test-test-errors"))
+		expectedRoute.Spec.Routes[0].Match = "Host(`ces.example.com`) && PathPrefix(`/test`) && (Method(`GET`) || Method(`POST`)) && Header(`X-Api-Version`, `2`)"
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress route for service [%s].", "test")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := getEmptyTraefikServiceInterfaceMock(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, getTestTraefikService("test", service, 55))
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail for invalid method matchers of the ces service", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test", Methods: []string{"get"}}}, "")
+
+		sut := getTestIngressRouteUpdater(t, false)
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid route matchers of ces service [test]: invalid method [get]")
+	})
+	t.Run("should create traefik service with the backend scheme and timeouts of the ces service", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test", BackendScheme: "h2c", IdleTimeout: "90s", MaxBodySize: "0"}},
+			`{"nginx.ingress.kubernetes.io/proxy-body-size":"1g","nginx.ingress.kubernetes.io/proxy-read-timeout":"600"}`)
+		expectedRoute := getTestIngressRoute("test", "/test", service, getTestTraefikServiceRef("test"), getTestMiddlewareRef("test-test-errors"))
+		expectedTraefikService := getTestTraefikService("test", service, 55)
+		expectedTraefikService.Spec.Weighted.Services[0].Scheme = "h2c"
+		expectedTraefikService.Spec.Weighted.Services[0].ServersTransport = "test"
+		readTimeout := intstr.FromString("600s")
+		idleTimeout := intstr.FromString("90s")
+		expectedServersTransport := getTestServersTransport("test", service, &traefikapi.ForwardingTimeouts{ResponseHeaderTimeout: &readTimeout, IdleConnTimeout: &idleTimeout})
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress route for service [%s].", "test")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := getEmptyTraefikServiceInterfaceMock(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, expectedTraefikService)
+		serversTransportInterfaceMock := newMockServersTransportInterface(t)
+		expectApplyServersTransport(t, serversTransportInterfaceMock, expectedServersTransport)
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+		sut.serversTransportInterface = serversTransportInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should route through the mirroring traefik service of the service annotation", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}}, "")
+		service.Annotations[MirroringAnnotation] = `{"target":"test-shadow","percent":10}`
+		expectedRoute := getTestIngressRoute("test", "/test", service, getTestTraefikServiceRef("test-mirroring"), getTestMiddlewareRef("test-test-errors"))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress route for service [%s].", "test")
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, getTestTraefikService("test", service, 55))
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, getTestMirroringTraefikService("test", service, traefikapi.MirrorService{
+			LoadBalancerSpec: traefikapi.LoadBalancerSpec{Name: "test-shadow", Namespace: testNamespace, Port: intstr.FromInt32(55)},
+			Percent:          10,
+		}))
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to delete unneeded servers transport", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}}, "")
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		serversTransportInterfaceMock := newMockServersTransportInterface(t)
+		serversTransportInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(assert.AnError)
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.serversTransportInterface = serversTransportInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete servers transport test")
+	})
+	t.Run("should create maintenance ingress route", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}}, "")
+		expectedRoute := getTestIngressRoute("test", "/test", service, getStaticContentService(), getTestMiddlewareRef("maintenance-mode"))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Ingress route for service [%s] has been updated to maintenance mode.", "test")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+
+		sut := getTestIngressRouteUpdater(t, true)
+		sut.eventRecorder = recorderMock
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should create dogu is starting ingress route", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}}, "")
+		expectedRoute := getTestIngressRoute("test", "/test", service, getStaticContentService(), getTestMiddlewareRef("dogu-starting"))
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(false, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should delete stale ingress route and replaced ingress object of the service", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}}, "")
+		expectedRoute := getTestIngressRoute("test", "/test", service, getStaticContentService(), getTestMiddlewareRef("dogu-starting"))
+		staleRoute := getTestIngressRoute("old", "/old", service, getTestTraefikServiceRef("old"))
+		replacedIngress := networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+			Name:            "test",
+			OwnerReferences: []metav1.OwnerReference{{Name: "test", UID: "uid"}},
+		}}
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale %s [%s] as the ces service no longer exists.", "ingress route", "old")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(false, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{Items: []traefikapi.IngressRoute{*staleRoute}}, nil)
+		ingressRouteInterfaceMock.EXPECT().Delete(testCtx, "old", metav1.DeleteOptions{}).Return(nil)
+		expectApplyIngressRoute(t, ingressRouteInterfaceMock, expectedRoute)
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		traefikServiceInterfaceMock.EXPECT().Delete(testCtx, "old-mirroring", metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "old-mirroring"))
+		traefikServiceInterfaceMock.EXPECT().Delete(testCtx, "old", metav1.DeleteOptions{}).Return(nil)
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&networking.IngressList{Items: []networking.Ingress{replacedIngress}}, nil)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(nil)
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.eventRecorder = recorderMock
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+		sut.ingressInterface = ingressInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should delete the ingress routes of a service without ces services annotation", func(t *testing.T) {
+		// given
+		service := getService(nil, "")
+		delete(service.Annotations, CesServiceAnnotation)
+		staleRoute := getTestIngressRoute("test", "/test", service, getTestTraefikServiceRef("test"))
+
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale %s [%s] as the ces service no longer exists.", "ingress route", "test")
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, []CesService(nil)).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{Items: []traefikapi.IngressRoute{*staleRoute}}, nil)
+		ingressRouteInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(nil)
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		traefikServiceInterfaceMock.EXPECT().Delete(testCtx, "test-mirroring", metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "test-mirroring"))
+		traefikServiceInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(nil)
+
+		sut := &ingressRouteUpdater{
+			ingressUpdater: &ingressUpdater{
+				namespace:                 testNamespace,
+				maintenanceAdapter:        getMaintenanceAdapterMock(t, false),
+				eventRecorder:             recorderMock,
+				doguInterface:             doguInterfaceMock,
+				middlewareManager:         middlewareManagerMock,
+				ingressInterface:          getEmptyIngressInterfaceMock(t),
+				serversTransportInterface: getEmptyServersTransportInterfaceMock(t),
+			},
+			ingressRouteInterface:   ingressRouteInterfaceMock,
+			traefikServiceInterface: traefikServiceInterfaceMock,
+		}
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to list ingress routes", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}}, "")
+
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(nil, assert.AnError)
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list ingress routes")
+	})
+	t.Run("should fail to apply traefik service", func(t *testing.T) {
+		// given
+		service := getService([]CesService{{Name: "test", Port: 55, Location: "/test", Pass: "/test"}}, "")
+
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
+		ingressRouteInterfaceMock := newMockIngressRouteInterface(t)
+		ingressRouteInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&traefikapi.IngressRouteList{}, nil)
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		traefikServiceInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := getTestIngressRouteUpdater(t, false)
+		sut.deploymentReadyChecker = deploymentReadyChecker
+		sut.doguInterface = doguInterfaceMock
+		sut.middlewareManager = middlewareManagerMock
+		sut.ingressRouteInterface = ingressRouteInterfaceMock
+		sut.traefikServiceInterface = traefikServiceInterfaceMock
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create ingress route for ces service")
+		assert.ErrorContains(t, err, "failed to upsert traefik service test")
+	})
+}
+
+func Test_newRouteOfIngressRoute(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nexus", UID: "uid"}}
+
+	t.Run("should create route of ingress route", func(t *testing.T) {
+		// given
+		traefikRoute := getTestIngressRoute("nexus-docker", "/v2", service, getTestTraefikServiceRef("nexus-docker"))
+		traefikRoute.Spec.Routes[0].Match = "Host(`registry.ces.example.com`) && PathPrefix(`/v2`)"
+
+		// when
+		actual, ok := newRouteOfIngressRoute(*traefikRoute)
+
+		// then
+		require.True(t, ok)
+		assert.Equal(t, ingressRoute{ingressName: "nexus-docker", serviceName: "nexus", host: "registry.ces.example.com", path: "/v2"}, actual)
+	})
+	t.Run("should ignore ingress route without owner", func(t *testing.T) {
+		// given
+		traefikRoute := getTestIngressRoute("nexus", "/nexus", service, getTestTraefikServiceRef("nexus"))
+		traefikRoute.OwnerReferences = nil
+
+		// when
+		_, ok := newRouteOfIngressRoute(*traefikRoute)
+
+		// then
+		assert.False(t, ok)
+	})
+	t.Run("should ignore ingress route without path prefix matcher", func(t *testing.T) {
+		// given
+		traefikRoute := getTestIngressRoute("nexus", "/nexus", service, getTestTraefikServiceRef("nexus"))
+		traefikRoute.Spec.Routes[0].Match = "Host(`ces.example.com`)"
+
+		// when
+		_, ok := newRouteOfIngressRoute(*traefikRoute)
+
+		// then
+		assert.False(t, ok)
+	})
+}
+
+func getTestIngressRouteUpdater(t *testing.T, maintenanceMode bool) *ingressRouteUpdater {
+	return &ingressRouteUpdater{
+		ingressUpdater: &ingressUpdater{
+			namespace:                 testNamespace,
+			maintenanceAdapter:        getMaintenanceAdapterMock(t, maintenanceMode),
+			doguConfigRepository:      getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository:    getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
+			ingressInterface:          getEmptyIngressInterfaceMock(t),
+			serversTransportInterface: getEmptyServersTransportInterfaceMock(t),
+		},
+	}
+}
+
+func getEmptyServersTransportInterfaceMock(t *testing.T) *mockServersTransportInterface {
+	serversTransportInterfaceMock := newMockServersTransportInterface(t)
+	serversTransportInterfaceMock.EXPECT().Delete(testCtx, mock.Anything, metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "")).Maybe()
+	return serversTransportInterfaceMock
+}
+
+func getEmptyTraefikServiceInterfaceMock(t *testing.T) *mockTraefikServiceInterface {
+	traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+	traefikServiceInterfaceMock.EXPECT().Delete(testCtx, mock.Anything, metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "")).Maybe()
+	return traefikServiceInterfaceMock
+}
+
+func getEmptyIngressInterfaceMock(t *testing.T) *mockIngressInterface {
+	ingressInterfaceMock := newMockIngressInterface(t)
+	ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&networking.IngressList{}, nil).Maybe()
+	return ingressInterfaceMock
+}
+
+func getTestTraefikServiceRef(name string) traefikapi.Service {
+	return traefikapi.Service{LoadBalancerSpec: traefikapi.LoadBalancerSpec{Name: name, Kind: "TraefikService", Namespace: testNamespace}}
+}
+
+func getTestMiddlewareRef(name string) traefikapi.MiddlewareRef {
+	return traefikapi.MiddlewareRef{Name: name, Namespace: testNamespace}
+}
+
+func getTestIngressRoute(routeName string, path string, service *corev1.Service, targetService traefikapi.Service, middlewares ...traefikapi.MiddlewareRef) *traefikapi.IngressRoute {
+	return &traefikapi.IngressRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "traefik.io/v1alpha1",
+			Kind:       "IngressRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeName,
+			Namespace: testNamespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: traefikapi.IngressRouteSpec{
+			Routes: []traefikapi.Route{{
+				Match:       "Host(`" + testFQDN + "`) && PathPrefix(`" + path + "`)",
+				Kind:        "Rule",
+				Priority:    getRouterPriorityValue(path),
+				Services:    []traefikapi.Service{targetService},
+				Middlewares: middlewares,
+			}},
+			TLS: &traefikapi.TLS{SecretName: "ecosystem-certificate"},
+		},
+	}
+}
+
+func getTestTraefikService(name string, service *corev1.Service, targetPort int32) *traefikapi.TraefikService {
+	return &traefikapi.TraefikService{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "traefik.io/v1alpha1",
+			Kind:       "TraefikService",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: traefikapi.TraefikServiceSpec{
+			Weighted: &traefikapi.WeightedRoundRobin{
+				Services: []traefikapi.Service{{LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+					Name:      service.Name,
+					Namespace: testNamespace,
+					Port:      intstr.FromInt32(targetPort),
+				}}},
+			},
+		},
+	}
+}
+
+func expectApplyIngressRoute(t *testing.T, ingressRouteInterfaceMock *mockIngressRouteInterface, expectedRoute *traefikapi.IngressRoute) {
+	ingressRouteInterfaceMock.EXPECT().Get(testCtx, expectedRoute.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedRoute.Name))
+	ingressRouteInterfaceMock.EXPECT().Patch(testCtx, expectedRoute.Name, types.ApplyPatchType, mock.Anything, metav1.PatchOptions{FieldManager: "k8s-service-discovery"}).
+		Return(nil, nil).
+		Run(func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+			appliedRoute := &traefikapi.IngressRoute{}
+			require.NoError(t, json.Unmarshal(data, appliedRoute))
+			assert.Equal(t, expectedRoute, appliedRoute)
+		})
+}
+
+func expectApplyTraefikService(t *testing.T, traefikServiceInterfaceMock *mockTraefikServiceInterface, expectedService *traefikapi.TraefikService) {
+	traefikServiceInterfaceMock.EXPECT().Get(testCtx, expectedService.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedService.Name))
+	traefikServiceInterfaceMock.EXPECT().Patch(testCtx, expectedService.Name, types.ApplyPatchType, mock.Anything, metav1.PatchOptions{FieldManager: "k8s-service-discovery"}).
+		Return(nil, nil).
+		Run(func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+			appliedService := &traefikapi.TraefikService{}
+			require.NoError(t, json.Unmarshal(data, appliedService))
+			assert.Equal(t, expectedService, appliedService)
+		})
+}
+
+func getTestServersTransport(name string, service *corev1.Service, forwardingTimeouts *traefikapi.ForwardingTimeouts) *traefikapi.ServersTransport {
+	return &traefikapi.ServersTransport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "traefik.io/v1alpha1",
+			Kind:       "ServersTransport",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: traefikapi.ServersTransportSpec{ForwardingTimeouts: forwardingTimeouts},
+	}
+}
+
+func expectApplyServersTransport(t *testing.T, serversTransportInterfaceMock *mockServersTransportInterface, expectedTransport *traefikapi.ServersTransport) {
+	serversTransportInterfaceMock.EXPECT().Get(testCtx, expectedTransport.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedTransport.Name))
+	serversTransportInterfaceMock.EXPECT().Patch(testCtx, expectedTransport.Name, types.ApplyPatchType, mock.Anything, metav1.PatchOptions{FieldManager: "k8s-service-discovery"}).
+		Return(nil, nil).
+		Run(func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+			appliedTransport := &traefikapi.ServersTransport{}
+			require.NoError(t, json.Unmarshal(data, appliedTransport))
+			assert.Equal(t, expectedTransport, appliedTransport)
+		})
+}
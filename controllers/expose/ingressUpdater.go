@@ -4,24 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"path"
+	"slices"
 	"strings"
 
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
-	"github.com/cloudogu/retry-lib/retry"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 const (
-	staticContentBackendName           = "k8s-ces-assets-service"
-	staticContentBackendPort           = 80
-	staticContentBackendRewrite        = "maintenance-mode@kubernetescrd"
-	staticContentDoguIsStartingRewrite = "dogu-starting@kubernetescrd"
+	staticContentBackendName = "k8s-ces-assets-service"
+	staticContentBackendPort = 80
 )
 
 const (
@@ -31,6 +32,10 @@ const (
 
 const (
 	ingressCreationEventReason = "IngressCreation"
+	ingressDeletionEventReason = "IngressDeletion"
+	// ignoredRoutingConfigEventReason is the reason of the events about routing config which the routing mode can't
+	// honor.
+	ignoredRoutingConfigEventReason = "IgnoredRoutingConfig"
 )
 const failedIngressUpdateErrMsg = "failed to update ingress object: %w"
 
@@ -47,12 +52,61 @@ type CesService struct {
 	// Rewrite that should be applied to the ingress configuration.
 	// Is a json-marshalled `serviceRewrite`. Useful if Dogus do not support sub-paths.
 	Rewrite string `json:"rewrite,omitempty"`
+	// Host of the ces service, e.g., `nexus` or `nexus.example.com`. If set, the ces service is served at the root path
+	// of this host instead of its location on the fqdn of the ecosystem. Hosts without a dot are subdomains of the fqdn.
+	Host string `json:"host,omitempty"`
+	// TLSSecretName of the tls secret used for the host. Defaults to the ecosystem certificate.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// ForwardAuth protects the ces service by the forward auth middleware, e.g., if the dogu has no own login. The
+	// auth endpoint is defined by the global config.
+	ForwardAuth bool `json:"forwardAuth,omitempty"`
+	// ErrorPageStatus are the comma separated status ranges of the dogu answered by the error pages of the static
+	// content backend, e.g., `500,502-504`. Defaults to `502-504`, `none` disables the error pages.
+	ErrorPageStatus string `json:"errorPageStatus,omitempty"`
+	// BackendScheme of the requests to the service, e.g., `https` or `h2c` for http/2 and grpc without tls. Defaults to
+	// `http`.
+	BackendScheme string `json:"backendScheme,omitempty"`
+	// ResponseTimeout is the time to wait for the response headers of the service, e.g., `10m`.
+	ResponseTimeout string `json:"responseTimeout,omitempty"`
+	// IdleTimeout is the time an idle connection to the service is kept open, e.g., `90s`.
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+	// MaxBodySize of the requests to the service in the nginx format, e.g., `1g`. Zero disables the limit.
+	MaxBodySize string `json:"maxBodySize,omitempty"`
+	// StickyCookie pins the clients of the ces service to one pod of a dogu with multiple replicas.
+	StickyCookie *StickyCookie `json:"stickyCookie,omitempty"`
+	// BalancingStrategy of the requests to the pods of the service, e.g., `p2c`. Defaults to `wrr`.
+	BalancingStrategy string `json:"balancingStrategy,omitempty"`
+	// Methods restrict the routes of the ces service to the given http methods, e.g., `GET`. Empty matches all methods.
+	Methods []string `json:"methods,omitempty"`
+	// Headers restrict the routes of the ces service to the requests with the given header values.
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
 func (cs CesService) hasRewriteConfig() bool {
 	return cs.Rewrite != ""
 }
 
+// needsReplacePathMiddleware returns true if the ces service requires a managed middleware which rewrites the
+// external location or the pattern of its rewrite config to the target path inside the service's pod.
+func (cs CesService) needsReplacePathMiddleware() bool {
+	return cs.hasRewriteConfig() || cs.Pass != cs.Location
+}
+
+// getReplacePath returns the external path prefix of the ces service and the target path its requests are rewritten
+// to. The rewrite config takes precedence over the location and pass. The prefix of the root path is empty.
+func (cs CesService) getReplacePath() (string, string, error) {
+	if !cs.hasRewriteConfig() {
+		return strings.TrimRight(cs.Location, "/"), cs.Pass, nil
+	}
+
+	rewriteCfg, err := cs.getRewriteConfig()
+	if err != nil {
+		return "", "", err
+	}
+
+	return rewriteCfg.getPathPrefix(), rewriteCfg.getTargetPath(), nil
+}
+
 func (cs CesService) getRewriteConfig() (*serviceRewrite, error) {
 	if !cs.hasRewriteConfig() {
 		return nil, fmt.Errorf("cesService has no rewrite config")
@@ -72,8 +126,14 @@ type serviceRewrite struct {
 	Rewrite string `json:"rewrite"`
 }
 
-func (sr *serviceRewrite) generateConfig() string {
-	return fmt.Sprintf("rewrite ^/%s(/|$)(.*) %s/$2 break;", sr.Pattern, sr.Rewrite)
+// getPathPrefix returns the path prefix matched by the pattern of the rewrite, e.g., `/v2` for the pattern `v2`.
+func (sr *serviceRewrite) getPathPrefix() string {
+	return strings.TrimRight("/"+strings.Trim(sr.Pattern, "/"), "/")
+}
+
+// getTargetPath returns the path the matched requests are rewritten to, e.g., `/` for an empty rewrite.
+func (sr *serviceRewrite) getTargetPath() string {
+	return path.Join("/", sr.Rewrite)
 }
 
 type ingressUpdater struct {
@@ -81,6 +141,8 @@ type ingressUpdater struct {
 	namespace string
 	// IngressClassName defines the ingress class for the ces services.
 	ingressClassName string
+	// pathType of the paths of the ingress objects. Defaults to prefix paths.
+	pathType networking.PathType
 	// deploymentReadyChecker checks whether dogu are ready (healthy).
 	deploymentReadyChecker DeploymentReadyChecker
 	eventRecorder          eventRecorder
@@ -89,6 +151,13 @@ type ingressUpdater struct {
 	doguInterface          doguInterface
 	middlewareManager      middlewareManager
 	maintenanceAdapter     maintenanceAdapter
+	globalConfigRepository GlobalConfigRepository
+	doguConfigRepository   doguConfigRepository
+	// serviceInterface patches the annotations of the services and manages the services of the canaries.
+	serviceInterface serviceInterface
+	// serversTransportInterface manages the servers transports with the forwarding timeouts of the backends. Nil
+	// without traefik.
+	serversTransportInterface serversTransportInterface
 }
 
 type IngressUpdaterDependencies struct {
@@ -97,17 +166,36 @@ type IngressUpdaterDependencies struct {
 	DoguInterface          doguInterface
 	Namespace              string
 	IngressClassName       string
+	// PathType of the paths of the ingress objects, e.g., `ImplementationSpecific` for ingress controllers which
+	// validate regex paths of the type `Prefix`. Defaults to `Prefix`.
+	PathType               networking.PathType
 	Recorder               eventRecorder
 	Controller             ingressController
 	MiddlewareManager      middlewareManager
 	MaintenanceAdapter     maintenanceAdapter
+	GlobalConfigRepository GlobalConfigRepository
+	DoguConfigRepository   doguConfigRepository
+	// HTTPRouteInterface is used instead of the IngressInterface if the routes are exposed by the gateway api.
+	HTTPRouteInterface httpRouteInterface
+	// GatewayName is the name of the gateway the http routes are attached to.
+	GatewayName string
+	// TraefikInterface is used to create ingress routes and traefik services instead of ingress objects.
+	TraefikInterface traefikInterface
+	// ServiceInterface is used to set the backend annotations of the services of ingress objects and to create the
+	// services of the dogu versions of a canary.
+	ServiceInterface serviceInterface
+	// PodInterface is used to check the readiness of the dogu versions of a canary.
+	PodInterface podInterface
+	// DeploymentInterface is used to pause the rollout of a dogu during its canary.
+	DeploymentInterface deploymentInterface
 }
 
 // NewIngressUpdater creates a new instance responsible for updating ingress objects.
 func NewIngressUpdater(deps IngressUpdaterDependencies) *ingressUpdater {
-	return &ingressUpdater{
+	updater := &ingressUpdater{
 		namespace:              deps.Namespace,
 		ingressClassName:       deps.IngressClassName,
+		pathType:               deps.PathType,
 		deploymentReadyChecker: deps.DeploymentReadyChecker,
 		eventRecorder:          deps.Recorder,
 		controller:             deps.Controller,
@@ -115,7 +203,16 @@ func NewIngressUpdater(deps IngressUpdaterDependencies) *ingressUpdater {
 		doguInterface:          deps.DoguInterface,
 		middlewareManager:      deps.MiddlewareManager,
 		maintenanceAdapter:     deps.MaintenanceAdapter,
+		globalConfigRepository: deps.GlobalConfigRepository,
+		doguConfigRepository:   deps.DoguConfigRepository,
+		serviceInterface:       deps.ServiceInterface,
+	}
+
+	if deps.TraefikInterface != nil {
+		updater.serversTransportInterface = deps.TraefikInterface.ServersTransports(deps.Namespace)
 	}
+
+	return updater
 }
 
 // UpsertIngressForService creates or updates the ingress object of the given service.
@@ -125,14 +222,20 @@ func (i *ingressUpdater) UpsertIngressForService(ctx context.Context, service *c
 		return err
 	}
 
-	cesServices, ok, err := i.getCesServices(service)
+	cesServices, err := i.getRoutedCesServices(ctx, service)
 	if err != nil {
-		return fmt.Errorf("failed to get ces services: %w", err)
+		return err
 	}
 
-	if !ok {
-		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("service [%s] has no ports or ces services -> skipping ingress creation", service.Name))
-		return nil
+	selector := labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).String()
+	ingressList, err := i.ingressInterface.List(ctx, v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list ingress objects: %w", err)
+	}
+
+	cesServices, err = i.resolveIngressConflicts(ctx, service, cesServices, ingressList.Items)
+	if err != nil {
+		return fmt.Errorf("failed to resolve ingress conflicts of service [%s]: %w", service.Name, err)
 	}
 
 	for _, cesService := range cesServices {
@@ -142,9 +245,125 @@ func (i *ingressUpdater) UpsertIngressForService(ctx context.Context, service *c
 		}
 	}
 
+	err = i.deleteStaleIngresses(ctx, service, cesServices, ingressList.Items)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale ingress objects of service [%s]: %w", service.Name, err)
+	}
+
+	if !i.controller.UsesMiddlewares() {
+		return nil
+	}
+
+	err = i.middlewareManager.deleteOrphanedMiddlewares(ctx, service, cesServices)
+	if err != nil {
+		return fmt.Errorf("failed to delete orphaned middlewares of service [%s]: %w", service.Name, err)
+	}
+
+	return i.upsertBackendOfService(ctx, service, cesServices)
+}
+
+// UpsertGlobalMiddlewares creates or updates the global middlewares of the global config, which are part of the
+// ingress objects of all services. Controllers without middlewares require no global middlewares.
+func (i *ingressUpdater) UpsertGlobalMiddlewares(ctx context.Context) error {
+	if !i.controller.UsesMiddlewares() {
+		return nil
+	}
+
+	globalConfig, err := i.globalConfigRepository.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get global config: %w", err)
+	}
+
+	return i.middlewareManager.UpsertGlobalMiddlewares(ctx, globalConfig)
+}
+
+// deleteStaleIngresses removes all of the given ingress objects owned by the given service which do not belong to one
+// of the desired ces services anymore, e.g., because a ces service was removed or renamed during a dogu upgrade.
+func (i *ingressUpdater) deleteStaleIngresses(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService, ingresses []networking.Ingress) error {
+	routes := make([]v1.Object, 0, len(ingresses))
+	for index := range ingresses {
+		routes = append(routes, &ingresses[index])
+	}
+
+	return i.deleteStaleRoutes(ctx, service, cesServices, routes, "ingress", i.deleteIngress)
+}
+
+// deleteStaleRoutes removes all of the given routing objects of the given kind owned by the given service which do
+// not belong to one of the desired ces services anymore.
+func (i *ingressUpdater) deleteStaleRoutes(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService, routes []v1.Object, kind string, deleteRoute func(ctx context.Context, name string) error) error {
+	desiredRouteNames := make(map[string]struct{}, len(cesServices))
+	for _, cesService := range cesServices {
+		desiredRouteNames[cesService.Name] = struct{}{}
+	}
+
+	var (
+		dogu *doguv2.Dogu
+		err  error
+	)
+	for _, route := range routes {
+		if !isOwnedByService(route.GetOwnerReferences(), service) {
+			continue
+		}
+
+		if _, desired := desiredRouteNames[route.GetName()]; desired {
+			continue
+		}
+
+		if dogu == nil {
+			dogu, err = i.doguInterface.Get(ctx, service.Name, v1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get dogu for service [%s]: %w", service.Name, err)
+			}
+		}
+
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("ces service [%s] no longer exists for service [%s] -> delete %s object", route.GetName(), service.Name, kind))
+		err = deleteRoute(ctx, route.GetName())
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %s: %w", kind, route.GetName(), err)
+		}
+
+		i.eventRecorder.Eventf(dogu, corev1.EventTypeNormal, ingressDeletionEventReason, "Deleted stale %s [%s] as the ces service no longer exists.", kind, route.GetName())
+	}
+
 	return nil
 }
 
+func isOwnedByService(ownerReferences []v1.OwnerReference, service *corev1.Service) bool {
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.Name == service.Name && ownerReference.UID == service.UID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getRoutedCesServices returns the ces services of the given service with their resolved routing. It returns no ces
+// services if the service exposes none, so the routing objects of formerly exposed ces services are still pruned.
+func (i *ingressUpdater) getRoutedCesServices(ctx context.Context, service *corev1.Service) ([]resolvedCesService, error) {
+	cesServices, ok, err := i.getCesServices(service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ces services: %w", err)
+	}
+
+	if !ok {
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("service [%s] has no ports or ces services -> pruning its routing objects", service.Name))
+		return []resolvedCesService{}, nil
+	}
+
+	routingConfig, err := i.getGlobalRoutingConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global routing config: %w", err)
+	}
+
+	resolvedServices, err := i.resolveCesServices(ctx, service, cesServices, routingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve routing of service [%s]: %w", service.Name, err)
+	}
+
+	return resolvedServices, nil
+}
+
 func (i *ingressUpdater) getCesServices(service *corev1.Service) ([]CesService, bool, error) {
 	if len(service.Spec.Ports) <= 0 {
 		return []CesService{}, false, nil
@@ -164,7 +383,7 @@ func (i *ingressUpdater) getCesServices(service *corev1.Service) ([]CesService,
 	return cesServices, true, nil
 }
 
-func (i *ingressUpdater) upsertIngressForCesService(ctx context.Context, cesService CesService, service *corev1.Service, isMaintenanceMode bool) error {
+func (i *ingressUpdater) upsertIngressForCesService(ctx context.Context, cesService resolvedCesService, service *corev1.Service, isMaintenanceMode bool) error {
 	dogu, err := i.doguInterface.Get(ctx, service.Name, v1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to get dogu for service [%s]: %w", service.Name, err)
@@ -185,7 +404,7 @@ func (i *ingressUpdater) upsertIngressForCesService(ctx context.Context, cesServ
 		}
 	}
 
-	err = i.upsertDoguIngressObject(ctx, cesService, service)
+	err = i.upsertDoguIngressObject(ctx, cesService, service, dogu)
 	if err != nil {
 		return err
 	}
@@ -207,12 +426,11 @@ func getAdditionalIngressAnnotations(doguService *corev1.Service) (doguv2.Ingres
 	return annotations, nil
 }
 
-func (i *ingressUpdater) upsertMaintenanceModeIngressObject(ctx context.Context, cesService CesService, service *corev1.Service, dogu *doguv2.Dogu) error {
+func (i *ingressUpdater) upsertMaintenanceModeIngressObject(ctx context.Context, cesService resolvedCesService, service *corev1.Service, dogu *doguv2.Dogu) error {
 	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("system is in maintenance mode -> create maintenance ingress object for service [%s]", service.GetName()))
-	middlewareName := fmt.Sprintf("%s-%s", i.namespace, staticContentBackendRewrite)
-	annotations := map[string]string{i.controller.GetRewriteAnnotationKey(): middlewareName}
+	annotations := i.addGlobalMiddlewares(cesService, i.controller.GetMaintenanceModeAnnotations(i.namespace))
 
-	err := i.upsertIngressObject(ctx, cesService.Name, service, cesService.Location, staticContentBackendName, staticContentBackendPort, annotations)
+	err := i.upsertIngressObject(ctx, cesService, service, cesService.Location, staticContentBackendName, staticContentBackendPort, annotations)
 	if err != nil {
 		return fmt.Errorf(failedIngressUpdateErrMsg, err)
 	}
@@ -221,12 +439,11 @@ func (i *ingressUpdater) upsertMaintenanceModeIngressObject(ctx context.Context,
 	return nil
 }
 
-func (i *ingressUpdater) upsertDoguIsStartingIngressObject(ctx context.Context, cesService CesService, service *corev1.Service) error {
+func (i *ingressUpdater) upsertDoguIsStartingIngressObject(ctx context.Context, cesService resolvedCesService, service *corev1.Service) error {
 	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("dogu is still starting -> create dogu is starting ingress object for service [%s]", service.GetName()))
-	middlewareName := fmt.Sprintf("%s-%s", i.namespace, staticContentDoguIsStartingRewrite)
-	annotations := map[string]string{i.controller.GetRewriteAnnotationKey(): middlewareName}
+	annotations := i.addGlobalMiddlewares(cesService, i.controller.GetDoguStartingAnnotations(i.namespace))
 
-	err := i.upsertIngressObject(ctx, cesService.Name, service, cesService.Location, staticContentBackendName, staticContentBackendPort, annotations)
+	err := i.upsertIngressObject(ctx, cesService, service, cesService.Location, staticContentBackendName, staticContentBackendPort, annotations)
 	if err != nil {
 		return fmt.Errorf(failedIngressUpdateErrMsg, err)
 	}
@@ -234,9 +451,30 @@ func (i *ingressUpdater) upsertDoguIsStartingIngressObject(ctx context.Context,
 	return nil
 }
 
-func (i *ingressUpdater) upsertDoguIngressObject(ctx context.Context, cesService CesService, service *corev1.Service) error {
+// getIngressUnsupportedFeatures describes the routing config of the ces service which only the routing mode
+// ingressroute honors.
+func (cs resolvedCesService) getIngressUnsupportedFeatures() []string {
+	var unsupported []string
+	if cs.hasRouteMatchers() {
+		unsupported = append(unsupported, "the method and header matchers")
+	}
+	if cs.backend.strategy != "" {
+		unsupported = append(unsupported, "the balancing strategy")
+	}
+	if cs.mirroring.isEnabled() {
+		unsupported = append(unsupported, "the mirroring")
+	}
+
+	return unsupported
+}
+
+func (i *ingressUpdater) upsertDoguIngressObject(ctx context.Context, cesService resolvedCesService, service *corev1.Service, dogu *doguv2.Dogu) error {
 	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("dogu is ready -> update ces service ingress object for service [%s]", service.GetName()))
 
+	if ignored := cesService.getIngressUnsupportedFeatures(); len(ignored) > 0 {
+		i.eventRecorder.Eventf(dogu, corev1.EventTypeWarning, ignoredRoutingConfigEventReason, "Ignored routing config of ces service [%s] without ingress object equivalent: %s.", cesService.Name, strings.Join(ignored, "; "))
+	}
+
 	ingressPath := cesService.Location
 	annotations := map[string]string{}
 
@@ -247,37 +485,87 @@ func (i *ingressUpdater) upsertDoguIngressObject(ctx context.Context, cesService
 		UID:        service.UID,
 	}}
 
-	if cesService.hasRewriteConfig() {
-		// the service has rewrite-config, we need to add it
-		rewriteCfg, err := cesService.getRewriteConfig()
+	additionalAnnotations, err := getAdditionalIngressAnnotations(service)
+	if err != nil {
+		return err
+	}
+
+	var translation nginxTranslation
+	var translatedMiddlewares []string
+	var serviceMiddlewares serviceMiddlewareRefs
+	if i.controller.UsesMiddlewares() {
+		translation, additionalAnnotations, err = i.translateAdditionalAnnotations(ctx, cesService, service, dogu, additionalAnnotations)
 		if err != nil {
-			return fmt.Errorf("error getting rewrite-config from ces-service: %w", err)
+			return err
 		}
 
-		annotations["traefik.ingress.kubernetes.io/router.middlewares"] = fmt.Sprintf("%s-%s@kubernetescrd", i.namespace, rewriteCfg.Rewrite)
-		ingressPath = rewriteCfg.Pattern
-	} else if cesService.Pass != cesService.Location {
-		// Create a dynamic middleware for the path rewrite
-		middlewareName, err := i.middlewareManager.createOrUpdateReplacePathMiddleware(ctx, service.Name, cesService, ownerReferences)
+		translatedMiddlewares, err = i.createTranslatedMiddlewares(ctx, cesService, service, translation, ownerReferences)
 		if err != nil {
-			return fmt.Errorf("failed to create/update middleware: %w", err)
+			return err
 		}
 
-		// Reference the created middleware
-		annotations["traefik.ingress.kubernetes.io/router.middlewares"] = fmt.Sprintf("%s-%s@kubernetescrd", i.namespace, middlewareName)
-		ingressPath = fmt.Sprintf("%s(/|$)(.*)", strings.TrimRight(cesService.Location, "/"))
+		serviceMiddlewares, err = i.createServiceMiddlewares(ctx, cesService, service, ownerReferences)
+		if err != nil {
+			return err
+		}
 	}
 
-	// add other additional annotations (can possibly overwrite the rewrite annotations)
-	additionalAnnotations, err := getAdditionalIngressAnnotations(service)
-	if err != nil {
-		return err
+	if cesService.needsReplacePathMiddleware() || translation.hasRewrite() {
+		pathPrefix, targetPath, err := cesService.getReplacePath()
+		if err != nil {
+			return fmt.Errorf("error getting rewrite-config from ces-service: %w", err)
+		}
+
+		middlewareName := ""
+		if translation.hasRewrite() {
+			// the translated rewrite target of the dogu replaces the managed path rewrite
+			middlewareName = getTranslatedMiddlewareName(service.Name, cesService, rewriteMiddlewareSuffix)
+			translatedMiddlewares = slices.DeleteFunc(translatedMiddlewares, func(name string) bool {
+				return name == middlewareName
+			})
+		} else if i.controller.UsesMiddlewares() {
+			// Create a dynamic middleware for the path rewrite
+			middlewareName, err = i.middlewareManager.createOrUpdateReplacePathMiddleware(ctx, service.Name, cesService, ownerReferences)
+			if err != nil {
+				return fmt.Errorf("failed to create/update middleware: %w", err)
+			}
+		}
+
+		ingressPath = pathPrefix + "(/|$)(.*)"
+		remainingPath := "$2"
+		if pathPrefix == "" {
+			ingressPath = rootPath
+			if !i.controller.UsesMiddlewares() {
+				// without middlewares, the controller rewrites the regex path of the ingress
+				ingressPath = "/(.*)"
+				remainingPath = "$1"
+			}
+		}
+
+		// Reference the created middleware or rewrite to the target path of the ces service
+		maps.Copy(annotations, i.controller.GetPathRewriteAnnotations(i.namespace, middlewareName, path.Join(targetPath, remainingPath)))
 	}
+
+	if i.controller.UsesMiddlewares() {
+		rewriteMiddlewares := strings.Split(annotations[ingressRouterMiddlewaresAnnotation], ",")
+		for _, name := range translatedMiddlewares {
+			rewriteMiddlewares = append(rewriteMiddlewares, getCRDMiddlewareRef(i.namespace, name))
+		}
+
+		chain := i.getMiddlewareChain(cesService, serviceMiddlewares, rewriteMiddlewares, additionalAnnotations[ingressRouterMiddlewaresAnnotation])
+		delete(additionalAnnotations, ingressRouterMiddlewaresAnnotation)
+		delete(annotations, ingressRouterMiddlewaresAnnotation)
+		if !chain.isEmpty() {
+			annotations[ingressRouterMiddlewaresAnnotation] = chain.String()
+		}
+	}
+
+	// add other additional annotations (the middlewares of the dogu are already part of the middleware chain)
 	for key, value := range additionalAnnotations {
 		annotations[key] = value
 	}
 
-	err = i.upsertIngressObject(ctx, cesService.Name, service, ingressPath, service.GetName(), int32(cesService.Port), annotations)
+	err = i.upsertIngressObject(ctx, cesService, service, ingressPath, service.GetName(), int32(cesService.Port), annotations)
 	if err != nil {
 		return fmt.Errorf(failedIngressUpdateErrMsg, err)
 	}
@@ -285,29 +573,148 @@ func (i *ingressUpdater) upsertDoguIngressObject(ctx context.Context, cesService
 	return nil
 }
 
-func (i *ingressUpdater) upsertIngressObject(ctx context.Context, ingressName string, service *corev1.Service, path string, endpointName string, endpointPort int32, annotations map[string]string) error {
-	ingress := i.getIngress(ingressName, service.ObjectMeta, service.TypeMeta, path, endpointName, endpointPort, annotations)
+// getMiddlewareChain composes the middleware chain of the given ces service from the global middlewares, the given
+// service middlewares, the forward auth and the given rewrite middlewares of the service discovery, the default
+// middlewares of the global config, the given middlewares of the dogu and the middleware overrides of the dogu config.
+// The ip allowlist and the request limits precede the forward auth, so rejected requests never reach the auth
+// endpoint. The forward auth precedes the rewrites, so the auth endpoint gets the original path of the request. The
+// error pages follow the overrides, as they replace the error responses of the dogu only.
+func (i *ingressUpdater) getMiddlewareChain(cesService resolvedCesService, serviceMiddlewares serviceMiddlewareRefs, rewriteMiddlewares []string, doguMiddlewares string) *middlewareChain {
+	chain := &middlewareChain{}
+	chain.add(cesService.globalMiddlewares...)
+	chain.add(serviceMiddlewares.access...)
+	chain.add(cesService.forwardAuthMiddleware)
+	chain.add(rewriteMiddlewares...)
+	chain.addAnnotation(cesService.defaultMiddlewares)
+	chain.addAnnotation(doguMiddlewares)
+	chain.applyOverrides(cesService.middlewareOverrides)
+	chain.add(serviceMiddlewares.errorPages...)
+
+	return chain
+}
 
-	err := retry.OnConflict(func() error {
-		_, err := i.ingressInterface.Get(ctx, ingress.Name, v1.GetOptions{})
+// getStaticPageMiddlewareChain composes the middleware chain of the static page of the maintenance mode or of a
+// starting dogu from the global middlewares of the given ces service and the given middlewares of the page.
+func getStaticPageMiddlewareChain(cesService resolvedCesService, pageMiddlewares string) *middlewareChain {
+	chain := &middlewareChain{}
+	chain.add(cesService.globalMiddlewares...)
+	chain.addAnnotation(pageMiddlewares)
 
-		if err != nil && !errors.IsNotFound(err) {
-			return err
+	return chain
+}
+
+// addGlobalMiddlewares prepends the global middlewares of the given ces service to the middlewares of the given
+// annotations of a static page.
+func (i *ingressUpdater) addGlobalMiddlewares(cesService resolvedCesService, annotations map[string]string) map[string]string {
+	if len(cesService.globalMiddlewares) == 0 || !i.controller.UsesMiddlewares() {
+		return annotations
+	}
+
+	result := maps.Clone(annotations)
+	if result == nil {
+		result = map[string]string{}
+	}
+
+	result[ingressRouterMiddlewaresAnnotation] = getStaticPageMiddlewareChain(cesService, annotations[ingressRouterMiddlewaresAnnotation]).String()
+	return result
+}
+
+// translateAdditionalAnnotations translates the nginx annotations of the given additional ingress annotations of the
+// dogu and returns the remaining annotations. The max body size of the backend config of the ces service takes
+// precedence over the translated body size. Every annotation without traefik equivalent raises a warning event on
+// the dogu as soon as it appears.
+func (i *ingressUpdater) translateAdditionalAnnotations(ctx context.Context, cesService resolvedCesService, service *corev1.Service, dogu *doguv2.Dogu, annotations doguv2.IngressAnnotations) (nginxTranslation, doguv2.IngressAnnotations, error) {
+	pathPrefix, _, err := cesService.getReplacePath()
+	if err != nil {
+		return nginxTranslation{}, nil, fmt.Errorf("error getting rewrite-config from ces-service: %w", err)
+	}
+
+	translation, remaining := translateNginxAnnotations(annotations, pathPrefix)
+	translation = cesService.backend.overrideMaxBodySize(translation)
+	err = i.recordUntranslatedAnnotations(ctx, cesService, service, dogu, translation.untranslated)
+	if err != nil {
+		return nginxTranslation{}, nil, err
+	}
+
+	return translation, remaining, nil
+}
+
+// recordUntranslatedAnnotations records a warning event for every untranslated annotation of the given ces service if
+// they differ from the untranslated annotations stored on the service, so an unchanged service doesn't raise the same
+// warnings on every reconciliation. The stored annotations are updated afterward.
+func (i *ingressUpdater) recordUntranslatedAnnotations(ctx context.Context, cesService resolvedCesService, service *corev1.Service, dogu *doguv2.Dogu, untranslated []string) error {
+	ignored := map[string][]string{}
+	if value, ok := service.Annotations[ignoredNginxAnnotationsAnnotation]; ok {
+		// an invalid value is replaced below, so the warnings are recorded again
+		if err := json.Unmarshal([]byte(value), &ignored); err != nil {
+			ignored = map[string][]string{}
 		}
+	}
 
-		if errors.IsNotFound(err) {
-			_, createErr := i.ingressInterface.Create(ctx, ingress, v1.CreateOptions{})
-			return createErr
+	if slices.Equal(ignored[cesService.Name], untranslated) {
+		return nil
+	}
+
+	for _, message := range untranslated {
+		i.eventRecorder.Eventf(dogu, corev1.EventTypeWarning, annotationTranslationEventReason, "Ignored nginx ingress annotation of service [%s]: %s.", cesService.Name, message)
+	}
+
+	if len(untranslated) == 0 {
+		delete(ignored, cesService.Name)
+	} else {
+		ignored[cesService.Name] = untranslated
+	}
+
+	var value any
+	if len(ignored) > 0 {
+		data, err := json.Marshal(ignored)
+		if err != nil {
+			return fmt.Errorf("failed to marshal ignored nginx annotations of service [%s]: %w", service.Name, err)
 		}
 
-		_, err = i.ingressInterface.Update(ctx, ingress, v1.UpdateOptions{})
+		value = string(data)
+	}
+
+	err := i.patchServiceAnnotations(ctx, service, map[string]any{ignoredNginxAnnotationsAnnotation: value})
+	if err != nil {
+		return fmt.Errorf("failed to patch ignored nginx annotations of service [%s]: %w", service.Name, err)
+	}
+
+	// the other ces services of the service are translated afterward and must not overwrite the updated annotation
+	if service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+
+	if value == nil {
+		delete(service.Annotations, ignoredNginxAnnotationsAnnotation)
+	} else {
+		service.Annotations[ignoredNginxAnnotationsAnnotation] = value.(string)
+	}
+
+	return nil
+}
+
+// createTranslatedMiddlewares creates or updates the middlewares of the given translation and returns their names in
+// the order of the middleware chain.
+func (i *ingressUpdater) createTranslatedMiddlewares(ctx context.Context, cesService resolvedCesService, service *corev1.Service, translation nginxTranslation, ownerReferences []v1.OwnerReference) ([]string, error) {
+	var names []string
+	for _, suffix := range translation.getMiddlewareSuffixes() {
+		name := getTranslatedMiddlewareName(service.Name, cesService, suffix)
+		err := i.middlewareManager.createOrUpdateMiddleware(ctx, name, translation.middlewares[suffix], ownerReferences)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("failed to create/update translated middleware %s: %w", name, err)
 		}
 
-		return nil
-	})
+		names = append(names, name)
+	}
 
+	return names, nil
+}
+
+func (i *ingressUpdater) upsertIngressObject(ctx context.Context, cesService resolvedCesService, service *corev1.Service, path string, endpointName string, endpointPort int32, annotations map[string]string) error {
+	ingress := i.getIngress(cesService, service.ObjectMeta, service.TypeMeta, path, endpointName, endpointPort, annotations)
+
+	_, err := util.ServerSideApply[*networking.Ingress](ctx, i.ingressInterface, ingress, i.eventRecorder)
 	if err != nil {
 		return fmt.Errorf("failed to upsert ingress %s: %w", ingress.Name, err)
 	}
@@ -315,14 +722,27 @@ func (i *ingressUpdater) upsertIngressObject(ctx context.Context, ingressName st
 	return nil
 }
 
-func (i *ingressUpdater) getIngress(ingressName string, ownerObject v1.ObjectMeta, ownerType v1.TypeMeta, path string, endpointName string, endpointPort int32, annotations map[string]string) *networking.Ingress {
-	pathType := networking.PathTypePrefix
+func (i *ingressUpdater) getIngress(cesService resolvedCesService, ownerObject v1.ObjectMeta, ownerType v1.TypeMeta, path string, endpointName string, endpointPort int32, annotations map[string]string) *networking.Ingress {
+	pathType := i.pathType
+	if pathType == "" {
+		pathType = networking.PathTypePrefix
+	}
+
+	ingressAnnotations := map[string]string{routerPriorityAnnotation: getRouterPriority(path)}
+	// annotations of the ces service, e.g., the additional ingress annotations of a dogu, may overwrite the priority
+	for key, value := range annotations {
+		ingressAnnotations[key] = value
+	}
 
-	return &networking.Ingress{
+	ingress := &networking.Ingress{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: networking.SchemeGroupVersion.String(),
+			Kind:       "Ingress",
+		},
 		ObjectMeta: v1.ObjectMeta{
-			Name:        ingressName,
+			Name:        cesService.Name,
 			Namespace:   i.namespace,
-			Annotations: annotations,
+			Annotations: ingressAnnotations,
 			Labels:      util.K8sCesServiceDiscoveryLabels,
 			OwnerReferences: []v1.OwnerReference{{
 				APIVersion: ownerType.APIVersion,
@@ -357,4 +777,16 @@ func (i *ingressUpdater) getIngress(ingressName string, ownerObject v1.ObjectMet
 			},
 		},
 	}
+
+	if cesService.hasHost() {
+		ingress.Spec.Rules[0].Host = cesService.Host
+		// traefik serves routers with tls entries only via https, plain http requests are redirected by the global
+		// https redirect if enabled (see GlobalConfigHTTPSRedirectKey)
+		ingress.Spec.TLS = []networking.IngressTLS{{
+			Hosts:      []string{cesService.Host},
+			SecretName: cesService.getTLSSecretName(),
+		}}
+	}
+
+	return ingress
 }
@@ -5,18 +5,23 @@ import (
 	"encoding/json"
 	"testing"
 
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
 	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
+	"github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var testCtx = context.Background()
@@ -28,9 +33,44 @@ func getMaintenanceAdapterMock(t *testing.T, maintenanceMode bool) maintenanceAd
 	return mck
 }
 
+func getDoguConfigRepositoryMock(t *testing.T, entries config.Entries) doguConfigRepository {
+	mck := newMockDoguConfigRepository(t)
+	mck.EXPECT().Get(testCtx, cescommons.SimpleName("test")).Return(config.CreateDoguConfig("test", entries), nil)
+
+	return mck
+}
+
+func getGlobalConfigRepositoryMock(t *testing.T, entries config.Entries) GlobalConfigRepository {
+	mck := NewMockGlobalConfigRepository(t)
+	mck.EXPECT().Get(testCtx).Return(config.CreateGlobalConfig(entries), nil)
+
+	return mck
+}
+
+func withFQDNHost(cesServices []CesService) []resolvedCesService {
+	result := make([]resolvedCesService, 0, len(cesServices))
+	for _, cesService := range cesServices {
+		cesService.Host = testFQDN
+		result = append(result, resolvedCesService{CesService: cesService})
+	}
+
+	return result
+}
+
+func withDefaultErrorPages(cesServices []resolvedCesService, dogu string) []resolvedCesService {
+	result := make([]resolvedCesService, 0, len(cesServices))
+	for _, cesService := range cesServices {
+		cesService.errorPages = errorPages{status: []string{defaultErrorPageStatus}, dogu: dogu}
+		result = append(result, cesService)
+	}
+
+	return result
+}
+
 const (
-	testNamespace        = "my-namespace"
-	testIngressClassName = "my-ingress-class-name"
+	testNamespace            = "my-namespace"
+	testIngressClassName     = "my-ingress-class-name"
+	testIngressLabelSelector = "app=ces,app.kubernetes.io/name=k8s-service-discovery"
 )
 
 func TestNewIngressUpdater(t *testing.T) {
@@ -44,15 +84,15 @@ func TestNewIngressUpdater(t *testing.T) {
 		middlewareManagerMock := newMockMiddlewareManager(t)
 		// when
 		sut := NewIngressUpdater(IngressUpdaterDependencies{
-			deploymentReadyCheckerMock,
-			ingressInterfaceMock,
-			doguInterfaceMock,
-			testNamespace,
-			testIngressClassName,
-			newMockEventRecorder(t),
-			ingressControllerMock,
-			middlewareManagerMock,
-			maintenanceAdapterMock,
+			DeploymentReadyChecker: deploymentReadyCheckerMock,
+			IngressInterface:       ingressInterfaceMock,
+			DoguInterface:          doguInterfaceMock,
+			Namespace:              testNamespace,
+			IngressClassName:       testIngressClassName,
+			Recorder:               newMockEventRecorder(t),
+			Controller:             ingressControllerMock,
+			MiddlewareManager:      middlewareManagerMock,
+			MaintenanceAdapter:     maintenanceAdapterMock,
 		})
 
 		// then
@@ -61,17 +101,25 @@ func TestNewIngressUpdater(t *testing.T) {
 }
 
 func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
-	t.Run("skipped as service has no ports", func(t *testing.T) {
+	t.Run("should prune the ingresses of a service without ports", func(t *testing.T) {
 		// given
 		service := &corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "uid"},
 		}
 
-		maintenanceAdapterMock := getMaintenanceAdapterMock(t, false)
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&v1.IngressList{}, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, []CesService(nil)).Return(nil)
 
 		sut := ingressUpdater{
 			namespace:          testNamespace,
-			maintenanceAdapter: maintenanceAdapterMock,
+			maintenanceAdapter: getMaintenanceAdapterMock(t, false),
+			ingressInterface:   ingressInterfaceMock,
+			controller:         ingressControllerMock,
+			middlewareManager:  middlewareManagerMock,
 		}
 
 		// when
@@ -80,20 +128,40 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 		// then
 		require.NoError(t, err)
 	})
-	t.Run("skipped as no annotation exist", func(t *testing.T) {
+	t.Run("should prune the ingresses and middlewares of a service whose annotation was removed", func(t *testing.T) {
 		// given
 		service := &corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "uid"},
 			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
 				{Name: "testPort", Port: 55},
 			}},
 		}
+		ingressList := &v1.IngressList{Items: []v1.Ingress{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test", OwnerReferences: []metav1.OwnerReference{{Name: "test", UID: "uid"}}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "other", OwnerReferences: []metav1.OwnerReference{{Name: "other", UID: "other-uid"}}}},
+		}}
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
 
-		maintenanceAdapterMock := getMaintenanceAdapterMock(t, false)
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(ingressList, nil)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale ingress [%s] as the ces service no longer exists.", "test")
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, service, []CesService(nil)).Return(nil)
 
 		sut := ingressUpdater{
 			namespace:          testNamespace,
-			maintenanceAdapter: maintenanceAdapterMock,
+			maintenanceAdapter: getMaintenanceAdapterMock(t, false),
+			ingressInterface:   ingressInterfaceMock,
+			doguInterface:      doguInterfaceMock,
+			eventRecorder:      recorderMock,
+			controller:         ingressControllerMock,
+			middlewareManager:  middlewareManagerMock,
 		}
 
 		// when
@@ -130,6 +198,72 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to unmarshal ces services")
 	})
+	t.Run("error when fetching the global routing config", func(t *testing.T) {
+		// given
+		cesServiceString, _ := json.Marshal([]CesService{{Name: "test", Port: 55, Location: "/myLocation", Pass: "/myPass"}})
+
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   testNamespace,
+				Annotations: map[string]string{CesServiceAnnotation: string(cesServiceString)},
+				Labels:      map[string]string{"dogu.name": "test"},
+			},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+				{Name: "testPort", Port: 55},
+			}},
+		}
+		globalConfigRepoMock := NewMockGlobalConfigRepository(t)
+		globalConfigRepoMock.EXPECT().Get(testCtx).Return(config.GlobalConfig{}, assert.AnError)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			maintenanceAdapter:     getMaintenanceAdapterMock(t, false),
+			globalConfigRepository: globalConfigRepoMock,
+		}
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get global routing config")
+	})
+	t.Run("error when listing the ingress objects", func(t *testing.T) {
+		// given
+		cesServiceString, _ := json.Marshal([]CesService{{Name: "test", Port: 55, Location: "/myLocation", Pass: "/myPass"}})
+
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   testNamespace,
+				Annotations: map[string]string{CesServiceAnnotation: string(cesServiceString)},
+				Labels:      map[string]string{"dogu.name": "test"},
+			},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+				{Name: "testPort", Port: 55},
+			}},
+		}
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(nil, assert.AnError)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			maintenanceAdapter:     getMaintenanceAdapterMock(t, false),
+			ingressInterface:       ingressInterfaceMock,
+			doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
+		}
+
+		// when
+		err := sut.UpsertIngressForService(testCtx, service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list ingress objects")
+	})
 	t.Run("error when fetching the dogu", func(t *testing.T) {
 		// given
 		cesService := []CesService{
@@ -157,13 +291,18 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 		}
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(nil, assert.AnError)
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&v1.IngressList{}, nil)
 
 		maintenanceAdapterMock := getMaintenanceAdapterMock(t, false)
 
 		sut := ingressUpdater{
-			namespace:          testNamespace,
-			maintenanceAdapter: maintenanceAdapterMock,
-			doguInterface:      doguInterfaceMock,
+			namespace:              testNamespace,
+			maintenanceAdapter:     maintenanceAdapterMock,
+			doguInterface:          doguInterfaceMock,
+			ingressInterface:       ingressInterfaceMock,
+			doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
 		}
 
 		// when
@@ -171,7 +310,7 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 
 		// then
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to create ingress object for ces service [{Name:test Port:55 Location:/myLocation Pass:/myPass Rewrite:}]")
+		assert.ErrorContains(t, err, "failed to create ingress object for ces service [{Name:test Port:55 Location:/myLocation Pass:/myPass Rewrite: Host:ces.example.com TLSSecretName:")
 	})
 	t.Run("error when updating service ingress object because deployment checker returns an error", func(t *testing.T) {
 		// given
@@ -204,12 +343,17 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 		maintenanceAdapterMock := getMaintenanceAdapterMock(t, false)
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, dogu.Name, metav1.GetOptions{}).Return(dogu, nil)
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&v1.IngressList{}, nil)
 
 		sut := ingressUpdater{
 			namespace:              testNamespace,
 			maintenanceAdapter:     maintenanceAdapterMock,
 			deploymentReadyChecker: deploymentReadyChecker,
 			doguInterface:          doguInterfaceMock,
+			ingressInterface:       ingressInterfaceMock,
+			doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
 		}
 
 		// when
@@ -246,9 +390,9 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 			}},
 		}
 
-		expectedIngress := getTestIngress("test", "myPattern", service, service.Name, 55, map[string]string{
-			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-@kubernetescrd",
-		})
+		expectedIngress := withTestHost(getTestIngress("test", "/myPattern(/|$)(.*)", service, service.Name, 55, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd,my-namespace-test-test-errors@kubernetescrd",
+		}), testFQDN)
 
 		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
 		recorderMock := newMockEventRecorder(t)
@@ -259,9 +403,15 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, dogu.Name, metav1.GetOptions{}).Return(dogu, nil)
 		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "test-test-rewrite", "/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd"})
 		ingressInterfaceMock := newMockIngressInterface(t)
-		ingressInterfaceMock.EXPECT().Get(testCtx, expectedIngress.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "not found"))
-		ingressInterfaceMock.EXPECT().Create(testCtx, expectedIngress, metav1.CreateOptions{}).Return(nil, nil)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&v1.IngressList{}, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", errorPages{status: []string{"502-504"}, dogu: "test"}.getMiddleware(), []metav1.OwnerReference{{Name: "test"}}).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, "test", withDefaultErrorPages(withFQDNHost(cesService), "test")[0], []metav1.OwnerReference{{Name: "test"}}).Return("test-test-rewrite", nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, &service, withDefaultErrorPages(withFQDNHost(cesService), "test")).Return(nil)
 
 		sut := ingressUpdater{
 			maintenanceAdapter:     maintenanceAdapterMock,
@@ -270,8 +420,11 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 			doguInterface:          doguInterfaceMock,
 			controller:             ingressControllerMock,
 			ingressInterface:       ingressInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
 			namespace:              testNamespace,
 			ingressClassName:       testIngressClassName,
+			doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
 		}
 
 		// when
@@ -309,12 +462,12 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 			}},
 		}
 
-		existingIngress := getTestIngress("test", "myPattern", service, service.Name, 44, map[string]string{})
+		existingIngress := getTestIngress("test", "/myPattern(/|$)(.*)", service, service.Name, 44, map[string]string{})
 
-		expectedIngress := getTestIngress("test", "myPattern", service, service.Name, 55, map[string]string{
-			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-@kubernetescrd",
+		expectedIngress := withTestHost(getTestIngress("test", "/myPattern(/|$)(.*)", service, service.Name, 55, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd,my-namespace-test-test-errors@kubernetescrd",
 			"example-annotation": "example-value",
-		})
+		}), testFQDN)
 
 		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
 		recorderMock := newMockEventRecorder(t)
@@ -325,11 +478,15 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, dogu.Name, metav1.GetOptions{}).Return(dogu, nil)
 		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "test-test-rewrite", "/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd"})
 		ingressInterfaceMock := newMockIngressInterface(t)
-		ingressInterfaceMock.EXPECT().Get(testCtx, expectedIngress.Name, metav1.GetOptions{}).Return(existingIngress, nil)
-		ingressInterfaceMock.EXPECT().Update(testCtx, mock.Anything, metav1.UpdateOptions{}).Return(nil, nil).Run(func(ctx context.Context, ingress *v1.Ingress, opts metav1.UpdateOptions) {
-			assert.Equal(t, ingress, expectedIngress)
-		})
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&v1.IngressList{Items: []v1.Ingress{*existingIngress}}, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", errorPages{status: []string{"502-504"}, dogu: "test"}.getMiddleware(), []metav1.OwnerReference{{Name: "test"}}).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, "test", withDefaultErrorPages(withFQDNHost(cesService), "test")[0], []metav1.OwnerReference{{Name: "test"}}).Return("test-test-rewrite", nil)
+		middlewareManagerMock.EXPECT().deleteOrphanedMiddlewares(testCtx, &service, withDefaultErrorPages(withFQDNHost(cesService), "test")).Return(nil)
 
 		sut := ingressUpdater{
 			maintenanceAdapter:     maintenanceAdapterMock,
@@ -338,8 +495,11 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 			doguInterface:          doguInterfaceMock,
 			controller:             ingressControllerMock,
 			ingressInterface:       ingressInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
 			namespace:              testNamespace,
 			ingressClassName:       testIngressClassName,
+			doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
 		}
 
 		// when
@@ -384,7 +544,11 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, dogu.Name, metav1.GetOptions{}).Return(dogu, nil)
 		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
 		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().List(testCtx, metav1.ListOptions{LabelSelector: testIngressLabelSelector}).Return(&v1.IngressList{}, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-errors", mock.Anything, mock.Anything).Return(nil)
 
 		sut := ingressUpdater{
 			maintenanceAdapter:     maintenanceAdapterMock,
@@ -393,8 +557,11 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 			doguInterface:          doguInterfaceMock,
 			controller:             ingressControllerMock,
 			ingressInterface:       ingressInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
 			namespace:              testNamespace,
 			ingressClassName:       testIngressClassName,
+			doguConfigRepository:   getDoguConfigRepositoryMock(t, nil),
+			globalConfigRepository: getGlobalConfigRepositoryMock(t, config.Entries{"fqdn": testFQDN}),
 		}
 
 		// when
@@ -410,12 +577,12 @@ func Test_ingressUpdater_UpdateIngressOfService(t *testing.T) {
 func Test_ingressUpdater_upsertIngressForCesService(t *testing.T) {
 	t.Run("Fail to create ingress resource for a single ces service with invalid additional ingress annotations", func(t *testing.T) {
 		// given
-		cesServiceWithOneWebapp := CesService{
+		cesServiceWithOneWebapp := resolvedCesService{CesService: CesService{
 			Name:     "test",
 			Port:     12345,
 			Location: "/myLocation",
 			Pass:     "/myPass",
-		}
+		}}
 		service := corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "test",
@@ -426,9 +593,6 @@ func Test_ingressUpdater_upsertIngressForCesService(t *testing.T) {
 				},
 			},
 		}
-		ownerReferences := []metav1.OwnerReference{
-			{Name: service.GetName()},
-		}
 		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
@@ -436,140 +600,957 @@ func Test_ingressUpdater_upsertIngressForCesService(t *testing.T) {
 		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
 		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
 
+		sut := ingressUpdater{
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			controller:             newMockIngressController(t),
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, false)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get addtional ingress annotations from dogu service 'test': invalid character '{' looking for beginning of object key string")
+	})
+
+	t.Run("Create ingress resource at the root path of the own host of a ces service", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{
+			Name:     "nexus",
+			Port:     8082,
+			Location: "/",
+			Pass:     "/nexus",
+			Host:     "nexus.ces.example.com",
+		}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: testNamespace, Labels: map[string]string{"dogu.name": "nexus"}},
+		}
+		ownerReferences := []metav1.OwnerReference{{Name: service.GetName()}}
+
+		expectedIngress := withTestHost(getTestIngress("nexus", "/", service, "nexus", 8082, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-nexus-nexus-rewrite@kubernetescrd",
+		}), "nexus.ces.example.com")
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "nexus").Return(true, nil)
 		middlewareManagerMock := newMockMiddlewareManager(t)
-		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, service.Name, mock.Anything, ownerReferences).Return("/errors/starting.html", nil)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, service.Name, cesService, ownerReferences).Return("nexus-nexus-rewrite", nil)
 		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "nexus-nexus-rewrite", "/nexus/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-nexus-nexus-rewrite@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "nexus")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
 
 		sut := ingressUpdater{
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
 			deploymentReadyChecker: deploymentReadyChecker,
 			doguInterface:          doguInterfaceMock,
 			middlewareManager:      middlewareManagerMock,
 			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
+			ingressInterface:       ingressInterfaceMock,
 		}
 
 		// when
-		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, false)
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
 
 		// then
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to get addtional ingress annotations from dogu service 'test': invalid character '{' looking for beginning of object key string")
+		require.NoError(t, err)
 	})
 
-	t.Run("Create ingress resource for a single ces service while maintenance mode is active", func(t *testing.T) {
+	t.Run("Create ingress resource and warn about the routing config of a ces service which only ingress routes honor", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{
+			Name:     "nexus",
+			Port:     8082,
+			Location: "/",
+			Pass:     "/nexus",
+			Host:     "nexus.ces.example.com",
+			Methods:  []string{"GET"},
+			Headers:  map[string]string{"X-Api-Version": "2"},
+		}, backend: backendConfig{strategy: "p2c"}, mirroring: mirroring{Target: "nexus-shadow"}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: testNamespace, Labels: map[string]string{"dogu.name": "nexus"}},
+		}
+		ownerReferences := []metav1.OwnerReference{{Name: service.GetName()}}
+
+		expectedIngress := withTestHost(getTestIngress("nexus", "/", service, "nexus", 8082, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-nexus-nexus-rewrite@kubernetescrd",
+		}), "nexus.ces.example.com")
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "nexus").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, service.Name, cesService, ownerReferences).Return("nexus-nexus-rewrite", nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "nexus-nexus-rewrite", "/nexus/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-nexus-nexus-rewrite@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "nexus")
+		recorderMock.EXPECT().Eventf(dogu, "Warning", "IgnoredRoutingConfig", "Ignored routing config of ces service [%s] without ingress object equivalent: %s.", "nexus", "the method and header matchers; the balancing strategy; the mirroring")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
+			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
+			ingressInterface:       ingressInterfaceMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Create ingress resource with the translated middlewares of nginx annotations", func(t *testing.T) {
 		// given
-		cesServiceWithOneWebapp := CesService{
+		cesService := resolvedCesService{CesService: CesService{
 			Name:     "test",
-			Port:     12345,
+			Port:     8080,
 			Location: "/myLocation",
 			Pass:     "/myPass",
-		}
+			Host:     testFQDN,
+		}}
 		service := corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: testNamespace,
+				Labels:    map[string]string{"dogu.name": "test"},
+				Annotations: map[string]string{
+					annotation.AdditionalIngressAnnotationsAnnotation: `{"nginx.ingress.kubernetes.io/proxy-body-size":"10m","nginx.ingress.kubernetes.io/configuration-snippet":"more_set_headers \"X-Frame-Options: DENY\";","nginx.ingress.kubernetes.io/proxy-read-timeout":"300","example-annotation":"example-value"}`,
+				},
+			},
 		}
+		ownerReferences := []metav1.OwnerReference{{Name: service.GetName()}}
 
-		expectedIngress := getTestIngress(
-			"test",
-			"/myLocation",
-			service,
-			"k8s-ces-assets-service",
-			80,
-			map[string]string{
-				"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-maintenance-mode@kubernetescrd",
-			},
-		)
+		expectedIngress := withTestHost(getTestIngress("test", "/myLocation(/|$)(.*)", service, "test", 8080, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd,my-namespace-test-test-buffering@kubernetescrd,my-namespace-test-test-headers@kubernetescrd",
+			"example-annotation": "example-value",
+		}), testFQDN)
 
 		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-buffering", traefikapi.MiddlewareSpec{
+			Buffering: &dynamic.Buffering{MaxRequestBodyBytes: 10 * 1024 * 1024},
+		}, ownerReferences).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-headers", traefikapi.MiddlewareSpec{
+			Headers: &dynamic.Headers{CustomResponseHeaders: map[string]string{"X-Frame-Options": "DENY"}},
+		}, ownerReferences).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, service.Name, cesService, ownerReferences).Return("test-test-rewrite", nil)
 		ingressControllerMock := newMockIngressController(t)
-		ingressControllerMock.EXPECT().GetRewriteAnnotationKey().Return("traefik.ingress.kubernetes.io/router.middlewares")
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "test-test-rewrite", "/myPass/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd"})
 		recorderMock := newMockEventRecorder(t)
-		recorderMock.EXPECT().Eventf(mock.IsType(&doguv2.Dogu{}), "Normal", "IngressCreation", "Ingress for service [%s] has been updated to maintenance mode.", "test")
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "test")
 		ingressInterfaceMock := newMockIngressInterface(t)
-		ingressInterfaceMock.EXPECT().Get(testCtx, expectedIngress.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "not found"))
-		ingressInterfaceMock.EXPECT().Create(testCtx, expectedIngress, metav1.CreateOptions{}).Return(nil, nil)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
 
 		sut := ingressUpdater{
-			doguInterface:    doguInterfaceMock,
-			controller:       ingressControllerMock,
-			ingressInterface: ingressInterfaceMock,
-			namespace:        testNamespace,
-			ingressClassName: testIngressClassName,
-			eventRecorder:    recorderMock,
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
+			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
+			ingressInterface:       ingressInterfaceMock,
 		}
 
 		// when
-		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, true)
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
 
 		// then
 		require.NoError(t, err)
 	})
-	t.Run("Failed to wait for deployment to be ready -> stuck at dogu is staring ingress object", func(t *testing.T) {
+
+	t.Run("Create ingress resource with the translated rewrite target and record the untranslated annotations", func(t *testing.T) {
 		// given
-		cesServiceWithOneWebapp := CesService{
+		cesService := resolvedCesService{CesService: CesService{
 			Name:     "test",
-			Port:     12345,
+			Port:     8080,
 			Location: "/myLocation",
 			Pass:     "/myPass",
-		}
+			Host:     testFQDN,
+		}}
 		service := corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{Name: "test",
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
 				Namespace: testNamespace,
-				Labels:    map[string]string{"dogu.name": "test"}},
+				Labels:    map[string]string{"dogu.name": "test"},
+				Annotations: map[string]string{
+					annotation.AdditionalIngressAnnotationsAnnotation: `{"nginx.ingress.kubernetes.io/proxy-body-size":"10m","nginx.ingress.kubernetes.io/rewrite-target":"/api/$2","nginx.ingress.kubernetes.io/server-snippet":"listen 8080;"}`,
+				},
+			},
 		}
 
-		expectedIngress := getTestIngress("test", "/myLocation", service, "k8s-ces-assets-service", 80, map[string]string{
-			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-dogu-starting@kubernetescrd",
-		})
+		expectedIngress := withTestHost(getTestIngress("test", "/myLocation(/|$)(.*)", service, "test", 8080, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd,my-namespace-test-test-buffering@kubernetescrd",
+		}), testFQDN)
 
 		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
 		doguInterfaceMock := newMockDoguInterface(t)
 		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
-		ingressControllerMock := newMockIngressController(t)
-		ingressControllerMock.EXPECT().GetRewriteAnnotationKey().Return("traefik.ingress.kubernetes.io/router.middlewares")
 		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
-		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(false, nil).Once()
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-rewrite", mock.Anything, mock.Anything).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-buffering", mock.Anything, mock.Anything).Return(nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "test-test-rewrite", "/myPass/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Warning", "IngressAnnotationTranslation", "Ignored nginx ingress annotation of service [%s]: %s.", "test", "annotation [nginx.ingress.kubernetes.io/server-snippet] has no traefik equivalent")
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "test")
+		serviceInterfaceMock := newMockServiceInterface(t)
+		serviceInterfaceMock.EXPECT().Patch(testCtx, "test", types.MergePatchType,
+			[]byte(`{"metadata":{"annotations":{"k8s-service-discovery.cloudogu.com/ignored-nginx-annotations":"{\"test\":[\"annotation [nginx.ingress.kubernetes.io/server-snippet] has no traefik equivalent\"]}"}}}`),
+			metav1.PatchOptions{}).Return(&service, nil)
 		ingressInterfaceMock := newMockIngressInterface(t)
-		ingressInterfaceMock.EXPECT().Get(testCtx, expectedIngress.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "not found"))
-		ingressInterfaceMock.EXPECT().Create(testCtx, expectedIngress, metav1.CreateOptions{}).Return(nil, nil)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
 
 		sut := ingressUpdater{
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
 			deploymentReadyChecker: deploymentReadyChecker,
 			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
 			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
 			ingressInterface:       ingressInterfaceMock,
-			namespace:              testNamespace,
-			ingressClassName:       testIngressClassName,
+			serviceInterface:       serviceInterfaceMock,
 		}
 
 		// when
-		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, false)
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
 
 		// then
 		require.NoError(t, err)
+		assert.Equal(t, `{"test":["annotation [nginx.ingress.kubernetes.io/server-snippet] has no traefik equivalent"]}`, service.Annotations["k8s-service-discovery.cloudogu.com/ignored-nginx-annotations"])
 	})
-}
-func TestCesService_getRewriteConfig(t *testing.T) {
-	tests := []struct {
-		name    string
-		rewrite string
-		want    *serviceRewrite
-		wantErr func(t *testing.T, err error)
-	}{
-		{
-			name:    "should fail to unmarshal invalid rewrite",
-			rewrite: "{\"pattern\": \"portainer\", \"rewrite\": \"\"",
-			want:    nil,
-			wantErr: func(t *testing.T, err error) {
-				assert.ErrorContains(t, err, "failed to read service rewrite from ces service: unexpected end of JSON input")
+
+	t.Run("Create ingress resource without recording the unchanged untranslated annotations again", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{
+			Name:     "test",
+			Port:     8080,
+			Location: "/myLocation",
+			Pass:     "/myPass",
+			Host:     testFQDN,
+		}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: testNamespace,
+				Labels:    map[string]string{"dogu.name": "test"},
+				Annotations: map[string]string{
+					annotation.AdditionalIngressAnnotationsAnnotation:              `{"nginx.ingress.kubernetes.io/server-snippet":"listen 8080;"}`,
+					"k8s-service-discovery.cloudogu.com/ignored-nginx-annotations": `{"test":["annotation [nginx.ingress.kubernetes.io/server-snippet] has no traefik equivalent"]}`,
+				},
 			},
-		},
-		{
-			name:    "should succeed to generate rewrite config",
-			rewrite: "{\"pattern\": \"portainer\", \"rewrite\": \"p\"}",
-			want:    &serviceRewrite{Pattern: "portainer", Rewrite: "p"},
-			wantErr: func(t *testing.T, err error) { require.NoError(t, err) },
-		},
+		}
+
+		expectedIngress := withTestHost(getTestIngress("test", "/myLocation(/|$)(.*)", service, "test", 8080, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd",
+		}), testFQDN)
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, service.Name, cesService, []metav1.OwnerReference{{Name: service.GetName()}}).Return("test-test-rewrite", nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "test-test-rewrite", "/myPass/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "test")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
+			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
+			ingressInterface:       ingressInterfaceMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Create ingress resource with the composed middleware chain of the rewrite, the defaults, the dogu and the overrides", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:          CesService{Name: "test", Port: 8080, Location: "/myLocation", Pass: "/myPass", Host: testFQDN},
+			defaultMiddlewares:  "my-namespace-hsts@kubernetescrd,compress@file",
+			middlewareOverrides: "-compress@file",
+		}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: testNamespace,
+				Labels:    map[string]string{"dogu.name": "test"},
+				Annotations: map[string]string{
+					annotation.AdditionalIngressAnnotationsAnnotation: `{"traefik.ingress.kubernetes.io/router.middlewares":"my-namespace-auth@kubernetescrd,my-namespace-hsts@kubernetescrd"}`,
+				},
+			},
+		}
+		ownerReferences := []metav1.OwnerReference{{Name: service.GetName()}}
+
+		expectedIngress := withTestHost(getTestIngress("test", "/myLocation(/|$)(.*)", service, "test", 8080, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd,my-namespace-hsts@kubernetescrd,my-namespace-auth@kubernetescrd",
+		}), testFQDN)
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateReplacePathMiddleware(testCtx, service.Name, cesService, ownerReferences).Return("test-test-rewrite", nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "test-test-rewrite", "/myPass/$2").Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-rewrite@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "test")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
+			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
+			ingressInterface:       ingressInterfaceMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Create ingress resource with the middlewares of the request limits", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:    CesService{Name: "test", Port: 8080, Location: "/test", Pass: "/test", Host: testFQDN},
+			requestLimits: requestLimits{Average: 100, InFlight: 10},
+		}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace, Labels: map[string]string{"dogu.name": "test"}},
+		}
+		ownerReferences := []metav1.OwnerReference{{Name: service.GetName()}}
+
+		expectedIngress := withTestHost(getTestIngress("test", "/test", service, "test", 8080, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-test-test-ratelimit@kubernetescrd,my-namespace-test-test-inflightreq@kubernetescrd",
+		}), testFQDN)
+
+		average := int64(100)
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-ratelimit", traefikapi.MiddlewareSpec{
+			RateLimit: &traefikapi.RateLimit{Average: &average},
+		}, ownerReferences).Return(nil)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-inflightreq", traefikapi.MiddlewareSpec{
+			InFlightReq: &dynamic.InFlightReq{Amount: 10},
+		}, ownerReferences).Return(nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "test")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
+			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
+			ingressInterface:       ingressInterfaceMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail to create the middlewares of the request limits", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{Name: "test", Port: 8080, Location: "/test", Pass: "/test"}, requestLimits: requestLimits{InFlight: 10}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace, Labels: map[string]string{"dogu.name": "test"}},
+		}
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-inflightreq", mock.Anything, mock.Anything).Return(assert.AnError)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
+			controller:             ingressControllerMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create/update service middleware test-test-inflightreq")
+	})
+
+	t.Run("Fail to create the translated middlewares of nginx annotations", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{Name: "test", Port: 8080, Location: "/test", Pass: "/test"}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   testNamespace,
+				Labels:      map[string]string{"dogu.name": "test"},
+				Annotations: map[string]string{annotation.AdditionalIngressAnnotationsAnnotation: `{"nginx.ingress.kubernetes.io/proxy-body-size":"10m"}`},
+			},
+		}
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().createOrUpdateMiddleware(testCtx, "test-test-buffering", mock.Anything, mock.Anything).Return(assert.AnError)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			middlewareManager:      middlewareManagerMock,
+			controller:             ingressControllerMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create/update translated middleware test-test-buffering")
+	})
+
+	t.Run("Create ingress resource with a rewrite annotation if the controller does not use middlewares", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{
+			Name:     "test",
+			Port:     8080,
+			Location: "/myLocation",
+			Pass:     "/myPass",
+			Host:     testFQDN,
+		}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace, Labels: map[string]string{"dogu.name": "test"}},
+		}
+
+		expectedIngress := withTestHost(getTestIngress("test", "/myLocation(/|$)(.*)", service, "test", 8080, map[string]string{
+			"nginx.ingress.kubernetes.io/rewrite-target": "/myPass/$2",
+			"nginx.ingress.kubernetes.io/use-regex":      "true",
+		}), testFQDN)
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(true, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(false)
+		ingressControllerMock.EXPECT().GetPathRewriteAnnotations(testNamespace, "", "/myPass/$2").Return(map[string]string{
+			"nginx.ingress.kubernetes.io/rewrite-target": "/myPass/$2",
+			"nginx.ingress.kubernetes.io/use-regex":      "true",
+		})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressCreation", "Created regular ingress for service [%s].", "test")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			controller:             ingressControllerMock,
+			eventRecorder:          recorderMock,
+			ingressInterface:       ingressInterfaceMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, false)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Create ingress resource for a single ces service while maintenance mode is active", func(t *testing.T) {
+		// given
+		cesServiceWithOneWebapp := resolvedCesService{CesService: CesService{
+			Name:     "test",
+			Port:     12345,
+			Location: "/myLocation",
+			Pass:     "/myPass",
+		}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		}
+
+		expectedIngress := getTestIngress(
+			"test",
+			"/myLocation",
+			service,
+			"k8s-ces-assets-service",
+			80,
+			map[string]string{
+				"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-maintenance-mode@kubernetescrd",
+			},
+		)
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().GetMaintenanceModeAnnotations(testNamespace).Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-maintenance-mode@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(mock.IsType(&doguv2.Dogu{}), "Normal", "IngressCreation", "Ingress for service [%s] has been updated to maintenance mode.", "test")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			doguInterface:    doguInterfaceMock,
+			controller:       ingressControllerMock,
+			ingressInterface: ingressInterfaceMock,
+			namespace:        testNamespace,
+			ingressClassName: testIngressClassName,
+			eventRecorder:    recorderMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, true)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("Create maintenance ingress resource with the global middlewares", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:        CesService{Name: "test", Port: 12345, Location: "/myLocation", Pass: "/myPass"},
+			globalMiddlewares: []string{"my-namespace-global-security-headers@kubernetescrd"},
+		}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		}
+
+		expectedIngress := getTestIngress(
+			"test",
+			"/myLocation",
+			service,
+			"k8s-ces-assets-service",
+			80,
+			map[string]string{
+				"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-global-security-headers@kubernetescrd,my-namespace-maintenance-mode@kubernetescrd",
+			},
+		)
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		ingressControllerMock.EXPECT().GetMaintenanceModeAnnotations(testNamespace).Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-maintenance-mode@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(mock.IsType(&doguv2.Dogu{}), "Normal", "IngressCreation", "Ingress for service [%s] has been updated to maintenance mode.", "test")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			doguInterface:    doguInterfaceMock,
+			controller:       ingressControllerMock,
+			ingressInterface: ingressInterfaceMock,
+			namespace:        testNamespace,
+			ingressClassName: testIngressClassName,
+			eventRecorder:    recorderMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesService, &service, true)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("Skip applying the unchanged ingress resource for a single ces service while maintenance mode is active", func(t *testing.T) {
+		// given
+		cesServiceWithOneWebapp := resolvedCesService{CesService: CesService{
+			Name:     "test",
+			Port:     12345,
+			Location: "/myLocation",
+			Pass:     "/myPass",
+		}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		}
+
+		liveIngress := getTestIngress("test", "/myLocation", service, "k8s-ces-assets-service", 80, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-maintenance-mode@kubernetescrd",
+		})
+		liveIngress.ResourceVersion = "42"
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().GetMaintenanceModeAnnotations(testNamespace).Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-maintenance-mode@kubernetescrd"})
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(mock.IsType(&doguv2.Dogu{}), "Normal", "IngressCreation", "Ingress for service [%s] has been updated to maintenance mode.", "test")
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(liveIngress, nil)
+
+		sut := ingressUpdater{
+			doguInterface:    doguInterfaceMock,
+			controller:       ingressControllerMock,
+			ingressInterface: ingressInterfaceMock,
+			namespace:        testNamespace,
+			ingressClassName: testIngressClassName,
+			eventRecorder:    recorderMock,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, true)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("Fail to apply ingress resource for a single ces service while maintenance mode is active", func(t *testing.T) {
+		// given
+		cesServiceWithOneWebapp := resolvedCesService{CesService: CesService{
+			Name:     "test",
+			Port:     12345,
+			Location: "/myLocation",
+			Pass:     "/myPass",
+		}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		}
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().GetMaintenanceModeAnnotations(testNamespace).Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-maintenance-mode@kubernetescrd"})
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, "test"))
+		ingressInterfaceMock.EXPECT().Patch(testCtx, "test", types.ApplyPatchType, mock.Anything, metav1.PatchOptions{FieldManager: "k8s-service-discovery"}).Return(nil, assert.AnError)
+
+		sut := ingressUpdater{
+			doguInterface:    doguInterfaceMock,
+			controller:       ingressControllerMock,
+			ingressInterface: ingressInterfaceMock,
+			namespace:        testNamespace,
+			ingressClassName: testIngressClassName,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, true)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to upsert ingress test")
+	})
+	t.Run("Failed to wait for deployment to be ready -> stuck at dogu is staring ingress object", func(t *testing.T) {
+		// given
+		cesServiceWithOneWebapp := resolvedCesService{CesService: CesService{
+			Name:     "test",
+			Port:     12345,
+			Location: "/myLocation",
+			Pass:     "/myPass",
+		}}
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test",
+				Namespace: testNamespace,
+				Labels:    map[string]string{"dogu.name": "test"}},
+		}
+
+		expectedIngress := getTestIngress("test", "/myLocation", service, "k8s-ces-assets-service", 80, map[string]string{
+			"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-dogu-starting@kubernetescrd",
+		})
+
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, service.Name, metav1.GetOptions{}).Return(dogu, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().GetDoguStartingAnnotations(testNamespace).Return(map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "my-namespace-dogu-starting@kubernetescrd"})
+		deploymentReadyChecker := NewMockDeploymentReadyChecker(t)
+		deploymentReadyChecker.EXPECT().IsReady(testCtx, "test").Return(false, nil).Once()
+		ingressInterfaceMock := newMockIngressInterface(t)
+		expectApplyIngress(t, ingressInterfaceMock, expectedIngress)
+
+		sut := ingressUpdater{
+			deploymentReadyChecker: deploymentReadyChecker,
+			doguInterface:          doguInterfaceMock,
+			controller:             ingressControllerMock,
+			ingressInterface:       ingressInterfaceMock,
+			namespace:              testNamespace,
+			ingressClassName:       testIngressClassName,
+		}
+
+		// when
+		err := sut.upsertIngressForCesService(testCtx, cesServiceWithOneWebapp, &service, false)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func Test_ingressUpdater_UpsertGlobalMiddlewares(t *testing.T) {
+	t.Run("should do nothing if the controller does not use middlewares", func(t *testing.T) {
+		// given
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(false)
+
+		sut := ingressUpdater{controller: ingressControllerMock}
+
+		// when
+		err := sut.UpsertGlobalMiddlewares(testCtx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should upsert the global middlewares of the global config", func(t *testing.T) {
+		// given
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "true"})
+		globalConfigRepoMock := NewMockGlobalConfigRepository(t)
+		globalConfigRepoMock.EXPECT().Get(testCtx).Return(globalConfig, nil)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+		middlewareManagerMock := newMockMiddlewareManager(t)
+		middlewareManagerMock.EXPECT().UpsertGlobalMiddlewares(testCtx, globalConfig).Return(nil)
+
+		sut := ingressUpdater{
+			controller:             ingressControllerMock,
+			globalConfigRepository: globalConfigRepoMock,
+			middlewareManager:      middlewareManagerMock,
+		}
+
+		// when
+		err := sut.UpsertGlobalMiddlewares(testCtx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to get global config", func(t *testing.T) {
+		// given
+		globalConfigRepoMock := NewMockGlobalConfigRepository(t)
+		globalConfigRepoMock.EXPECT().Get(testCtx).Return(config.GlobalConfig{}, assert.AnError)
+		ingressControllerMock := newMockIngressController(t)
+		ingressControllerMock.EXPECT().UsesMiddlewares().Return(true)
+
+		sut := ingressUpdater{controller: ingressControllerMock, globalConfigRepository: globalConfigRepoMock}
+
+		// when
+		err := sut.UpsertGlobalMiddlewares(testCtx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get global config")
+	})
+}
+
+func Test_ingressUpdater_deleteStaleIngresses(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace, UID: "service-uid"},
+	}
+	ownedBy := func(name string, uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Name: name, UID: uid}}
+	}
+	cesServices := []resolvedCesService{{CesService: CesService{Name: "test", Port: 8080, Location: "/test", Pass: "/test"}}}
+
+	t.Run("should delete ingresses of the service which do not belong to a ces service anymore", func(t *testing.T) {
+		// given
+		ingressList := &v1.IngressList{Items: []v1.Ingress{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test", OwnerReferences: ownedBy("test", "service-uid")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-old", OwnerReferences: ownedBy("test", "service-uid")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "other", OwnerReferences: ownedBy("other", "other-uid")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "ces-alternative-fqdn"}},
+		}}
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "test-old", metav1.DeleteOptions{}).Return(nil)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale ingress [%s] as the ces service no longer exists.", "test-old")
+
+		sut := ingressUpdater{
+			ingressInterface: ingressInterfaceMock,
+			doguInterface:    doguInterfaceMock,
+			eventRecorder:    recorderMock,
+		}
+
+		// when
+		err := sut.deleteStaleIngresses(testCtx, service, cesServices, ingressList.Items)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should delete all ingresses of the service if there are no ces services", func(t *testing.T) {
+		// given
+		ingressList := &v1.IngressList{Items: []v1.Ingress{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test", OwnerReferences: ownedBy("test", "service-uid")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-old", OwnerReferences: ownedBy("test", "service-uid")}},
+		}}
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "test", metav1.DeleteOptions{}).Return(nil)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "test-old", metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "test-old"))
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil).Once()
+		recorderMock := newMockEventRecorder(t)
+		recorderMock.EXPECT().Eventf(dogu, "Normal", "IngressDeletion", "Deleted stale ingress [%s] as the ces service no longer exists.", mock.Anything).Twice()
+
+		sut := ingressUpdater{
+			ingressInterface: ingressInterfaceMock,
+			doguInterface:    doguInterfaceMock,
+			eventRecorder:    recorderMock,
+		}
+
+		// when
+		err := sut.deleteStaleIngresses(testCtx, service, nil, ingressList.Items)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail to get dogu for event", func(t *testing.T) {
+		// given
+		ingressList := &v1.IngressList{Items: []v1.Ingress{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-old", OwnerReferences: ownedBy("test", "service-uid")}},
+		}}
+
+		ingressInterfaceMock := newMockIngressInterface(t)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := ingressUpdater{
+			ingressInterface: ingressInterfaceMock,
+			doguInterface:    doguInterfaceMock,
+		}
+
+		// when
+		err := sut.deleteStaleIngresses(testCtx, service, cesServices, ingressList.Items)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get dogu for service [test]")
+	})
+	t.Run("should fail to delete stale ingress", func(t *testing.T) {
+		// given
+		ingressList := &v1.IngressList{Items: []v1.Ingress{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-old", OwnerReferences: ownedBy("test", "service-uid")}},
+		}}
+		dogu := &doguv2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}}
+
+		ingressInterfaceMock := newMockIngressInterface(t)
+		ingressInterfaceMock.EXPECT().Delete(testCtx, "test-old", metav1.DeleteOptions{}).Return(assert.AnError)
+		doguInterfaceMock := newMockDoguInterface(t)
+		doguInterfaceMock.EXPECT().Get(testCtx, "test", metav1.GetOptions{}).Return(dogu, nil)
+
+		sut := ingressUpdater{
+			ingressInterface: ingressInterfaceMock,
+			doguInterface:    doguInterfaceMock,
+		}
+
+		// when
+		err := sut.deleteStaleIngresses(testCtx, service, cesServices, ingressList.Items)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete ingress test-old")
+	})
+}
+
+func TestCesService_getRewriteConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		rewrite string
+		want    *serviceRewrite
+		wantErr func(t *testing.T, err error)
+	}{
+		{
+			name:    "should fail to unmarshal invalid rewrite",
+			rewrite: "{\"pattern\": \"portainer\", \"rewrite\": \"\"",
+			want:    nil,
+			wantErr: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "failed to read service rewrite from ces service: unexpected end of JSON input")
+			},
+		},
+		{
+			name:    "should succeed to generate rewrite config",
+			rewrite: "{\"pattern\": \"portainer\", \"rewrite\": \"p\"}",
+			want:    &serviceRewrite{Pattern: "portainer", Rewrite: "p"},
+			wantErr: func(t *testing.T, err error) { require.NoError(t, err) },
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -587,11 +1568,19 @@ func TestCesService_getRewriteConfig(t *testing.T) {
 func getTestIngress(ingressName string, path string, service corev1.Service, targetServiceName string, targetPort int32, annotations map[string]string) *v1.Ingress {
 	pathType := v1.PathTypePrefix
 	ingressClassName := testIngressClassName
+	ingressAnnotations := map[string]string{"traefik.ingress.kubernetes.io/router.priority": getRouterPriority(path)}
+	for key, value := range annotations {
+		ingressAnnotations[key] = value
+	}
 	return &v1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "Ingress",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ingressName,
 			Namespace:   testNamespace,
-			Annotations: annotations,
+			Annotations: ingressAnnotations,
 			Labels:      util.K8sCesServiceDiscoveryLabels,
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: service.APIVersion,
@@ -627,3 +1616,75 @@ func getTestIngress(ingressName string, path string, service corev1.Service, tar
 		},
 	}
 }
+
+func withTestHost(ingress *v1.Ingress, host string) *v1.Ingress {
+	ingress.Spec.Rules[0].Host = host
+	ingress.Spec.TLS = []v1.IngressTLS{{Hosts: []string{host}, SecretName: "ecosystem-certificate"}}
+
+	return ingress
+}
+
+func expectApplyIngress(t *testing.T, ingressInterfaceMock *mockIngressInterface, expectedIngress *v1.Ingress) {
+	ingressInterfaceMock.EXPECT().Get(testCtx, expectedIngress.Name, metav1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedIngress.Name))
+	ingressInterfaceMock.EXPECT().Patch(testCtx, expectedIngress.Name, types.ApplyPatchType, mock.Anything, metav1.PatchOptions{FieldManager: "k8s-service-discovery"}).
+		Return(nil, nil).
+		Run(func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) {
+			appliedIngress := &v1.Ingress{}
+			require.NoError(t, json.Unmarshal(data, appliedIngress))
+			assert.Equal(t, expectedIngress, appliedIngress)
+		})
+}
+
+func TestCesService_getReplacePath(t *testing.T) {
+	tests := []struct {
+		name           string
+		cesService     CesService
+		wantPathPrefix string
+		wantTargetPath string
+	}{
+		{
+			name:           "should use location and pass",
+			cesService:     CesService{Location: "/redmine/", Pass: "/"},
+			wantPathPrefix: "/redmine",
+			wantTargetPath: "/",
+		},
+		{
+			name:           "should use empty prefix for the root path",
+			cesService:     CesService{Location: "/", Pass: "/nexus"},
+			wantPathPrefix: "",
+			wantTargetPath: "/nexus",
+		},
+		{
+			name:           "should use pattern and rewrite of the rewrite config",
+			cesService:     CesService{Location: "/v2", Pass: "/v2", Rewrite: "{\"pattern\":\"v2\",\"rewrite\":\"/repository/\"}"},
+			wantPathPrefix: "/v2",
+			wantTargetPath: "/repository",
+		},
+		{
+			name:           "should rewrite to the root path for an empty rewrite",
+			cesService:     CesService{Location: "/portainer", Pass: "/portainer", Rewrite: "{\"pattern\":\"/portainer/\",\"rewrite\":\"\"}"},
+			wantPathPrefix: "/portainer",
+			wantTargetPath: "/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			pathPrefix, targetPath, err := tt.cesService.getReplacePath()
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPathPrefix, pathPrefix)
+			assert.Equal(t, tt.wantTargetPath, targetPath)
+		})
+	}
+
+	t.Run("should fail for invalid rewrite config", func(t *testing.T) {
+		// when
+		_, _, err := CesService{Rewrite: "{\"pattern\":"}.getReplacePath()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read service rewrite from ces service")
+	})
+}
@@ -2,51 +2,102 @@ package expose
 
 import (
 	"context"
+	"time"
 
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	traefikv1alpha1 "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/generated/clientset/versioned/typed/traefikio/v1alpha1"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	netv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
 	"k8s.io/client-go/tools/record"
+	gatewayv1client "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/typed/apis/v1"
 )
 
 type maintenanceAdapter interface {
 	GetStatus(ctx context.Context) (repository.MaintenanceModeDescription, bool, error)
 }
 
+// RoutingUpdater exposes the dogus with the routing objects of an ingress controller, e.g., ingress objects, traefik
+// ingress routes or http routes.
+type RoutingUpdater interface {
+	// UpsertIngressForService creates or updates the routing objects of the given service.
+	UpsertIngressForService(ctx context.Context, service *corev1.Service) error
+	// UpsertGlobalMiddlewares creates or updates the global middlewares of the global config which are part of the
+	// routing objects of all services.
+	UpsertGlobalMiddlewares(ctx context.Context) error
+	// UpdateCanary advances the canary of the given dogu service after an upgrade of the given deployment. It returns
+	// the time until the next step of the canary is due or zero if no step is due.
+	UpdateCanary(ctx context.Context, service *corev1.Service, deployment *apps.Deployment) (time.Duration, error)
+}
+
 // DeploymentReadyChecker checks the readiness from deployments.
 type DeploymentReadyChecker interface {
 	// IsReady checks whether the application of the deployment is ready, i.e., contains at least one ready pod.
 	IsReady(ctx context.Context, deploymentName string) (bool, error)
 }
 
+// GlobalConfigRepository is used to get the global config of the ecosystem, e.g., to read the fqdn.
+type GlobalConfigRepository interface {
+	Get(context.Context) (libconfig.GlobalConfig, error)
+	Watch(context.Context, ...libconfig.WatchFilter) (<-chan repository.GlobalConfigWatchResult, error)
+	Update(ctx context.Context, globalConfig libconfig.GlobalConfig) (libconfig.GlobalConfig, error)
+}
+
+type doguConfigRepository interface {
+	Get(ctx context.Context, name cescommons.SimpleName) (libconfig.DoguConfig, error)
+}
+
 type eventRecorder interface {
 	record.EventRecorder
 }
 
-// used for mocks
-
-//nolint:unused
-//goland:noinspection GoUnusedType
 type deploymentInterface interface {
 	appsv1.DeploymentInterface
 }
 
+// used for mocks
+
 //nolint:unused
 //goland:noinspection GoUnusedType
 type ingressInterface interface {
 	netv1.IngressInterface
 }
 
+type serviceInterface interface {
+	corev1client.ServiceInterface
+}
+
+type podInterface interface {
+	corev1client.PodInterface
+}
+
+type httpRouteInterface interface {
+	gatewayv1client.HTTPRouteInterface
+}
+
 type doguInterface interface {
 	doguClient.DoguInterface
 }
 
 type ingressController interface {
 	GetName() string
-	GetRewriteAnnotationKey() string
+	// UsesMiddlewares returns true if the controller rewrites paths with traefik middlewares.
+	UsesMiddlewares() bool
+	// GetPathRewriteAnnotations returns the annotations rewriting the requests of a regex ingress path either by the
+	// given middleware or to the given target path.
+	GetPathRewriteAnnotations(namespace string, middlewareName string, targetPath string) map[string]string
+	// GetMaintenanceModeAnnotations returns the annotations serving the maintenance page of the static content backend.
+	GetMaintenanceModeAnnotations(namespace string) map[string]string
+	// GetDoguStartingAnnotations returns the annotations serving the page of starting dogus of the static content
+	// backend.
+	GetDoguStartingAnnotations(namespace string) map[string]string
 }
 
 type networkPolicyInterface interface {
@@ -54,8 +105,11 @@ type networkPolicyInterface interface {
 }
 
 type middlewareManager interface {
-	createOrUpdateReplacePathMiddleware(ctx context.Context, serviceName string, cesService CesService, ownerReferences []v1.OwnerReference) (string, error)
+	createOrUpdateReplacePathMiddleware(ctx context.Context, serviceName string, cesService resolvedCesService, ownerReferences []v1.OwnerReference) (string, error)
+	createOrUpdateMiddleware(ctx context.Context, name string, spec traefikapi.MiddlewareSpec, ownerReferences []v1.OwnerReference) error
 	CreateOrUpdateAlternativeFQDNRedirectMiddleware(ctx context.Context, alternativeFQDNs []string, primaryFQDN string, ownerReferences []v1.OwnerReference) (string, error)
+	UpsertGlobalMiddlewares(ctx context.Context, globalConfig libconfig.GlobalConfig) error
+	deleteOrphanedMiddlewares(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService) error
 }
 
 //nolint:unused
@@ -64,6 +118,18 @@ type middlewareInterface interface {
 	traefikv1alpha1.MiddlewareInterface
 }
 
+type ingressRouteInterface interface {
+	traefikv1alpha1.IngressRouteInterface
+}
+
+type traefikServiceInterface interface {
+	traefikv1alpha1.TraefikServiceInterface
+}
+
+type serversTransportInterface interface {
+	traefikv1alpha1.ServersTransportInterface
+}
+
 type traefikInterface interface {
 	traefikv1alpha1.TraefikV1alpha1Interface
 }
@@ -0,0 +1,99 @@
+package expose
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+const (
+	// doguConfigIPAllowListKey is the dogu config key with the comma separated ip ranges allowed to access a ces
+	// service, e.g., `ingress/adminer/ip_allowlist`.
+	doguConfigIPAllowListKey = "ingress/%s/ip_allowlist"
+	// doguConfigIPAllowListDepthKey is the dogu config key with the depth of the `X-Forwarded-For` header of the ip
+	// allowlist of a ces service. Defaults to the depth of the global config.
+	doguConfigIPAllowListDepthKey = "ingress/%s/ip_allowlist_depth"
+)
+
+const ipAllowListMiddlewareSuffix = "ipallowlist"
+
+// ipAllowList restricts the access to the given source ranges. The client ip is taken from the `X-Forwarded-For`
+// header at the given depth, e.g., for clients behind proxies, or from the remote address if the depth is zero.
+type ipAllowList struct {
+	sourceRanges []string
+	depth        int
+}
+
+// parseIPAllowList parses the comma separated ip ranges and the depth of an ip allowlist from the values of the
+// given config keys. The ip ranges are either ip addresses or CIDRs.
+func parseIPAllowList(sourceRanges string, sourceRangesKey string, depth string, depthKey string) (ipAllowList, error) {
+	allowList := ipAllowList{}
+	for _, sourceRange := range strings.Split(sourceRanges, ",") {
+		sourceRange = strings.TrimSpace(sourceRange)
+		if sourceRange == "" {
+			continue
+		}
+
+		if _, err := netip.ParsePrefix(sourceRange); err != nil {
+			if _, err = netip.ParseAddr(sourceRange); err != nil {
+				return ipAllowList{}, fmt.Errorf("invalid ip range [%s] of config key [%s]", sourceRange, sourceRangesKey)
+			}
+		}
+
+		allowList.sourceRanges = append(allowList.sourceRanges, sourceRange)
+	}
+
+	parsedDepth, err := parseIPAllowListDepth(depth, depthKey)
+	if err != nil {
+		return ipAllowList{}, err
+	}
+
+	allowList.depth = parsedDepth
+	return allowList, nil
+}
+
+func parseIPAllowListDepth(depth string, depthKey string) (int, error) {
+	if depth == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(depth)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid value [%s] of config key [%s]: expected depth", depth, depthKey)
+	}
+
+	return parsed, nil
+}
+
+// getDoguIPAllowList returns the ip allowlist of the given ces service in the given dogu config. The depth defaults
+// to the given depth of the global ip allowlist.
+func getDoguIPAllowList(doguConfig libconfig.DoguConfig, cesServiceName string, globalDepth int) (ipAllowList, error) {
+	sourceRangesKey := libconfig.Key(fmt.Sprintf(doguConfigIPAllowListKey, cesServiceName))
+	depthKey := libconfig.Key(fmt.Sprintf(doguConfigIPAllowListDepthKey, cesServiceName))
+
+	sourceRanges, _ := doguConfig.Get(sourceRangesKey)
+	depth, ok := doguConfig.Get(depthKey)
+	if !ok {
+		depth = libconfig.Value(strconv.Itoa(globalDepth))
+	}
+
+	return parseIPAllowList(sourceRanges.String(), string(sourceRangesKey), depth.String(), string(depthKey))
+}
+
+func (l ipAllowList) isEnabled() bool {
+	return len(l.sourceRanges) > 0
+}
+
+func (l ipAllowList) getMiddleware() traefikapi.MiddlewareSpec {
+	allowList := &dynamic.IPAllowList{SourceRange: l.sourceRanges}
+	if l.depth > 0 {
+		allowList.IPStrategy = &dynamic.IPStrategy{Depth: l.depth}
+	}
+
+	return traefikapi.MiddlewareSpec{IPAllowList: allowList}
+}
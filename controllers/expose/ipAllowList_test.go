@@ -0,0 +1,89 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+func Test_getDoguIPAllowList(t *testing.T) {
+	t.Run("should return disabled allowlist without dogu config", func(t *testing.T) {
+		// when
+		actual, err := getDoguIPAllowList(config.DoguConfig{}, "adminer", 0)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, actual.isEnabled())
+	})
+	t.Run("should use the depth of the global config by default", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("adminer", config.Entries{"ingress/adminer/ip_allowlist": "10.8.0.0/16,fd00::/8"})
+
+		// when
+		actual, err := getDoguIPAllowList(doguConfig, "adminer", 2)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ipAllowList{sourceRanges: []string{"10.8.0.0/16", "fd00::/8"}, depth: 2}, actual)
+	})
+	t.Run("should override the depth of the global config", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("adminer", config.Entries{
+			"ingress/adminer/ip_allowlist":       "10.8.0.1",
+			"ingress/adminer/ip_allowlist_depth": "0",
+		})
+
+		// when
+		actual, err := getDoguIPAllowList(doguConfig, "adminer", 2)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ipAllowList{sourceRanges: []string{"10.8.0.1"}, depth: 0}, actual)
+	})
+	t.Run("should fail for invalid ip range", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("adminer", config.Entries{"ingress/adminer/ip_allowlist": "10.8.0.0/16,vpn"})
+
+		// when
+		_, err := getDoguIPAllowList(doguConfig, "adminer", 0)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid ip range [vpn] of config key [ingress/adminer/ip_allowlist]")
+	})
+	t.Run("should fail for invalid depth", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("adminer", config.Entries{"ingress/adminer/ip_allowlist_depth": "deep"})
+
+		// when
+		_, err := getDoguIPAllowList(doguConfig, "adminer", 0)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [deep] of config key [ingress/adminer/ip_allowlist_depth]: expected depth")
+	})
+}
+
+func Test_ipAllowList_getMiddleware(t *testing.T) {
+	t.Run("should use the remote address without depth", func(t *testing.T) {
+		// when
+		actual := ipAllowList{sourceRanges: []string{"10.0.0.0/8"}}.getMiddleware()
+
+		// then
+		assert.Equal(t, traefikapi.MiddlewareSpec{IPAllowList: &dynamic.IPAllowList{SourceRange: []string{"10.0.0.0/8"}}}, actual)
+	})
+	t.Run("should use the forwarded header at the depth", func(t *testing.T) {
+		// when
+		actual := ipAllowList{sourceRanges: []string{"10.0.0.0/8"}, depth: 1}.getMiddleware()
+
+		// then
+		assert.Equal(t, traefikapi.MiddlewareSpec{IPAllowList: &dynamic.IPAllowList{
+			SourceRange: []string{"10.0.0.0/8"},
+			IPStrategy:  &dynamic.IPStrategy{Depth: 1},
+		}}, actual)
+	})
+}
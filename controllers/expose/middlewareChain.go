@@ -0,0 +1,75 @@
+package expose
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// GlobalConfigMiddlewaresKey is the global config key with the comma separated default middlewares of all dogus,
+	// e.g., `ecosystem-compress@kubernetescrd`.
+	GlobalConfigMiddlewaresKey = "ingress/middlewares"
+	// doguConfigIngressMiddlewaresKey is the dogu config key which overrides the middlewares of a ces service, e.g.,
+	// `ingress/nexus/middlewares`.
+	doguConfigIngressMiddlewaresKey = "ingress/%s/middlewares"
+	// middlewareOverrideRemovePrefix marks a middleware of an override which is removed from the chain.
+	middlewareOverrideRemovePrefix = "-"
+)
+
+// middlewareChain is the ordered list of the middlewares of a router in the format of the traefik router annotation,
+// e.g., `<namespace>-<name>@kubernetescrd` or `compress@file`. The middlewares are added in the order of their
+// sources: the global middlewares, the ip allowlist and the request limits, the forward auth, the rewrites of the
+// service discovery, the global default middlewares, the middlewares of the dogu, the overrides of the administrator
+// and the error pages. A middleware added twice keeps its first position.
+type middlewareChain struct {
+	middlewares []string
+}
+
+// getCRDMiddlewareRef returns the reference of the kubernetes crd middleware with the given name in the given
+// namespace.
+func getCRDMiddlewareRef(namespace string, name string) string {
+	return fmt.Sprintf("%s-%s%s", namespace, name, kubernetesCRDProviderSuffix)
+}
+
+// add appends the given middlewares which are not yet part of the chain.
+func (c *middlewareChain) add(middlewares ...string) {
+	for _, middleware := range middlewares {
+		middleware = strings.TrimSpace(middleware)
+		if middleware == "" || slices.Contains(c.middlewares, middleware) {
+			continue
+		}
+
+		c.middlewares = append(c.middlewares, middleware)
+	}
+}
+
+// addAnnotation appends the comma separated middlewares of the given router annotation value.
+func (c *middlewareChain) addAnnotation(value string) {
+	c.add(strings.Split(value, ",")...)
+}
+
+// applyOverrides applies the comma separated middleware overrides of an administrator. Middlewares prefixed with `-`
+// are removed from the chain, all others are appended.
+func (c *middlewareChain) applyOverrides(value string) {
+	for _, middleware := range strings.Split(value, ",") {
+		middleware = strings.TrimSpace(middleware)
+		if removed, ok := strings.CutPrefix(middleware, middlewareOverrideRemovePrefix); ok {
+			c.middlewares = slices.DeleteFunc(c.middlewares, func(existing string) bool {
+				return existing == strings.TrimSpace(removed)
+			})
+			continue
+		}
+
+		c.add(middleware)
+	}
+}
+
+func (c *middlewareChain) isEmpty() bool {
+	return len(c.middlewares) == 0
+}
+
+// String returns the comma separated middlewares of the chain as value of the router annotation.
+func (c *middlewareChain) String() string {
+	return strings.Join(c.middlewares, ",")
+}
@@ -0,0 +1,147 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_middlewareChain(t *testing.T) {
+	t.Run("should keep the order of the added middlewares", func(t *testing.T) {
+		// given
+		chain := &middlewareChain{}
+
+		// when
+		chain.add("my-namespace-nexus-nexus-rewrite@kubernetescrd")
+		chain.addAnnotation("compress@file, my-namespace-auth@kubernetescrd")
+
+		// then
+		assert.Equal(t, "my-namespace-nexus-nexus-rewrite@kubernetescrd,compress@file,my-namespace-auth@kubernetescrd", chain.String())
+	})
+	t.Run("should keep the first position of duplicate middlewares", func(t *testing.T) {
+		// given
+		chain := &middlewareChain{}
+
+		// when
+		chain.add("compress@file", "my-namespace-auth@kubernetescrd")
+		chain.addAnnotation("my-namespace-auth@kubernetescrd,compress@file,headers@file")
+
+		// then
+		assert.Equal(t, "compress@file,my-namespace-auth@kubernetescrd,headers@file", chain.String())
+	})
+	t.Run("should ignore empty middlewares", func(t *testing.T) {
+		// given
+		chain := &middlewareChain{}
+
+		// when
+		chain.addAnnotation("")
+		chain.addAnnotation(" , ")
+		chain.applyOverrides("")
+
+		// then
+		assert.True(t, chain.isEmpty())
+		assert.Empty(t, chain.String())
+	})
+	t.Run("should remove and append middlewares of the overrides", func(t *testing.T) {
+		// given
+		chain := &middlewareChain{}
+		chain.add("my-namespace-nexus-nexus-rewrite@kubernetescrd", "compress@file")
+
+		// when
+		chain.applyOverrides("-compress@file, auth@file, my-namespace-nexus-nexus-rewrite@kubernetescrd, -unknown@file")
+
+		// then
+		assert.Equal(t, "my-namespace-nexus-nexus-rewrite@kubernetescrd,auth@file", chain.String())
+	})
+}
+
+func Test_ingressUpdater_getMiddlewareChain(t *testing.T) {
+	t.Run("should compose the middlewares of all sources in order", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:            CesService{Name: "nexus"},
+			globalMiddlewares:     []string{"my-namespace-global-security-headers@kubernetescrd"},
+			forwardAuthMiddleware: "my-namespace-forward-auth@kubernetescrd",
+			defaultMiddlewares:    "my-namespace-hsts@kubernetescrd,compress@file",
+			middlewareOverrides:   "-compress@file,my-namespace-ip-allowlist@kubernetescrd",
+		}
+		sut := ingressUpdater{namespace: testNamespace}
+
+		// when
+		chain := sut.getMiddlewareChain(cesService, serviceMiddlewareRefs{}, []string{"my-namespace-nexus-nexus-rewrite@kubernetescrd"}, "my-namespace-auth@kubernetescrd,my-namespace-hsts@kubernetescrd")
+
+		// then
+		assert.Equal(t, "my-namespace-global-security-headers@kubernetescrd,my-namespace-forward-auth@kubernetescrd,my-namespace-nexus-nexus-rewrite@kubernetescrd,my-namespace-hsts@kubernetescrd,my-namespace-auth@kubernetescrd,my-namespace-ip-allowlist@kubernetescrd", chain.String())
+	})
+	t.Run("should place the forward auth after the ip allowlist and the request limits", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:            CesService{Name: "nexus"},
+			forwardAuthMiddleware: "my-namespace-forward-auth@kubernetescrd",
+		}
+		serviceMiddlewares := serviceMiddlewareRefs{access: []string{
+			"my-namespace-nexus-nexus-ipallowlist@kubernetescrd",
+			"my-namespace-nexus-nexus-ratelimit@kubernetescrd",
+			"my-namespace-nexus-nexus-inflightreq@kubernetescrd",
+		}}
+		sut := ingressUpdater{namespace: testNamespace}
+
+		// when
+		chain := sut.getMiddlewareChain(cesService, serviceMiddlewares, []string{"my-namespace-nexus-nexus-rewrite@kubernetescrd"}, "")
+
+		// then
+		assert.Equal(t, []string{
+			"my-namespace-nexus-nexus-ipallowlist@kubernetescrd",
+			"my-namespace-nexus-nexus-ratelimit@kubernetescrd",
+			"my-namespace-nexus-nexus-inflightreq@kubernetescrd",
+			"my-namespace-forward-auth@kubernetescrd",
+			"my-namespace-nexus-nexus-rewrite@kubernetescrd",
+		}, chain.middlewares)
+	})
+	t.Run("should compose the full chain with the error pages last", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:            CesService{Name: "nexus"},
+			globalMiddlewares:     []string{"my-namespace-global-ip-allowlist@kubernetescrd", "my-namespace-global-security-headers@kubernetescrd"},
+			forwardAuthMiddleware: "my-namespace-forward-auth@kubernetescrd",
+			defaultMiddlewares:    "compress@file",
+			middlewareOverrides:   "headers@file",
+		}
+		serviceMiddlewares := serviceMiddlewareRefs{
+			access:     []string{"my-namespace-nexus-nexus-ipallowlist@kubernetescrd", "my-namespace-nexus-nexus-ratelimit@kubernetescrd"},
+			errorPages: []string{"my-namespace-nexus-nexus-errors@kubernetescrd"},
+		}
+		sut := ingressUpdater{namespace: testNamespace}
+
+		// when
+		chain := sut.getMiddlewareChain(cesService, serviceMiddlewares, []string{"my-namespace-nexus-nexus-rewrite@kubernetescrd", "my-namespace-nexus-nexus-buffering@kubernetescrd"}, "my-namespace-auth@kubernetescrd")
+
+		// then
+		assert.Equal(t, []string{
+			"my-namespace-global-ip-allowlist@kubernetescrd",
+			"my-namespace-global-security-headers@kubernetescrd",
+			"my-namespace-nexus-nexus-ipallowlist@kubernetescrd",
+			"my-namespace-nexus-nexus-ratelimit@kubernetescrd",
+			"my-namespace-forward-auth@kubernetescrd",
+			"my-namespace-nexus-nexus-rewrite@kubernetescrd",
+			"my-namespace-nexus-nexus-buffering@kubernetescrd",
+			"compress@file",
+			"my-namespace-auth@kubernetescrd",
+			"headers@file",
+			"my-namespace-nexus-nexus-errors@kubernetescrd",
+		}, chain.middlewares)
+	})
+}
+
+func Test_getStaticPageMiddlewareChain(t *testing.T) {
+	t.Run("should add the global middlewares before the middleware of the page", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{Name: "nexus"}, globalMiddlewares: []string{"my-namespace-global-security-headers@kubernetescrd"}}
+
+		// when
+		chain := getStaticPageMiddlewareChain(cesService, "my-namespace-maintenance-mode@kubernetescrd")
+
+		// then
+		assert.Equal(t, "my-namespace-global-security-headers@kubernetescrd,my-namespace-maintenance-mode@kubernetescrd", chain.String())
+	})
+}
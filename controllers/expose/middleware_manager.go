@@ -4,71 +4,267 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"slices"
 	"strings"
 
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 type MiddlewareManager struct {
-	client    middlewareInterface
-	namespace string
+	client             middlewareInterface
+	ingressRouteClient ingressRouteInterface
+	namespace          string
+	recorder           eventRecorder
 }
 
-func NewMiddlewareManager(traefikClient traefikInterface, namespace string) *MiddlewareManager {
+func NewMiddlewareManager(traefikClient traefikInterface, namespace string, recorder eventRecorder) *MiddlewareManager {
 	return &MiddlewareManager{
-		client:    traefikClient.Middlewares(namespace),
-		namespace: namespace,
+		client:             traefikClient.Middlewares(namespace),
+		ingressRouteClient: traefikClient.IngressRoutes(namespace),
+		namespace:          namespace,
+		recorder:           recorder,
 	}
 }
 
-// createOrUpdateReplacePathMiddleware creates or updates a Traefik Middleware CR for path replacement
-func (m *MiddlewareManager) createOrUpdateReplacePathMiddleware(ctx context.Context, serviceName string, cesService CesService, ownerReferences []v1.OwnerReference) (string, error) {
-	middlewareName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
-	targetPath := path.Join(cesService.Pass, "$2")
+// createOrUpdateReplacePathMiddleware creates or updates a Traefik Middleware CR for path replacement. The middleware
+// rewrites the pattern of the rewrite config of the ces service or otherwise its location to the target path.
+func (m *MiddlewareManager) createOrUpdateReplacePathMiddleware(ctx context.Context, serviceName string, cesService resolvedCesService, ownerReferences []v1.OwnerReference) (string, error) {
+	middlewareName := getReplacePathMiddlewareName(serviceName, cesService)
+	pathPrefix, targetPath, err := cesService.getReplacePath()
+	if err != nil {
+		return "", fmt.Errorf("failed to get replace path of ces service %s: %w", cesService.Name, err)
+	}
+
+	spec := traefikapi.MiddlewareSpec{
+		ReplacePathRegex: &dynamic.ReplacePathRegex{
+			Regex:       fmt.Sprintf("^%s(/|$)(.*)", pathPrefix),
+			Replacement: path.Join(targetPath, "$2"),
+		},
+	}
+
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Applying middleware [%s] for service [%s]", middlewareName, serviceName))
+	err = m.createOrUpdateMiddleware(ctx, middlewareName, spec, ownerReferences)
+	if err != nil {
+		return "", err
+	}
+
+	return middlewareName, nil
+}
 
+// createOrUpdateMiddleware creates or updates the managed Traefik Middleware CR with the given name and spec.
+func (m *MiddlewareManager) createOrUpdateMiddleware(ctx context.Context, name string, spec traefikapi.MiddlewareSpec, ownerReferences []v1.OwnerReference) error {
 	middleware := &traefikapi.Middleware{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       "Middleware",
+		},
 		ObjectMeta: v1.ObjectMeta{
-			Name:            middlewareName,
+			Name:            name,
 			Namespace:       m.namespace,
+			Labels:          util.K8sCesServiceDiscoveryLabels,
 			OwnerReferences: ownerReferences,
 		},
-		Spec: traefikapi.MiddlewareSpec{
-			ReplacePathRegex: &dynamic.ReplacePathRegex{
-				Regex:       fmt.Sprintf("^%s(/|$)(.*)", strings.TrimRight(cesService.Location, "/")),
-				Replacement: targetPath,
-			},
-		},
+		Spec: spec,
+	}
+
+	_, err := util.ServerSideApply[*traefikapi.Middleware](ctx, m.client, middleware, m.recorder)
+	if err != nil {
+		return fmt.Errorf("failed to apply middleware: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertGlobalMiddlewares creates or updates the global middlewares configured in the given global config, e.g., the
+// security headers, the https redirect and the forward auth middleware and deletes the ones which are no longer
+// configured. The global middlewares are part of the routes of all dogus and the forward auth middleware is shared by
+// the ces services with forward auth, so they are not owned by any service. The https redirect is served by a
+// catch-all ingress route of the http entrypoint.
+//
+// The global middlewares have fixed names, so only middlewares with the labels of the service discovery are deleted.
+// Middlewares of the same name created by users are kept.
+func (m *MiddlewareManager) UpsertGlobalMiddlewares(ctx context.Context, globalConfig libconfig.GlobalConfig) error {
+	middlewares, err := getGlobalMiddlewares(globalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get global middlewares: %w", err)
 	}
 
-	// Try to get existing middleware
-	existing, err := m.client.Get(ctx, middlewareName, v1.GetOptions{})
+	httpsRedirect, err := getHTTPSRedirectMiddleware(globalConfig)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// Create new middleware
-			ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Creating middleware [%s] for service [%s]", middlewareName, serviceName))
-			_, createErr := m.client.Create(ctx, middleware, v1.CreateOptions{})
-			if createErr != nil {
-				return "", fmt.Errorf("failed to create middleware: %w", createErr)
+		return fmt.Errorf("failed to get https redirect middleware: %w", err)
+	}
+
+	if httpsRedirect != nil {
+		middlewares = append(middlewares, *httpsRedirect)
+	} else {
+		// the route is deleted before its middleware, so traefik never routes to a missing middleware
+		err = deleteManagedObject[*traefikapi.IngressRoute](ctx, m.ingressRouteClient, httpsRedirectRouteName)
+		if err != nil {
+			return fmt.Errorf("failed to delete https redirect ingress route %s: %w", httpsRedirectRouteName, err)
+		}
+	}
+
+	forwardAuth, err := getForwardAuthMiddleware(globalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get forward auth middleware: %w", err)
+	}
+
+	if forwardAuth != nil {
+		middlewares = append(middlewares, *forwardAuth)
+	}
+
+	configured := make(map[string]traefikapi.MiddlewareSpec, len(middlewares))
+	for _, middleware := range middlewares {
+		configured[middleware.name] = middleware.spec
+	}
+
+	for _, name := range append(slices.Clone(globalMiddlewareNames), httpsRedirectMiddlewareName, forwardAuthMiddlewareName) {
+		spec, ok := configured[name]
+		if ok {
+			ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Applying global middleware [%s]", name))
+			err = m.createOrUpdateMiddleware(ctx, name, spec, nil)
+			if err != nil {
+				return fmt.Errorf("failed to create/update global middleware %s: %w", name, err)
 			}
-			return middlewareName, nil
+
+			continue
+		}
+
+		err = deleteManagedObject[*traefikapi.Middleware](ctx, m.client, name)
+		if err != nil {
+			return fmt.Errorf("failed to delete global middleware %s: %w", name, err)
 		}
-		return "", fmt.Errorf("failed to get middleware: %w", err)
 	}
 
-	// Update existing middleware
-	middleware.ResourceVersion = existing.ResourceVersion
-	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Updating middleware [%s] for service [%s]", middlewareName, serviceName))
-	_, updateErr := m.client.Update(ctx, middleware, v1.UpdateOptions{})
-	if updateErr != nil {
-		return "", fmt.Errorf("failed to update middleware: %w", updateErr)
+	if httpsRedirect == nil {
+		return nil
 	}
 
-	return middlewareName, nil
+	_, err = util.ServerSideApply[*traefikapi.IngressRoute](ctx, m.ingressRouteClient, m.getHTTPSRedirectRoute(), m.recorder)
+	if err != nil {
+		return fmt.Errorf("failed to apply https redirect ingress route %s: %w", httpsRedirectRouteName, err)
+	}
+
+	return nil
+}
+
+// managedObjectClient is implemented by all typed clients which are able to get and delete objects of type T.
+type managedObjectClient[T v1.Object] interface {
+	Get(ctx context.Context, name string, opts v1.GetOptions) (T, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+}
+
+// deleteManagedObject deletes the object with the given name if it carries the labels of the service discovery.
+// Objects without these labels were not created by the service discovery and are kept.
+func deleteManagedObject[T v1.Object](ctx context.Context, objectClient managedObjectClient[T], name string) error {
+	obj, err := objectClient.Get(ctx, name, v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).Matches(labels.Set(obj.GetLabels())) {
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Keeping [%s] without the labels of the service discovery", name))
+		return nil
+	}
+
+	err = objectClient.Delete(ctx, name, v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// getHTTPSRedirectRoute returns the catch-all ingress route of the http entrypoint which redirects all requests to
+// https. The routes of the dogus can't redirect, as their tls routers only serve the https entrypoint. The route has the
+// lowest priority, so other http routes, e.g., of acme challenges, take precedence. The redirect middleware answers
+// every request, so the route needs no real backend.
+func (m *MiddlewareManager) getHTTPSRedirectRoute() *traefikapi.IngressRoute {
+	return &traefikapi.IngressRoute{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       "IngressRoute",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      httpsRedirectRouteName,
+			Namespace: m.namespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+		},
+		Spec: traefikapi.IngressRouteSpec{
+			EntryPoints: []string{httpEntryPoint},
+			Routes: []traefikapi.Route{{
+				Match:       "PathPrefix(`/`)",
+				Kind:        "Rule",
+				Priority:    1,
+				Services:    []traefikapi.Service{{LoadBalancerSpec: traefikapi.LoadBalancerSpec{Name: noopServiceName, Kind: traefikServiceKind}}},
+				Middlewares: []traefikapi.MiddlewareRef{{Name: httpsRedirectMiddlewareName, Namespace: m.namespace}},
+			}},
+		},
+	}
+}
+
+// deleteOrphanedMiddlewares deletes the middlewares owned by the given service which are not needed by any of the
+// given ces services, e.g., because the ces service now has an equal pass and location and no rewrite config, an
+// nginx annotation of the dogu was removed or a request limit, ip allowlist or max body size was disabled.
+//
+// The middlewares are selected by their owner reference instead of the labels of the service discovery, as older
+// versions created the rewrite middlewares without these labels.
+func (m *MiddlewareManager) deleteOrphanedMiddlewares(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService) error {
+	middlewareList, err := m.client.List(ctx, v1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list middlewares: %w", err)
+	}
+
+	// the annotations were already validated when the ingresses were created, so invalid annotations require nothing
+	additionalAnnotations, _ := getAdditionalIngressAnnotations(service)
+	translation, _ := translateNginxAnnotations(additionalAnnotations, "")
+
+	requiredMiddlewares := make(map[string]struct{}, len(cesServices))
+	for _, cesService := range cesServices {
+		if cesService.needsReplacePathMiddleware() {
+			requiredMiddlewares[getReplacePathMiddlewareName(service.Name, cesService)] = struct{}{}
+		}
+
+		serviceSuffixes, _ := cesService.getServiceMiddlewares()
+		translatedSuffixes := cesService.backend.overrideMaxBodySize(translation).getMiddlewareSuffixes()
+		for _, suffix := range append(translatedSuffixes, serviceSuffixes...) {
+			requiredMiddlewares[getTranslatedMiddlewareName(service.Name, cesService, suffix)] = struct{}{}
+		}
+	}
+
+	for _, middleware := range middlewareList.Items {
+		if !isOwnedByService(middleware.GetOwnerReferences(), service) {
+			continue
+		}
+
+		if _, required := requiredMiddlewares[middleware.Name]; required {
+			continue
+		}
+
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Deleting orphaned middleware [%s] of service [%s]", middleware.Name, service.Name))
+		err = m.client.Delete(ctx, middleware.Name, v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete middleware %s: %w", middleware.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func getReplacePathMiddlewareName(serviceName string, cesService resolvedCesService) string {
+	return fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
 }
 
 // CreateOrUpdateAlternativeFQDNRedirectMiddleware creates or updates a Traefik Middleware CR for redirecting
@@ -88,6 +284,10 @@ func (m *MiddlewareManager) CreateOrUpdateAlternativeFQDNRedirectMiddleware(ctx
 	replacement := fmt.Sprintf("https://%s${2}", primaryFQDN)
 
 	middleware := &traefikapi.Middleware{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       "Middleware",
+		},
 		ObjectMeta: v1.ObjectMeta{
 			Name:            middlewareName,
 			Namespace:       m.namespace,
@@ -101,27 +301,10 @@ func (m *MiddlewareManager) CreateOrUpdateAlternativeFQDNRedirectMiddleware(ctx
 		},
 	}
 
-	// Try to get existing middleware
-	existing, err := m.client.Get(ctx, middlewareName, v1.GetOptions{})
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Applying alternative FQDN redirect middleware [%s] for FQDNs %v -> %s", middlewareName, alternativeFQDNs, primaryFQDN))
+	_, err := util.ServerSideApply[*traefikapi.Middleware](ctx, m.client, middleware, m.recorder)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// Create new middleware
-			ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Creating alternative FQDN redirect middleware [%s] for FQDNs %v -> %s", middlewareName, alternativeFQDNs, primaryFQDN))
-			_, createErr := m.client.Create(ctx, middleware, v1.CreateOptions{})
-			if createErr != nil {
-				return "", fmt.Errorf("failed to create alternative FQDN redirect middleware: %w", createErr)
-			}
-			return middlewareName, nil
-		}
-		return "", fmt.Errorf("failed to get middleware: %w", err)
-	}
-
-	// Update existing middleware
-	middleware.ResourceVersion = existing.ResourceVersion
-	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Updating alternative FQDN redirect middleware [%s] for FQDNs %v -> %s", middlewareName, alternativeFQDNs, primaryFQDN))
-	_, updateErr := m.client.Update(ctx, middleware, v1.UpdateOptions{})
-	if updateErr != nil {
-		return "", fmt.Errorf("failed to update alternative FQDN redirect middleware: %w", updateErr)
+		return "", fmt.Errorf("failed to apply alternative FQDN redirect middleware: %w", err)
 	}
 
 	return middlewareName, nil
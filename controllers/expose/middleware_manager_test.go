@@ -1,34 +1,64 @@
 package expose
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/cloudogu/k8s-dogu-operator/v3/controllers/annotation"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
+var testApplyOptions = v1.PatchOptions{FieldManager: "k8s-service-discovery"}
+
+// appliedMiddleware matches the apply patch of a middleware if the given check succeeds for the patched middleware.
+func appliedMiddleware(check func(mw *traefikapi.Middleware) bool) interface{} {
+	return mock.MatchedBy(func(data []byte) bool {
+		mw := &traefikapi.Middleware{}
+		if err := json.Unmarshal(data, mw); err != nil {
+			return false
+		}
+
+		return check(mw)
+	})
+}
+
 func TestMiddlewareManager_createOrUpdateReplacePathMiddleware(t *testing.T) {
-	t.Run("should create middleware when it does not exist", func(t *testing.T) {
+	cesService := resolvedCesService{CesService: CesService{
+		Name:     "test",
+		Port:     55,
+		Location: "/myLocation",
+		Pass:     "/myPass",
+	}}
+	serviceName := "my-service"
+	expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
+
+	t.Run("should apply middleware", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
-		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
 
 		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedName))
-		clientMock.EXPECT().Create(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.CreateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		clientMock.EXPECT().Patch(testCtx, expectedName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
+			// since the middleware ist never returned, it is validated here
+			return mw.APIVersion == "traefik.io/v1alpha1" &&
+				mw.Kind == "Middleware" &&
+				mw.Name == expectedName &&
+				mw.Namespace == "test-namespace" &&
+				mw.Labels["app.kubernetes.io/name"] == "k8s-service-discovery"
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
 		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
@@ -38,24 +68,23 @@ func TestMiddlewareManager_createOrUpdateReplacePathMiddleware(t *testing.T) {
 		assert.Equal(t, expectedName, result)
 	})
 
-	t.Run("should update middleware when it already exists", func(t *testing.T) {
+	t.Run("should skip apply when middleware is unchanged", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
+		liveMiddleware := &traefikapi.Middleware{
+			ObjectMeta: v1.ObjectMeta{
+				Name:            expectedName,
+				Namespace:       "test-namespace",
+				Labels:          map[string]string{"app": "ces", "app.kubernetes.io/name": "k8s-service-discovery"},
+				ResourceVersion: "42",
+			},
+			Spec: traefikapi.MiddlewareSpec{
+				ReplacePathRegex: &dynamic.ReplacePathRegex{Regex: "^/myLocation(/|$)(.*)", Replacement: "/myPass/$2"},
+			},
 		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
 
-		existingMiddleware := &traefikapi.Middleware{
-			ObjectMeta: v1.ObjectMeta{Name: expectedName, ResourceVersion: "42"},
-		}
-		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(existingMiddleware, nil)
-		clientMock.EXPECT().Update(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.UpdateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(liveMiddleware, nil)
 
 		// when
 		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
@@ -65,57 +94,36 @@ func TestMiddlewareManager_createOrUpdateReplacePathMiddleware(t *testing.T) {
 		assert.Equal(t, expectedName, result)
 	})
 
-	t.Run("should set resource version from existing middleware on update", func(t *testing.T) {
+	t.Run("should return error when live middleware cannot be fetched", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
-		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
-		expectedResourceVersion := "99"
 
-		existingMiddleware := &traefikapi.Middleware{
-			ObjectMeta: v1.ObjectMeta{Name: expectedName, ResourceVersion: expectedResourceVersion},
-		}
-		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(existingMiddleware, nil)
-		clientMock.EXPECT().Update(testCtx, mock.MatchedBy(func(mw *traefikapi.Middleware) bool {
-			// since the middleware ist never returned, it is validated here
-			return mw.ResourceVersion == expectedResourceVersion
-		}), v1.UpdateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, assert.AnError)
 
 		// when
-		_, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
+		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
 
 		// then
-		require.NoError(t, err)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get Middleware my-service-test-rewrite")
+		assert.Empty(t, result)
 	})
 
 	t.Run("should build correct regex with trailing slash stripped from location", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
-		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
 
 		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedName))
-		clientMock.EXPECT().Create(testCtx, mock.MatchedBy(func(mw *traefikapi.Middleware) bool {
+		clientMock.EXPECT().Patch(testCtx, expectedName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
 			// since the middleware ist never returned, it is validated here
 			spec := mw.Spec.ReplacePathRegex
 			return spec != nil &&
 				spec.Regex == "^/myLocation(/|$)(.*)" &&
 				spec.Replacement == "/myPass/$2"
-		}), v1.CreateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
 		_, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
@@ -124,25 +132,53 @@ func TestMiddlewareManager_createOrUpdateReplacePathMiddleware(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("should build regex and replacement from the rewrite config", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		rewriteService := resolvedCesService{CesService: CesService{Name: "test", Port: 55, Location: "/v2", Pass: "/v2", Rewrite: "{\"pattern\":\"v2\",\"rewrite\":\"/repository\"}"}}
+
+		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedName))
+		clientMock.EXPECT().Patch(testCtx, expectedName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
+			spec := mw.Spec.ReplacePathRegex
+			return spec != nil &&
+				spec.Regex == "^/v2(/|$)(.*)" &&
+				spec.Replacement == "/repository/$2"
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
+
+		// when
+		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, rewriteService, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expectedName, result)
+	})
+
+	t.Run("should return error for invalid rewrite config", func(t *testing.T) {
+		// given
+		manager := &MiddlewareManager{client: newMockMiddlewareInterface(t), namespace: "test-namespace"}
+		rewriteService := resolvedCesService{CesService: CesService{Name: "test", Location: "/v2", Pass: "/v2", Rewrite: "{\"pattern\":"}}
+
+		// when
+		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, rewriteService, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get replace path of ces service test")
+		assert.Empty(t, result)
+	})
+
 	t.Run("should set owner references on middleware", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
-		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
 		ownerRefs := []v1.OwnerReference{{Name: "my-owner"}}
 
 		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedName))
-		clientMock.EXPECT().Create(testCtx, mock.MatchedBy(func(mw *traefikapi.Middleware) bool {
+		clientMock.EXPECT().Patch(testCtx, expectedName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
 			// since the middleware ist never returned, it is validated here
 			return len(mw.OwnerReferences) == 1 && mw.OwnerReferences[0].Name == "my-owner"
-		}), v1.CreateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
 		_, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, ownerRefs)
@@ -151,252 +187,581 @@ func TestMiddlewareManager_createOrUpdateReplacePathMiddleware(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	t.Run("should return error when get fails with non not-found error", func(t *testing.T) {
+	t.Run("should record event and force apply on conflict", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
-		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
-		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
+		recorderMock := newMockEventRecorder(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace", recorder: recorderMock}
+		conflictErr := errors.NewConflict(schema.GroupResource{}, expectedName, assert.AnError)
 
-		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, fmt.Errorf("server error"))
+		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedName))
+		clientMock.EXPECT().Patch(testCtx, expectedName, types.ApplyPatchType, mock.Anything, testApplyOptions).Return(nil, conflictErr)
+		recorderMock.EXPECT().Eventf(mock.IsType(&traefikapi.Middleware{}), "Warning", "ApplyConflict", "Fields are managed by another field manager and will be overwritten: %s", conflictErr.Error())
+		forceOptions := v1.PatchOptions{FieldManager: "k8s-service-discovery", Force: ptr.To(true)}
+		clientMock.EXPECT().Patch(testCtx, expectedName, types.ApplyPatchType, mock.Anything, forceOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
 		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
 
 		// then
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to get middleware")
-		assert.Empty(t, result)
+		require.NoError(t, err)
+		assert.Equal(t, expectedName, result)
 	})
 
-	t.Run("should return error when create fails", func(t *testing.T) {
+	t.Run("should return error when apply fails", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
-		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
 
 		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedName))
-		clientMock.EXPECT().Create(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.CreateOptions{}).Return(nil, fmt.Errorf("create failed"))
+		clientMock.EXPECT().Patch(testCtx, expectedName, types.ApplyPatchType, mock.Anything, testApplyOptions).Return(nil, assert.AnError)
 
 		// when
 		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
 
 		// then
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to create middleware")
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to apply middleware")
 		assert.Empty(t, result)
 	})
+}
+
+func TestMiddlewareManager_deleteOrphanedMiddlewares(t *testing.T) {
+	listOptions := v1.ListOptions{}
+	service := &corev1.Service{ObjectMeta: v1.ObjectMeta{Name: "my-service", UID: "my-uid"}}
+	ownerReferences := []v1.OwnerReference{{Name: "my-service", UID: "my-uid"}}
 
-	t.Run("should return error when update fails", func(t *testing.T) {
+	t.Run("should delete middlewares which are not required by any ces service", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		cesService := CesService{
-			Name:     "test",
-			Port:     55,
-			Location: "/myLocation",
-			Pass:     "/myPass",
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "rewritten", Location: "/myLocation", Pass: "/myPass"}},
+			{CesService: CesService{Name: "equal", Location: "/equal", Pass: "/equal"}},
+			{CesService: CesService{Name: "custom", Location: "/custom", Pass: "/other", Rewrite: "{\"pattern\":\"custom\",\"rewrite\":\"\"}"}},
 		}
-		serviceName := "my-service"
-		expectedName := fmt.Sprintf("%s-%s-rewrite", serviceName, cesService.Name)
 
-		existingMiddleware := &traefikapi.Middleware{
-			ObjectMeta: v1.ObjectMeta{Name: expectedName, ResourceVersion: "1"},
-		}
-		clientMock.EXPECT().Get(testCtx, expectedName, v1.GetOptions{}).Return(existingMiddleware, nil)
-		clientMock.EXPECT().Update(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.UpdateOptions{}).Return(nil, fmt.Errorf("update failed"))
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-rewritten-rewrite", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-rewrite", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-custom-rewrite", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-removed-rewrite", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "other-service-test-rewrite", OwnerReferences: []v1.OwnerReference{{Name: "other-service", UID: "other-uid"}}}},
+		}}, nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-equal-rewrite", v1.DeleteOptions{}).Return(nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-removed-rewrite", v1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "my-service-removed-rewrite"))
 
 		// when
-		result, err := manager.createOrUpdateReplacePathMiddleware(testCtx, serviceName, cesService, nil)
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, cesServices)
 
 		// then
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to update middleware")
-		assert.Empty(t, result)
+		require.NoError(t, err)
 	})
-}
 
-func TestMiddlewareManager_CreateOrUpdateAlternativeFQDNRedirectMiddleware(t *testing.T) {
-	const expectedMiddlewareName = "alternative-fqdn"
+	t.Run("should delete unlabelled rewrite middlewares of older versions", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "equal", Location: "/equal", Pass: "/equal"}}}
+
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-rewrite", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "alternative-fqdn"}},
+			{ObjectMeta: v1.ObjectMeta{Name: "security-headers", Labels: util.K8sCesServiceDiscoveryLabels}},
+		}}, nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-equal-rewrite", v1.DeleteOptions{}).Return(nil)
+
+		// when
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, cesServices)
 
-	t.Run("should create middleware when it does not exist", func(t *testing.T) {
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should keep the translated middlewares of the nginx annotations", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		serviceWithAnnotations := &corev1.Service{ObjectMeta: v1.ObjectMeta{
+			Name:        "my-service",
+			UID:         "my-uid",
+			Annotations: map[string]string{annotation.AdditionalIngressAnnotationsAnnotation: `{"nginx.ingress.kubernetes.io/proxy-body-size":"8m","nginx.ingress.kubernetes.io/rewrite-target":"/$2"}`},
+		}}
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "equal", Location: "/equal", Pass: "/equal"}}}
+
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-rewrite", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-buffering", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-headers", OwnerReferences: ownerReferences}},
+		}}, nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-equal-headers", v1.DeleteOptions{}).Return(nil)
 
-		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedMiddlewareName))
-		clientMock.EXPECT().Create(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.CreateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		// when
+		err := manager.deleteOrphanedMiddlewares(testCtx, serviceWithAnnotations, cesServices)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should keep the middlewares of the request limits", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "equal", Location: "/equal", Pass: "/equal"}, requestLimits: requestLimits{Average: 100}}}
+
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-ratelimit", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-inflightreq", OwnerReferences: ownerReferences}},
+		}}, nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-equal-inflightreq", v1.DeleteOptions{}).Return(nil)
 
 		// when
-		result, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt1.example.com", "alt2.example.com"}, "primary.example.com", nil)
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, cesServices)
 
 		// then
 		require.NoError(t, err)
-		assert.Equal(t, expectedMiddlewareName, result)
 	})
 
-	t.Run("should update middleware when it already exists", func(t *testing.T) {
+	t.Run("should keep the middleware of the ip allowlist", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		cesServices := []resolvedCesService{{CesService: CesService{Name: "equal", Location: "/equal", Pass: "/equal"}, ipAllowList: ipAllowList{sourceRanges: []string{"10.0.0.0/8"}}}}
 
-		existingMiddleware := &traefikapi.Middleware{
-			ObjectMeta: v1.ObjectMeta{Name: expectedMiddlewareName, ResourceVersion: "7"},
-		}
-		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(existingMiddleware, nil)
-		clientMock.EXPECT().Update(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.UpdateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-ipallowlist", OwnerReferences: ownerReferences}},
+		}}, nil)
 
 		// when
-		result, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt1.example.com"}, "primary.example.com", nil)
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, cesServices)
 
 		// then
 		require.NoError(t, err)
-		assert.Equal(t, expectedMiddlewareName, result)
 	})
 
-	t.Run("should set resource version from existing middleware on update", func(t *testing.T) {
+	t.Run("should keep the buffering middleware of the max body size", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		expectedResourceVersion := "55"
+		maxBodyBytes := int64(1024)
+		noLimit := int64(0)
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "equal", Location: "/equal", Pass: "/equal"}, backend: backendConfig{maxBodyBytes: &maxBodyBytes}},
+			{CesService: CesService{Name: "other", Location: "/other", Pass: "/other"}, backend: backendConfig{maxBodyBytes: &noLimit}},
+		}
 
-		existingMiddleware := &traefikapi.Middleware{
-			ObjectMeta: v1.ObjectMeta{Name: expectedMiddlewareName, ResourceVersion: expectedResourceVersion},
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-buffering", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-other-buffering", OwnerReferences: ownerReferences}},
+		}}, nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-other-buffering", v1.DeleteOptions{}).Return(nil)
+
+		// when
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, cesServices)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should delete the middleware of disabled error pages", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		cesServices := []resolvedCesService{
+			{CesService: CesService{Name: "equal", Location: "/equal", Pass: "/equal"}, errorPages: errorPages{status: []string{"502-504"}, dogu: "my-service"}},
+			{CesService: CesService{Name: "other", Location: "/other", Pass: "/other"}},
 		}
-		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(existingMiddleware, nil)
-		clientMock.EXPECT().Update(testCtx, mock.MatchedBy(func(mw *traefikapi.Middleware) bool {
-			// since the middleware ist never returned, it is validated here
-			return mw.ResourceVersion == expectedResourceVersion
-		}), v1.UpdateOptions{}).Return(&traefikapi.Middleware{}, nil)
+
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-equal-errors", OwnerReferences: ownerReferences}},
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-other-errors", OwnerReferences: ownerReferences}},
+		}}, nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-other-errors", v1.DeleteOptions{}).Return(nil)
 
 		// when
-		_, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", nil)
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, cesServices)
 
 		// then
 		require.NoError(t, err)
 	})
 
-	t.Run("should build correct regex pattern with escaped dots and correct replacement", func(t *testing.T) {
+	t.Run("should return error when listing middlewares fails", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
 
-		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedMiddlewareName))
-		clientMock.EXPECT().Create(testCtx, mock.MatchedBy(func(mw *traefikapi.Middleware) bool {
-			// since the middleware ist never returned, it is validated here
-			spec := mw.Spec.RedirectRegex
-			return spec != nil &&
-				spec.Regex == `^https?://(alt1\.example\.com|alt2\.example\.com)(.*)` &&
-				spec.Replacement == "https://primary.example.com${2}"
-		}), v1.CreateOptions{}).Return(&traefikapi.Middleware{}, nil)
+		clientMock.EXPECT().List(testCtx, listOptions).Return(nil, assert.AnError)
 
 		// when
-		_, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt1.example.com", "alt2.example.com"}, "primary.example.com", nil)
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list middlewares")
+	})
+
+	t.Run("should return error when deleting a middleware fails", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+
+		clientMock.EXPECT().List(testCtx, listOptions).Return(&traefikapi.MiddlewareList{Items: []traefikapi.Middleware{
+			{ObjectMeta: v1.ObjectMeta{Name: "my-service-test-rewrite", OwnerReferences: ownerReferences}},
+		}}, nil)
+		clientMock.EXPECT().Delete(testCtx, "my-service-test-rewrite", v1.DeleteOptions{}).Return(assert.AnError)
+
+		// when
+		err := manager.deleteOrphanedMiddlewares(testCtx, service, nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete middleware my-service-test-rewrite")
+	})
+}
+
+func TestMiddlewareManager_UpsertGlobalMiddlewares(t *testing.T) {
+	notFound := func(name string) error {
+		return errors.NewNotFound(schema.GroupResource{}, name)
+	}
+	managedMiddleware := func(name string) *traefikapi.Middleware {
+		return &traefikapi.Middleware{ObjectMeta: v1.ObjectMeta{Name: name, Labels: util.K8sCesServiceDiscoveryLabels}}
+	}
+
+	t.Run("should apply configured and delete disabled global middlewares", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: clientMock, ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/frame_options": "SAMEORIGIN"})
+
+		ingressRouteMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, notFound("global-https-redirect"))
+		clientMock.EXPECT().Get(testCtx, "global-ip-allowlist", v1.GetOptions{}).Return(managedMiddleware("global-ip-allowlist"), nil)
+		clientMock.EXPECT().Delete(testCtx, "global-ip-allowlist", v1.DeleteOptions{}).Return(notFound("global-ip-allowlist"))
+		clientMock.EXPECT().Get(testCtx, "global-security-headers", v1.GetOptions{}).Return(nil, notFound("global-security-headers"))
+		clientMock.EXPECT().Patch(testCtx, "global-security-headers", types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
+			return mw.Namespace == "test-namespace" &&
+				mw.Labels["app.kubernetes.io/name"] == "k8s-service-discovery" &&
+				len(mw.OwnerReferences) == 0 &&
+				mw.Spec.Headers != nil &&
+				mw.Spec.Headers.CustomFrameOptionsValue == "SAMEORIGIN"
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
+		clientMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, notFound("global-https-redirect"))
+		clientMock.EXPECT().Get(testCtx, "forward-auth", v1.GetOptions{}).Return(nil, notFound("forward-auth"))
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
 
 		// then
 		require.NoError(t, err)
 	})
 
-	t.Run("should set namespace on created middleware", func(t *testing.T) {
+	t.Run("should keep middlewares and routes without the labels of the service discovery", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
-		manager := &MiddlewareManager{client: clientMock, namespace: "my-namespace"}
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: clientMock, ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+
+		ingressRouteMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(&traefikapi.IngressRoute{ObjectMeta: v1.ObjectMeta{Name: "global-https-redirect"}}, nil)
+		for _, name := range []string{"global-ip-allowlist", "global-security-headers", "global-https-redirect", "forward-auth"} {
+			clientMock.EXPECT().Get(testCtx, name, v1.GetOptions{}).Return(&traefikapi.Middleware{ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{"app": "ces"}}}, nil)
+		}
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, config.CreateGlobalConfig(config.Entries{}))
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should apply the https redirect middleware and its catch-all route of the http entrypoint", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: clientMock, ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "true"})
+
+		for _, name := range []string{"global-ip-allowlist", "global-security-headers", "forward-auth"} {
+			clientMock.EXPECT().Get(testCtx, name, v1.GetOptions{}).Return(nil, notFound(name))
+		}
+		clientMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, notFound("global-https-redirect"))
+		clientMock.EXPECT().Patch(testCtx, "global-https-redirect", types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
+			return mw.Spec.RedirectScheme != nil && mw.Spec.RedirectScheme.Scheme == "https" && mw.Spec.RedirectScheme.Permanent
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
+		expectApplyIngressRoute(t, ingressRouteMock, &traefikapi.IngressRoute{
+			TypeMeta: v1.TypeMeta{APIVersion: "traefik.io/v1alpha1", Kind: "IngressRoute"},
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "global-https-redirect",
+				Namespace: "test-namespace",
+				Labels:    util.K8sCesServiceDiscoveryLabels,
+			},
+			Spec: traefikapi.IngressRouteSpec{
+				EntryPoints: []string{"web"},
+				Routes: []traefikapi.Route{{
+					Match:       "PathPrefix(`/`)",
+					Kind:        "Rule",
+					Priority:    1,
+					Services:    []traefikapi.Service{{LoadBalancerSpec: traefikapi.LoadBalancerSpec{Name: "noop@internal", Kind: "TraefikService"}}},
+					Middlewares: []traefikapi.MiddlewareRef{{Name: "global-https-redirect", Namespace: "test-namespace"}},
+				}},
+			},
+		})
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should apply the forward auth middleware", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: clientMock, ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{
+			"ingress/security/forward_auth_address":          "http://localhost:4181/auth",
+			"ingress/security/forward_auth_response_headers": "X-Forwarded-User, X-Forwarded-Groups",
+		})
+
+		ingressRouteMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(&traefikapi.IngressRoute{ObjectMeta: v1.ObjectMeta{Name: "global-https-redirect", Labels: util.K8sCesServiceDiscoveryLabels}}, nil)
+		ingressRouteMock.EXPECT().Delete(testCtx, "global-https-redirect", v1.DeleteOptions{}).Return(nil)
+		for _, name := range []string{"global-ip-allowlist", "global-security-headers", "global-https-redirect"} {
+			clientMock.EXPECT().Get(testCtx, name, v1.GetOptions{}).Return(managedMiddleware(name), nil)
+			clientMock.EXPECT().Delete(testCtx, name, v1.DeleteOptions{}).Return(nil)
+		}
+		clientMock.EXPECT().Get(testCtx, "forward-auth", v1.GetOptions{}).Return(nil, notFound("forward-auth"))
+		clientMock.EXPECT().Patch(testCtx, "forward-auth", types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
+			return len(mw.OwnerReferences) == 0 &&
+				mw.Spec.ForwardAuth != nil &&
+				mw.Spec.ForwardAuth.Address == "http://localhost:4181/auth" &&
+				assert.ObjectsAreEqual([]string{"X-Forwarded-User", "X-Forwarded-Groups"}, mw.Spec.ForwardAuth.AuthResponseHeaders)
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error for invalid forward auth address", func(t *testing.T) {
+		// given
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: newMockMiddlewareInterface(t), ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/forward_auth_address": "auth-server"})
+
+		ingressRouteMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, notFound("global-https-redirect"))
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get forward auth middleware")
+	})
+
+	t.Run("should return error for invalid global config", func(t *testing.T) {
+		// given
+		manager := &MiddlewareManager{client: newMockMiddlewareInterface(t), namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/hsts_max_age": "one year"})
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get global middlewares")
+	})
+
+	t.Run("should return error for invalid https redirect", func(t *testing.T) {
+		// given
+		manager := &MiddlewareManager{client: newMockMiddlewareInterface(t), namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "yes please"})
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get https redirect middleware")
+	})
+
+	t.Run("should return error when apply fails", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "true"})
+
+		clientMock.EXPECT().Get(testCtx, "global-ip-allowlist", v1.GetOptions{}).Return(nil, notFound("global-ip-allowlist"))
+		clientMock.EXPECT().Get(testCtx, "global-security-headers", v1.GetOptions{}).Return(nil, notFound("global-security-headers"))
+		clientMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, notFound("global-https-redirect"))
+		clientMock.EXPECT().Patch(testCtx, "global-https-redirect", types.ApplyPatchType, mock.Anything, testApplyOptions).Return(nil, assert.AnError)
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create/update global middleware global-https-redirect")
+	})
+
+	t.Run("should return error when applying the https redirect route fails", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: clientMock, ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+		globalConfig := config.CreateGlobalConfig(config.Entries{"ingress/security/https_redirect": "true"})
+
+		for _, name := range []string{"global-ip-allowlist", "global-security-headers", "forward-auth"} {
+			clientMock.EXPECT().Get(testCtx, name, v1.GetOptions{}).Return(nil, notFound(name))
+		}
+		clientMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, notFound("global-https-redirect"))
+		clientMock.EXPECT().Patch(testCtx, "global-https-redirect", types.ApplyPatchType, mock.Anything, testApplyOptions).Return(&traefikapi.Middleware{}, nil)
+		ingressRouteMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, assert.AnError)
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, globalConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to apply https redirect ingress route global-https-redirect")
+	})
+
+	t.Run("should return error when deleting the https redirect route fails", func(t *testing.T) {
+		// given
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: newMockMiddlewareInterface(t), ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+
+		ingressRouteMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, assert.AnError)
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, config.CreateGlobalConfig(config.Entries{}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete https redirect ingress route global-https-redirect")
+	})
+
+	t.Run("should return error when delete fails", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		ingressRouteMock := newMockIngressRouteInterface(t)
+		manager := &MiddlewareManager{client: clientMock, ingressRouteClient: ingressRouteMock, namespace: "test-namespace"}
+
+		ingressRouteMock.EXPECT().Get(testCtx, "global-https-redirect", v1.GetOptions{}).Return(nil, notFound("global-https-redirect"))
+		clientMock.EXPECT().Get(testCtx, "global-ip-allowlist", v1.GetOptions{}).Return(managedMiddleware("global-ip-allowlist"), nil)
+		clientMock.EXPECT().Delete(testCtx, "global-ip-allowlist", v1.DeleteOptions{}).Return(assert.AnError)
+
+		// when
+		err := manager.UpsertGlobalMiddlewares(testCtx, config.CreateGlobalConfig(config.Entries{}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete global middleware global-ip-allowlist")
+	})
+}
+
+func TestMiddlewareManager_CreateOrUpdateAlternativeFQDNRedirectMiddleware(t *testing.T) {
+	const expectedMiddlewareName = "alternative-fqdn"
+
+	t.Run("should apply middleware", func(t *testing.T) {
+		// given
+		clientMock := newMockMiddlewareInterface(t)
+		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
 
 		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedMiddlewareName))
-		clientMock.EXPECT().Create(testCtx, mock.MatchedBy(func(mw *traefikapi.Middleware) bool {
+		clientMock.EXPECT().Patch(testCtx, expectedMiddlewareName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
 			// since the middleware ist never returned, it is validated here
-			return mw.Namespace == "my-namespace"
-		}), v1.CreateOptions{}).Return(&traefikapi.Middleware{}, nil)
+			return mw.APIVersion == "traefik.io/v1alpha1" && mw.Kind == "Middleware"
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
-		_, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", nil)
+		result, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt1.example.com", "alt2.example.com"}, "primary.example.com", nil)
 
 		// then
 		require.NoError(t, err)
+		assert.Equal(t, expectedMiddlewareName, result)
 	})
 
-	t.Run("should set owner references on created middleware", func(t *testing.T) {
+	t.Run("should build correct regex pattern with escaped dots and correct replacement", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
-		ownerRefs := []v1.OwnerReference{{Name: "my-owner"}}
 
 		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedMiddlewareName))
-		clientMock.EXPECT().Create(testCtx, mock.MatchedBy(func(mw *traefikapi.Middleware) bool {
+		clientMock.EXPECT().Patch(testCtx, expectedMiddlewareName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
 			// since the middleware ist never returned, it is validated here
-			return len(mw.OwnerReferences) == 1 && mw.OwnerReferences[0].Name == "my-owner"
-		}), v1.CreateOptions{}).Return(&traefikapi.Middleware{}, nil)
+			spec := mw.Spec.RedirectRegex
+			return spec != nil &&
+				spec.Regex == `^https?://(alt1\.example\.com|alt2\.example\.com)(.*)` &&
+				spec.Replacement == "https://primary.example.com${2}"
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
-		_, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", ownerRefs)
+		_, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt1.example.com", "alt2.example.com"}, "primary.example.com", nil)
 
 		// then
 		require.NoError(t, err)
 	})
 
-	t.Run("should return error when get fails with non-404 error", func(t *testing.T) {
+	t.Run("should set namespace on applied middleware", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
-		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		manager := &MiddlewareManager{client: clientMock, namespace: "my-namespace"}
 
-		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, fmt.Errorf("server unavailable"))
+		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedMiddlewareName))
+		clientMock.EXPECT().Patch(testCtx, expectedMiddlewareName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
+			// since the middleware ist never returned, it is validated here
+			return mw.Namespace == "my-namespace"
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
-		result, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", nil)
+		_, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", nil)
 
 		// then
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to get middleware")
-		assert.Empty(t, result)
+		require.NoError(t, err)
 	})
 
-	t.Run("should return error when create fails", func(t *testing.T) {
+	t.Run("should set owner references on applied middleware", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
+		ownerRefs := []v1.OwnerReference{{Name: "my-owner"}}
 
 		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedMiddlewareName))
-		clientMock.EXPECT().Create(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.CreateOptions{}).Return(nil, fmt.Errorf("create failed"))
+		clientMock.EXPECT().Patch(testCtx, expectedMiddlewareName, types.ApplyPatchType, appliedMiddleware(func(mw *traefikapi.Middleware) bool {
+			// since the middleware ist never returned, it is validated here
+			return len(mw.OwnerReferences) == 1 && mw.OwnerReferences[0].Name == "my-owner"
+		}), testApplyOptions).Return(&traefikapi.Middleware{}, nil)
 
 		// when
-		result, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", nil)
+		_, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", ownerRefs)
 
 		// then
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to create alternative FQDN redirect middleware")
-		assert.Empty(t, result)
+		require.NoError(t, err)
 	})
 
-	t.Run("should return error when update fails", func(t *testing.T) {
+	t.Run("should return error when apply fails", func(t *testing.T) {
 		// given
 		clientMock := newMockMiddlewareInterface(t)
 		manager := &MiddlewareManager{client: clientMock, namespace: "test-namespace"}
 
-		existingMiddleware := &traefikapi.Middleware{
-			ObjectMeta: v1.ObjectMeta{Name: expectedMiddlewareName, ResourceVersion: "3"},
-		}
-		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(existingMiddleware, nil)
-		clientMock.EXPECT().Update(testCtx, mock.AnythingOfType("*v1alpha1.Middleware"), v1.UpdateOptions{}).Return(nil, fmt.Errorf("update failed"))
+		clientMock.EXPECT().Get(testCtx, expectedMiddlewareName, v1.GetOptions{}).Return(nil, errors.NewNotFound(schema.GroupResource{}, expectedMiddlewareName))
+		clientMock.EXPECT().Patch(testCtx, expectedMiddlewareName, types.ApplyPatchType, mock.Anything, testApplyOptions).Return(nil, fmt.Errorf("apply failed"))
 
 		// when
 		result, err := manager.CreateOrUpdateAlternativeFQDNRedirectMiddleware(testCtx, []string{"alt.example.com"}, "primary.example.com", nil)
 
 		// then
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "failed to update alternative FQDN redirect middleware")
+		assert.ErrorContains(t, err, "failed to apply alternative FQDN redirect middleware")
 		assert.Empty(t, result)
 	})
 }
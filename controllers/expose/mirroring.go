@@ -0,0 +1,227 @@
+package expose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// MirroringAnnotation can be appended to the service of a dogu to mirror the requests of all its ces services to a
+	// shadow service, e.g., `{"target": "redmine-shadow", "port": 3000, "percent": 10, "maxBodySize": "1m"}`.
+	MirroringAnnotation = "k8s-service-discovery.cloudogu.com/mirroring"
+)
+
+const (
+	// doguConfigMirroringTargetKey is the dogu config key with the name of the shadow service which receives the
+	// mirrored requests of the dogu.
+	doguConfigMirroringTargetKey = "ingress/mirroring/target"
+	// doguConfigMirroringPortKey is the dogu config key with the port of the shadow service. Defaults to the port of
+	// the ces service.
+	doguConfigMirroringPortKey = "ingress/mirroring/port"
+	// doguConfigMirroringPercentKey is the dogu config key with the percentage of the mirrored requests. Defaults to
+	// all requests.
+	doguConfigMirroringPercentKey = "ingress/mirroring/percent"
+	// doguConfigMirroringMaxBodySizeKey is the dogu config key with the max body size of the mirrored requests in the
+	// nginx format, e.g., `1m`. Larger requests are not mirrored.
+	doguConfigMirroringMaxBodySizeKey = "ingress/mirroring/max_body_size"
+)
+
+const mirroringTraefikServiceSuffix = "mirroring"
+
+// mirroring mirrors the requests of a ces service to a shadow service. The responses of the shadow service are
+// discarded. The mirroring is disabled without target.
+type mirroring struct {
+	// Target is the name of the shadow service in the namespace of the dogu.
+	Target string `json:"target,omitempty"`
+	// Port of the shadow service. Defaults to the port of the ces service.
+	Port int32 `json:"port,omitempty"`
+	// Percent of the requests which are mirrored. Defaults to 100 if unset.
+	Percent *int `json:"percent,omitempty"`
+	// MaxBodySize of the mirrored requests in the nginx format, e.g., `1m`. Larger requests are not mirrored. Zero or
+	// empty mirrors requests of any size.
+	MaxBodySize string `json:"maxBodySize,omitempty"`
+}
+
+// getMirroringOfService returns the mirroring of the annotation of the given service.
+func getMirroringOfService(service *corev1.Service) (mirroring, error) {
+	m := mirroring{}
+	value, ok := service.Annotations[MirroringAnnotation]
+	if !ok {
+		return m, nil
+	}
+
+	err := json.Unmarshal([]byte(value), &m)
+	if err != nil {
+		return mirroring{}, fmt.Errorf("failed to unmarshal mirroring of service [%s]: %w", service.Name, err)
+	}
+
+	err = m.validate()
+	if err != nil {
+		return mirroring{}, fmt.Errorf("invalid mirroring of service [%s]: %w", service.Name, err)
+	}
+
+	return m, nil
+}
+
+// withDoguConfig returns the mirroring overridden by the dogu config.
+func (m mirroring) withDoguConfig(doguConfig libconfig.DoguConfig) (mirroring, error) {
+	if target, ok := doguConfig.Get(doguConfigMirroringTargetKey); ok {
+		m.Target = strings.TrimSpace(target.String())
+	}
+	if size, ok := doguConfig.Get(doguConfigMirroringMaxBodySizeKey); ok {
+		m.MaxBodySize = strings.TrimSpace(size.String())
+	}
+
+	if port, ok := doguConfig.Get(doguConfigMirroringPortKey); ok {
+		parsed, err := strconv.ParseInt(port.String(), 10, 32)
+		if err != nil {
+			return mirroring{}, fmt.Errorf("invalid value [%s] of dogu config key [%s]: expected number", port, doguConfigMirroringPortKey)
+		}
+
+		m.Port = int32(parsed)
+	}
+	if percent, ok := doguConfig.Get(doguConfigMirroringPercentKey); ok {
+		parsed, err := strconv.Atoi(percent.String())
+		if err != nil {
+			return mirroring{}, fmt.Errorf("invalid value [%s] of dogu config key [%s]: expected number", percent, doguConfigMirroringPercentKey)
+		}
+
+		m.Percent = &parsed
+	}
+
+	err := m.validate()
+	if err != nil {
+		return mirroring{}, fmt.Errorf("invalid mirroring in dogu config: %w", err)
+	}
+
+	return m, nil
+}
+
+func (m mirroring) validate() error {
+	if m.Port < 0 || m.Port > 65535 {
+		return fmt.Errorf("invalid port [%d]", m.Port)
+	}
+
+	if m.Percent != nil && (*m.Percent < 1 || *m.Percent > 100) {
+		return fmt.Errorf("invalid percent [%d]: expected percentage between 1 and 100", *m.Percent)
+	}
+
+	if m.MaxBodySize != "" {
+		if _, err := parseNginxSize(m.MaxBodySize); err != nil {
+			return fmt.Errorf("invalid max body size: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m mirroring) isEnabled() bool {
+	return m.Target != ""
+}
+
+// getMirrorService returns the shadow service of the given ces service.
+func (m mirroring) getMirrorService(cesService resolvedCesService, namespace string) traefikapi.MirrorService {
+	port := m.Port
+	if port == 0 {
+		port = int32(cesService.Port)
+	}
+
+	percent := 100
+	if m.Percent != nil {
+		percent = *m.Percent
+	}
+
+	return traefikapi.MirrorService{
+		LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+			Name:      m.Target,
+			Namespace: namespace,
+			Port:      intstr.FromInt32(port),
+		},
+		Percent: percent,
+	}
+}
+
+// getMaxBodySize returns the max body size of the mirrored requests in bytes or nil to mirror requests of any size.
+func (m mirroring) getMaxBodySize() *int64 {
+	if m.MaxBodySize == "" {
+		return nil
+	}
+
+	// the size is validated on resolving the mirroring
+	size, _ := parseNginxSize(m.MaxBodySize)
+	if size <= 0 {
+		return nil
+	}
+
+	return &size
+}
+
+func getMirroringTraefikServiceName(cesServiceName string) string {
+	return fmt.Sprintf("%s-%s", cesServiceName, mirroringTraefikServiceSuffix)
+}
+
+// upsertMirroring creates or deletes the mirroring traefik service of the given ces service and returns the traefik
+// service which the ingress route of the ces service forwards the requests to. The mirroring traefik service forwards
+// the requests to the traefik service of the ces service and mirrors them to the shadow service.
+func (r *ingressRouteUpdater) upsertMirroring(ctx context.Context, cesService resolvedCesService, ownerReferences []v1.OwnerReference) (string, error) {
+	name := getMirroringTraefikServiceName(cesService.Name)
+	if !cesService.mirroring.isEnabled() {
+		err := r.deleteTraefikService(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to delete mirroring traefik service %s: %w", name, err)
+		}
+
+		return cesService.Name, nil
+	}
+
+	traefikService := &traefikapi.TraefikService{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: util.TraefikAPIVersion,
+			Kind:       traefikServiceKind,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:            name,
+			Namespace:       r.namespace,
+			Labels:          util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: traefikapi.TraefikServiceSpec{
+			Mirroring: &traefikapi.Mirroring{
+				LoadBalancerSpec: traefikapi.LoadBalancerSpec{
+					Name:      cesService.Name,
+					Kind:      traefikServiceKind,
+					Namespace: r.namespace,
+				},
+				MaxBodySize: cesService.mirroring.getMaxBodySize(),
+				Mirrors:     []traefikapi.MirrorService{cesService.mirroring.getMirrorService(cesService, r.namespace)},
+			},
+		},
+	}
+
+	_, err := util.ServerSideApply[*traefikapi.TraefikService](ctx, r.traefikServiceInterface, traefikService, r.eventRecorder)
+	if err != nil {
+		return "", fmt.Errorf("failed to upsert mirroring traefik service %s: %w", traefikService.Name, err)
+	}
+
+	return traefikService.Name, nil
+}
+
+func (r *ingressRouteUpdater) deleteTraefikService(ctx context.Context, name string) error {
+	err := r.traefikServiceInterface.Delete(ctx, name, v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func Test_getMirroringOfService(t *testing.T) {
+	t.Run("should disable the mirroring without annotation", func(t *testing.T) {
+		// when
+		actual, err := getMirroringOfService(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redmine"}})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, actual.isEnabled())
+	})
+	t.Run("should parse the mirroring of the annotation", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redmine", Annotations: map[string]string{
+			"k8s-service-discovery.cloudogu.com/mirroring": `{"target":"redmine-shadow","port":3000,"percent":10,"maxBodySize":"1m"}`,
+		}}}
+
+		// when
+		actual, err := getMirroringOfService(service)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, mirroring{Target: "redmine-shadow", Port: 3000, Percent: ptr.To(10), MaxBodySize: "1m"}, actual)
+	})
+	t.Run("should fail for invalid json", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redmine", Annotations: map[string]string{
+			"k8s-service-discovery.cloudogu.com/mirroring": `{"target":`,
+		}}}
+
+		// when
+		_, err := getMirroringOfService(service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to unmarshal mirroring of service [redmine]")
+	})
+	t.Run("should fail for a percent of zero", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redmine", Annotations: map[string]string{
+			"k8s-service-discovery.cloudogu.com/mirroring": `{"target":"redmine-shadow","percent":0}`,
+		}}}
+
+		// when
+		_, err := getMirroringOfService(service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid mirroring of service [redmine]: invalid percent [0]: expected percentage between 1 and 100")
+	})
+	t.Run("should fail for invalid percent", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redmine", Annotations: map[string]string{
+			"k8s-service-discovery.cloudogu.com/mirroring": `{"target":"redmine-shadow","percent":150}`,
+		}}}
+
+		// when
+		_, err := getMirroringOfService(service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid mirroring of service [redmine]: invalid percent [150]: expected percentage between 1 and 100")
+	})
+}
+
+func Test_mirroring_withDoguConfig(t *testing.T) {
+	t.Run("should override the mirroring with the dogu config", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{
+			"ingress/mirroring/percent":       "25",
+			"ingress/mirroring/max_body_size": "2m",
+		})
+
+		// when
+		actual, err := mirroring{Target: "redmine-shadow", Percent: ptr.To(10)}.withDoguConfig(doguConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, mirroring{Target: "redmine-shadow", Percent: ptr.To(25), MaxBodySize: "2m"}, actual)
+	})
+	t.Run("should disable the mirroring with an empty target", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"ingress/mirroring/target": ""})
+
+		// when
+		actual, err := mirroring{Target: "redmine-shadow"}.withDoguConfig(doguConfig)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, actual.isEnabled())
+	})
+	t.Run("should fail for a percent of zero", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"ingress/mirroring/percent": "0"})
+
+		// when
+		_, err := mirroring{Target: "redmine-shadow"}.withDoguConfig(doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid mirroring in dogu config: invalid percent [0]: expected percentage between 1 and 100")
+	})
+	t.Run("should fail for invalid port", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"ingress/mirroring/port": "http"})
+
+		// when
+		_, err := mirroring{}.withDoguConfig(doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [http] of dogu config key [ingress/mirroring/port]: expected number")
+	})
+	t.Run("should fail for invalid max body size", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("redmine", config.Entries{"ingress/mirroring/max_body_size": "1t"})
+
+		// when
+		_, err := mirroring{}.withDoguConfig(doguConfig)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid mirroring in dogu config: invalid max body size: invalid nginx size [1t]")
+	})
+}
+
+func Test_ingressRouteUpdater_upsertMirroring(t *testing.T) {
+	service := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "redmine", Namespace: testNamespace, UID: "uid"},
+	}
+	ownerReferences := []metav1.OwnerReference{{APIVersion: "v1", Kind: "Service", Name: "redmine", UID: "uid"}}
+
+	t.Run("should create the mirroring traefik service with the defaults of the ces service", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{CesService: CesService{Name: "redmine", Port: 3000}, mirroring: mirroring{Target: "redmine-shadow", MaxBodySize: "1k"}}
+		expected := getTestMirroringTraefikService("redmine", service, traefikapi.MirrorService{
+			LoadBalancerSpec: traefikapi.LoadBalancerSpec{Name: "redmine-shadow", Namespace: testNamespace, Port: intstr.FromInt32(3000)},
+			Percent:          100,
+		})
+		maxBodySize := int64(1024)
+		expected.Spec.Mirroring.MaxBodySize = &maxBodySize
+
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		expectApplyTraefikService(t, traefikServiceInterfaceMock, expected)
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}, traefikServiceInterface: traefikServiceInterfaceMock}
+
+		// when
+		actual, err := sut.upsertMirroring(testCtx, cesService, ownerReferences)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "redmine-mirroring", actual)
+	})
+	t.Run("should delete the mirroring traefik service without target", func(t *testing.T) {
+		// given
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		traefikServiceInterfaceMock.EXPECT().Delete(testCtx, "redmine-mirroring", metav1.DeleteOptions{}).Return(errors.NewNotFound(schema.GroupResource{}, "redmine-mirroring"))
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}, traefikServiceInterface: traefikServiceInterfaceMock}
+
+		// when
+		actual, err := sut.upsertMirroring(testCtx, resolvedCesService{CesService: CesService{Name: "redmine", Port: 3000}}, ownerReferences)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "redmine", actual)
+	})
+	t.Run("should fail to delete the mirroring traefik service", func(t *testing.T) {
+		// given
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		traefikServiceInterfaceMock.EXPECT().Delete(testCtx, "redmine-mirroring", metav1.DeleteOptions{}).Return(assert.AnError)
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}, traefikServiceInterface: traefikServiceInterfaceMock}
+
+		// when
+		_, err := sut.upsertMirroring(testCtx, resolvedCesService{CesService: CesService{Name: "redmine", Port: 3000}}, ownerReferences)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete mirroring traefik service redmine-mirroring")
+	})
+	t.Run("should fail to apply the mirroring traefik service", func(t *testing.T) {
+		// given
+		traefikServiceInterfaceMock := newMockTraefikServiceInterface(t)
+		traefikServiceInterfaceMock.EXPECT().Get(testCtx, "redmine-mirroring", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		sut := &ingressRouteUpdater{ingressUpdater: &ingressUpdater{namespace: testNamespace}, traefikServiceInterface: traefikServiceInterfaceMock}
+
+		// when
+		_, err := sut.upsertMirroring(testCtx, resolvedCesService{CesService: CesService{Name: "redmine", Port: 3000}, mirroring: mirroring{Target: "redmine-shadow"}}, ownerReferences)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to upsert mirroring traefik service redmine-mirroring")
+	})
+}
+
+func getTestMirroringTraefikService(cesServiceName string, service *corev1.Service, mirror traefikapi.MirrorService) *traefikapi.TraefikService {
+	return &traefikapi.TraefikService{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "traefik.io/v1alpha1",
+			Kind:       "TraefikService",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cesServiceName + "-mirroring",
+			Namespace: testNamespace,
+			Labels:    util.K8sCesServiceDiscoveryLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: service.APIVersion,
+				Kind:       service.Kind,
+				Name:       service.Name,
+				UID:        service.UID,
+			}},
+		},
+		Spec: traefikapi.TraefikServiceSpec{
+			Mirroring: &traefikapi.Mirroring{
+				LoadBalancerSpec: traefikapi.LoadBalancerSpec{Name: cesServiceName, Kind: "TraefikService", Namespace: testNamespace},
+				Mirrors:          []traefikapi.MirrorService{mirror},
+			},
+		},
+	}
+}
@@ -17,6 +17,102 @@ func (_m *mockIngressController) EXPECT() *mockIngressController_Expecter {
 	return &mockIngressController_Expecter{mock: &_m.Mock}
 }
 
+// GetDoguStartingAnnotations provides a mock function with given fields: namespace
+func (_m *mockIngressController) GetDoguStartingAnnotations(namespace string) map[string]string {
+	ret := _m.Called(namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDoguStartingAnnotations")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// mockIngressController_GetDoguStartingAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDoguStartingAnnotations'
+type mockIngressController_GetDoguStartingAnnotations_Call struct {
+	*mock.Call
+}
+
+// GetDoguStartingAnnotations is a helper method to define mock.On call
+//   - namespace string
+func (_e *mockIngressController_Expecter) GetDoguStartingAnnotations(namespace interface{}) *mockIngressController_GetDoguStartingAnnotations_Call {
+	return &mockIngressController_GetDoguStartingAnnotations_Call{Call: _e.mock.On("GetDoguStartingAnnotations", namespace)}
+}
+
+func (_c *mockIngressController_GetDoguStartingAnnotations_Call) Run(run func(namespace string)) *mockIngressController_GetDoguStartingAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *mockIngressController_GetDoguStartingAnnotations_Call) Return(_a0 map[string]string) *mockIngressController_GetDoguStartingAnnotations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockIngressController_GetDoguStartingAnnotations_Call) RunAndReturn(run func(string) map[string]string) *mockIngressController_GetDoguStartingAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMaintenanceModeAnnotations provides a mock function with given fields: namespace
+func (_m *mockIngressController) GetMaintenanceModeAnnotations(namespace string) map[string]string {
+	ret := _m.Called(namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaintenanceModeAnnotations")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// mockIngressController_GetMaintenanceModeAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaintenanceModeAnnotations'
+type mockIngressController_GetMaintenanceModeAnnotations_Call struct {
+	*mock.Call
+}
+
+// GetMaintenanceModeAnnotations is a helper method to define mock.On call
+//   - namespace string
+func (_e *mockIngressController_Expecter) GetMaintenanceModeAnnotations(namespace interface{}) *mockIngressController_GetMaintenanceModeAnnotations_Call {
+	return &mockIngressController_GetMaintenanceModeAnnotations_Call{Call: _e.mock.On("GetMaintenanceModeAnnotations", namespace)}
+}
+
+func (_c *mockIngressController_GetMaintenanceModeAnnotations_Call) Run(run func(namespace string)) *mockIngressController_GetMaintenanceModeAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *mockIngressController_GetMaintenanceModeAnnotations_Call) Return(_a0 map[string]string) *mockIngressController_GetMaintenanceModeAnnotations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockIngressController_GetMaintenanceModeAnnotations_Call) RunAndReturn(run func(string) map[string]string) *mockIngressController_GetMaintenanceModeAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetName provides a mock function with no fields
 func (_m *mockIngressController) GetName() string {
 	ret := _m.Called()
@@ -62,47 +158,97 @@ func (_c *mockIngressController_GetName_Call) RunAndReturn(run func() string) *m
 	return _c
 }
 
-// GetRewriteAnnotationKey provides a mock function with no fields
-func (_m *mockIngressController) GetRewriteAnnotationKey() string {
+// GetPathRewriteAnnotations provides a mock function with given fields: namespace, middlewareName, targetPath
+func (_m *mockIngressController) GetPathRewriteAnnotations(namespace string, middlewareName string, targetPath string) map[string]string {
+	ret := _m.Called(namespace, middlewareName, targetPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPathRewriteAnnotations")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string, string, string) map[string]string); ok {
+		r0 = rf(namespace, middlewareName, targetPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// mockIngressController_GetPathRewriteAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPathRewriteAnnotations'
+type mockIngressController_GetPathRewriteAnnotations_Call struct {
+	*mock.Call
+}
+
+// GetPathRewriteAnnotations is a helper method to define mock.On call
+//   - namespace string
+//   - middlewareName string
+//   - targetPath string
+func (_e *mockIngressController_Expecter) GetPathRewriteAnnotations(namespace interface{}, middlewareName interface{}, targetPath interface{}) *mockIngressController_GetPathRewriteAnnotations_Call {
+	return &mockIngressController_GetPathRewriteAnnotations_Call{Call: _e.mock.On("GetPathRewriteAnnotations", namespace, middlewareName, targetPath)}
+}
+
+func (_c *mockIngressController_GetPathRewriteAnnotations_Call) Run(run func(namespace string, middlewareName string, targetPath string)) *mockIngressController_GetPathRewriteAnnotations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockIngressController_GetPathRewriteAnnotations_Call) Return(_a0 map[string]string) *mockIngressController_GetPathRewriteAnnotations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockIngressController_GetPathRewriteAnnotations_Call) RunAndReturn(run func(string, string, string) map[string]string) *mockIngressController_GetPathRewriteAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsesMiddlewares provides a mock function with no fields
+func (_m *mockIngressController) UsesMiddlewares() bool {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRewriteAnnotationKey")
+		panic("no return value specified for UsesMiddlewares")
 	}
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// mockIngressController_GetRewriteAnnotationKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRewriteAnnotationKey'
-type mockIngressController_GetRewriteAnnotationKey_Call struct {
+// mockIngressController_UsesMiddlewares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsesMiddlewares'
+type mockIngressController_UsesMiddlewares_Call struct {
 	*mock.Call
 }
 
-// GetRewriteAnnotationKey is a helper method to define mock.On call
-func (_e *mockIngressController_Expecter) GetRewriteAnnotationKey() *mockIngressController_GetRewriteAnnotationKey_Call {
-	return &mockIngressController_GetRewriteAnnotationKey_Call{Call: _e.mock.On("GetRewriteAnnotationKey")}
+// UsesMiddlewares is a helper method to define mock.On call
+func (_e *mockIngressController_Expecter) UsesMiddlewares() *mockIngressController_UsesMiddlewares_Call {
+	return &mockIngressController_UsesMiddlewares_Call{Call: _e.mock.On("UsesMiddlewares")}
 }
 
-func (_c *mockIngressController_GetRewriteAnnotationKey_Call) Run(run func()) *mockIngressController_GetRewriteAnnotationKey_Call {
+func (_c *mockIngressController_UsesMiddlewares_Call) Run(run func()) *mockIngressController_UsesMiddlewares_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *mockIngressController_GetRewriteAnnotationKey_Call) Return(_a0 string) *mockIngressController_GetRewriteAnnotationKey_Call {
+func (_c *mockIngressController_UsesMiddlewares_Call) Return(_a0 bool) *mockIngressController_UsesMiddlewares_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *mockIngressController_GetRewriteAnnotationKey_Call) RunAndReturn(run func() string) *mockIngressController_GetRewriteAnnotationKey_Call {
+func (_c *mockIngressController_UsesMiddlewares_Call) RunAndReturn(run func() bool) *mockIngressController_UsesMiddlewares_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,10 +3,16 @@
 package expose
 
 import (
+	config "github.com/cloudogu/k8s-registry-lib/config"
+
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 )
 
 // mockMiddlewareManager is an autogenerated mock type for the middlewareManager type
@@ -81,8 +87,104 @@ func (_c *mockMiddlewareManager_CreateOrUpdateAlternativeFQDNRedirectMiddleware_
 	return _c
 }
 
+// UpsertGlobalMiddlewares provides a mock function with given fields: ctx, globalConfig
+func (_m *mockMiddlewareManager) UpsertGlobalMiddlewares(ctx context.Context, globalConfig config.GlobalConfig) error {
+	ret := _m.Called(ctx, globalConfig)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertGlobalMiddlewares")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, config.GlobalConfig) error); ok {
+		r0 = rf(ctx, globalConfig)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockMiddlewareManager_UpsertGlobalMiddlewares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertGlobalMiddlewares'
+type mockMiddlewareManager_UpsertGlobalMiddlewares_Call struct {
+	*mock.Call
+}
+
+// UpsertGlobalMiddlewares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - globalConfig config.GlobalConfig
+func (_e *mockMiddlewareManager_Expecter) UpsertGlobalMiddlewares(ctx interface{}, globalConfig interface{}) *mockMiddlewareManager_UpsertGlobalMiddlewares_Call {
+	return &mockMiddlewareManager_UpsertGlobalMiddlewares_Call{Call: _e.mock.On("UpsertGlobalMiddlewares", ctx, globalConfig)}
+}
+
+func (_c *mockMiddlewareManager_UpsertGlobalMiddlewares_Call) Run(run func(ctx context.Context, globalConfig config.GlobalConfig)) *mockMiddlewareManager_UpsertGlobalMiddlewares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(config.GlobalConfig))
+	})
+	return _c
+}
+
+func (_c *mockMiddlewareManager_UpsertGlobalMiddlewares_Call) Return(_a0 error) *mockMiddlewareManager_UpsertGlobalMiddlewares_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockMiddlewareManager_UpsertGlobalMiddlewares_Call) RunAndReturn(run func(context.Context, config.GlobalConfig) error) *mockMiddlewareManager_UpsertGlobalMiddlewares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// createOrUpdateMiddleware provides a mock function with given fields: ctx, name, spec, ownerReferences
+func (_m *mockMiddlewareManager) createOrUpdateMiddleware(ctx context.Context, name string, spec v1alpha1.MiddlewareSpec, ownerReferences []v1.OwnerReference) error {
+	ret := _m.Called(ctx, name, spec, ownerReferences)
+
+	if len(ret) == 0 {
+		panic("no return value specified for createOrUpdateMiddleware")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1alpha1.MiddlewareSpec, []v1.OwnerReference) error); ok {
+		r0 = rf(ctx, name, spec, ownerReferences)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockMiddlewareManager_createOrUpdateMiddleware_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'createOrUpdateMiddleware'
+type mockMiddlewareManager_createOrUpdateMiddleware_Call struct {
+	*mock.Call
+}
+
+// createOrUpdateMiddleware is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - spec v1alpha1.MiddlewareSpec
+//   - ownerReferences []v1.OwnerReference
+func (_e *mockMiddlewareManager_Expecter) createOrUpdateMiddleware(ctx interface{}, name interface{}, spec interface{}, ownerReferences interface{}) *mockMiddlewareManager_createOrUpdateMiddleware_Call {
+	return &mockMiddlewareManager_createOrUpdateMiddleware_Call{Call: _e.mock.On("createOrUpdateMiddleware", ctx, name, spec, ownerReferences)}
+}
+
+func (_c *mockMiddlewareManager_createOrUpdateMiddleware_Call) Run(run func(ctx context.Context, name string, spec v1alpha1.MiddlewareSpec, ownerReferences []v1.OwnerReference)) *mockMiddlewareManager_createOrUpdateMiddleware_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(v1alpha1.MiddlewareSpec), args[3].([]v1.OwnerReference))
+	})
+	return _c
+}
+
+func (_c *mockMiddlewareManager_createOrUpdateMiddleware_Call) Return(_a0 error) *mockMiddlewareManager_createOrUpdateMiddleware_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockMiddlewareManager_createOrUpdateMiddleware_Call) RunAndReturn(run func(context.Context, string, v1alpha1.MiddlewareSpec, []v1.OwnerReference) error) *mockMiddlewareManager_createOrUpdateMiddleware_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // createOrUpdateReplacePathMiddleware provides a mock function with given fields: ctx, serviceName, cesService, ownerReferences
-func (_m *mockMiddlewareManager) createOrUpdateReplacePathMiddleware(ctx context.Context, serviceName string, cesService CesService, ownerReferences []v1.OwnerReference) (string, error) {
+func (_m *mockMiddlewareManager) createOrUpdateReplacePathMiddleware(ctx context.Context, serviceName string, cesService resolvedCesService, ownerReferences []v1.OwnerReference) (string, error) {
 	ret := _m.Called(ctx, serviceName, cesService, ownerReferences)
 
 	if len(ret) == 0 {
@@ -91,16 +193,16 @@ func (_m *mockMiddlewareManager) createOrUpdateReplacePathMiddleware(ctx context
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, CesService, []v1.OwnerReference) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, resolvedCesService, []v1.OwnerReference) (string, error)); ok {
 		return rf(ctx, serviceName, cesService, ownerReferences)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, CesService, []v1.OwnerReference) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, resolvedCesService, []v1.OwnerReference) string); ok {
 		r0 = rf(ctx, serviceName, cesService, ownerReferences)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, CesService, []v1.OwnerReference) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, resolvedCesService, []v1.OwnerReference) error); ok {
 		r1 = rf(ctx, serviceName, cesService, ownerReferences)
 	} else {
 		r1 = ret.Error(1)
@@ -117,15 +219,15 @@ type mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call struct {
 // createOrUpdateReplacePathMiddleware is a helper method to define mock.On call
 //   - ctx context.Context
 //   - serviceName string
-//   - cesService CesService
+//   - cesService resolvedCesService
 //   - ownerReferences []v1.OwnerReference
 func (_e *mockMiddlewareManager_Expecter) createOrUpdateReplacePathMiddleware(ctx interface{}, serviceName interface{}, cesService interface{}, ownerReferences interface{}) *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call {
 	return &mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call{Call: _e.mock.On("createOrUpdateReplacePathMiddleware", ctx, serviceName, cesService, ownerReferences)}
 }
 
-func (_c *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call) Run(run func(ctx context.Context, serviceName string, cesService CesService, ownerReferences []v1.OwnerReference)) *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call {
+func (_c *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call) Run(run func(ctx context.Context, serviceName string, cesService resolvedCesService, ownerReferences []v1.OwnerReference)) *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(CesService), args[3].([]v1.OwnerReference))
+		run(args[0].(context.Context), args[1].(string), args[2].(resolvedCesService), args[3].([]v1.OwnerReference))
 	})
 	return _c
 }
@@ -135,7 +237,55 @@ func (_c *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call) Return
 	return _c
 }
 
-func (_c *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call) RunAndReturn(run func(context.Context, string, CesService, []v1.OwnerReference) (string, error)) *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call {
+func (_c *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call) RunAndReturn(run func(context.Context, string, resolvedCesService, []v1.OwnerReference) (string, error)) *mockMiddlewareManager_createOrUpdateReplacePathMiddleware_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// deleteOrphanedMiddlewares provides a mock function with given fields: ctx, service, cesServices
+func (_m *mockMiddlewareManager) deleteOrphanedMiddlewares(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService) error {
+	ret := _m.Called(ctx, service, cesServices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for deleteOrphanedMiddlewares")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1.Service, []resolvedCesService) error); ok {
+		r0 = rf(ctx, service, cesServices)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockMiddlewareManager_deleteOrphanedMiddlewares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'deleteOrphanedMiddlewares'
+type mockMiddlewareManager_deleteOrphanedMiddlewares_Call struct {
+	*mock.Call
+}
+
+// deleteOrphanedMiddlewares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service *corev1.Service
+//   - cesServices []resolvedCesService
+func (_e *mockMiddlewareManager_Expecter) deleteOrphanedMiddlewares(ctx interface{}, service interface{}, cesServices interface{}) *mockMiddlewareManager_deleteOrphanedMiddlewares_Call {
+	return &mockMiddlewareManager_deleteOrphanedMiddlewares_Call{Call: _e.mock.On("deleteOrphanedMiddlewares", ctx, service, cesServices)}
+}
+
+func (_c *mockMiddlewareManager_deleteOrphanedMiddlewares_Call) Run(run func(ctx context.Context, service *corev1.Service, cesServices []resolvedCesService)) *mockMiddlewareManager_deleteOrphanedMiddlewares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*corev1.Service), args[2].([]resolvedCesService))
+	})
+	return _c
+}
+
+func (_c *mockMiddlewareManager_deleteOrphanedMiddlewares_Call) Return(_a0 error) *mockMiddlewareManager_deleteOrphanedMiddlewares_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockMiddlewareManager_deleteOrphanedMiddlewares_Call) RunAndReturn(run func(context.Context, *corev1.Service, []resolvedCesService) error) *mockMiddlewareManager_deleteOrphanedMiddlewares_Call {
 	_c.Call.Return(run)
 	return _c
 }
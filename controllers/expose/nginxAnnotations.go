@@ -0,0 +1,275 @@
+package expose
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	nginxAnnotationPrefix               = "nginx.ingress.kubernetes.io/"
+	nginxProxyBodySizeAnnotation        = nginxAnnotationPrefix + "proxy-body-size"
+	nginxRewriteTargetAnnotation        = nginxAnnotationPrefix + "rewrite-target"
+	nginxUseRegexAnnotation             = nginxAnnotationPrefix + "use-regex"
+	nginxConfigurationSnippetAnnotation = nginxAnnotationPrefix + "configuration-snippet"
+	nginxProxyConnectTimeoutAnnotation  = nginxAnnotationPrefix + "proxy-connect-timeout"
+	nginxProxyReadTimeoutAnnotation     = nginxAnnotationPrefix + "proxy-read-timeout"
+)
+
+const (
+	bufferingMiddlewareSuffix = "buffering"
+	headersMiddlewareSuffix   = "headers"
+	// rewriteMiddlewareSuffix equals the suffix of the replace path middleware, so a translated rewrite target
+	// replaces the managed path rewrite of the ces service.
+	rewriteMiddlewareSuffix = "rewrite"
+)
+
+const annotationTranslationEventReason = "IngressAnnotationTranslation"
+
+// ignoredNginxAnnotationsAnnotation stores the untranslated nginx annotations of the ces services of a service, so
+// the warning events are only recorded if the translation result changes.
+const ignoredNginxAnnotationsAnnotation = "k8s-service-discovery.cloudogu.com/ignored-nginx-annotations"
+
+var (
+	nginxSizeRegex      = regexp.MustCompile(`^(\d+)([kKmMgG]?)$`)
+	nginxDirectiveRegex = regexp.MustCompile(`^(\S+)\s+(.*)$`)
+)
+
+// nginxTranslation contains the traefik equivalents of the nginx annotations of a dogu.
+type nginxTranslation struct {
+	// middlewares maps the name suffixes of the translated middlewares to their specs.
+	middlewares map[string]traefikapi.MiddlewareSpec
+	// forwardingTimeouts of the backend of the dogu. Nil if no timeout annotation was translated.
+	forwardingTimeouts *traefikapi.ForwardingTimeouts
+	// untranslated describes the annotations and snippet directives without traefik equivalent.
+	untranslated []string
+}
+
+// getMiddlewareSuffixes returns the sorted name suffixes of the translated middlewares. The rewrite comes first, as
+// all other middlewares expect the rewritten path.
+func (t nginxTranslation) getMiddlewareSuffixes() []string {
+	suffixes := slices.Sorted(maps.Keys(t.middlewares))
+	if index := slices.Index(suffixes, rewriteMiddlewareSuffix); index > 0 {
+		suffixes = append([]string{rewriteMiddlewareSuffix}, slices.Delete(suffixes, index, index+1)...)
+	}
+
+	return suffixes
+}
+
+func (t nginxTranslation) hasRewrite() bool {
+	_, ok := t.middlewares[rewriteMiddlewareSuffix]
+	return ok
+}
+
+func getTranslatedMiddlewareName(serviceName string, cesService resolvedCesService, suffix string) string {
+	return fmt.Sprintf("%s-%s-%s", serviceName, cesService.Name, suffix)
+}
+
+// translateNginxAnnotations translates the nginx annotations of the given additional ingress annotations of a dogu
+// into traefik middlewares and forwarding timeouts. The path prefix of the ces service is matched by the regex of a
+// translated rewrite target. All nginx annotations are removed from the returned annotations, as traefik ignores them.
+func translateNginxAnnotations(annotations doguv2.IngressAnnotations, pathPrefix string) (nginxTranslation, doguv2.IngressAnnotations) {
+	translation := nginxTranslation{middlewares: map[string]traefikapi.MiddlewareSpec{}}
+	remaining := doguv2.IngressAnnotations{}
+
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		value := annotations[key]
+		if !strings.HasPrefix(key, nginxAnnotationPrefix) {
+			remaining[key] = value
+			continue
+		}
+
+		switch key {
+		case nginxProxyBodySizeAnnotation:
+			translation.translateProxyBodySize(key, value)
+		case nginxRewriteTargetAnnotation:
+			translation.middlewares[rewriteMiddlewareSuffix] = traefikapi.MiddlewareSpec{
+				ReplacePathRegex: &dynamic.ReplacePathRegex{
+					Regex:       fmt.Sprintf("^%s(/|$)(.*)", pathPrefix),
+					Replacement: value,
+				},
+			}
+		case nginxUseRegexAnnotation:
+			// the paths of rewritten ces services are regular expressions anyway
+		case nginxConfigurationSnippetAnnotation:
+			translation.translateConfigurationSnippet(value)
+		case nginxProxyConnectTimeoutAnnotation:
+			translation.translateTimeout(key, value, func(timeouts *traefikapi.ForwardingTimeouts, timeout *intstr.IntOrString) {
+				timeouts.DialTimeout = timeout
+			})
+		case nginxProxyReadTimeoutAnnotation:
+			translation.translateTimeout(key, value, func(timeouts *traefikapi.ForwardingTimeouts, timeout *intstr.IntOrString) {
+				timeouts.ResponseHeaderTimeout = timeout
+			})
+		default:
+			translation.untranslated = append(translation.untranslated, fmt.Sprintf("annotation [%s] has no traefik equivalent", key))
+		}
+	}
+
+	return translation, remaining
+}
+
+func (t *nginxTranslation) translateProxyBodySize(key string, value string) {
+	size, err := parseNginxSize(value)
+	if err != nil {
+		t.untranslated = append(t.untranslated, fmt.Sprintf("annotation [%s] has invalid value [%s]", key, value))
+		return
+	}
+
+	// nginx does not limit the body size for the value 0 and neither does traefik by default
+	if size == 0 {
+		return
+	}
+
+	t.middlewares[bufferingMiddlewareSuffix] = traefikapi.MiddlewareSpec{
+		Buffering: &dynamic.Buffering{MaxRequestBodyBytes: size},
+	}
+}
+
+// parseNginxSize parses the nginx size of the given value, e.g., `512`, `8k` or `100m`, in bytes.
+func parseNginxSize(value string) (int64, error) {
+	match := nginxSizeRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("invalid nginx size [%s]", value)
+	}
+
+	size, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid nginx size [%s]: %w", value, err)
+	}
+
+	shift := 0
+	switch strings.ToLower(match[2]) {
+	case "k":
+		shift = 10
+	case "m":
+		shift = 20
+	case "g":
+		shift = 30
+	}
+
+	if size > math.MaxInt64>>shift {
+		return 0, fmt.Errorf("nginx size [%s] exceeds the max size", value)
+	}
+
+	return size << shift, nil
+}
+
+// translateConfigurationSnippet translates the header directives of the given nginx snippet into a headers
+// middleware. All other directives are reported as untranslated.
+func (t *nginxTranslation) translateConfigurationSnippet(snippet string) {
+	headers := &dynamic.Headers{}
+
+	for _, directive := range splitNginxDirectives(snippet) {
+		name, value, ok := parseHeaderDirective(directive)
+		if !ok {
+			t.untranslated = append(t.untranslated, fmt.Sprintf("snippet directive [%s] has no traefik equivalent", directive))
+			continue
+		}
+
+		if strings.HasPrefix(directive, "proxy_set_header") {
+			headers.CustomRequestHeaders = setHeader(headers.CustomRequestHeaders, name, value)
+		} else {
+			headers.CustomResponseHeaders = setHeader(headers.CustomResponseHeaders, name, value)
+		}
+	}
+
+	if headers.CustomRequestHeaders != nil || headers.CustomResponseHeaders != nil {
+		t.middlewares[headersMiddlewareSuffix] = traefikapi.MiddlewareSpec{Headers: headers}
+	}
+}
+
+// splitNginxDirectives splits the given snippet into its trimmed directives. Semicolons inside quotes, e.g., of a
+// content security policy, don't end a directive.
+func splitNginxDirectives(snippet string) []string {
+	var directives []string
+	var current strings.Builder
+	var quote rune
+
+	for _, char := range snippet {
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote == 0 && (char == '"' || char == '\''):
+			quote = char
+		case quote == 0 && char == ';':
+			if directive := strings.TrimSpace(current.String()); directive != "" {
+				directives = append(directives, directive)
+			}
+			current.Reset()
+			continue
+		}
+
+		current.WriteRune(char)
+	}
+
+	if directive := strings.TrimSpace(current.String()); directive != "" {
+		directives = append(directives, directive)
+	}
+
+	return directives
+}
+
+// parseHeaderDirective parses the header of the nginx directives `more_set_headers "Name: value"`,
+// `add_header Name value` and `proxy_set_header Name value`. Values with nginx variables can't be translated.
+func parseHeaderDirective(directive string) (string, string, bool) {
+	match := nginxDirectiveRegex.FindStringSubmatch(directive)
+	if match == nil || strings.Contains(match[2], "$") {
+		return "", "", false
+	}
+
+	switch match[1] {
+	case "more_set_headers":
+		name, value, ok := strings.Cut(unquote(match[2]), ":")
+		return strings.TrimSpace(name), strings.TrimSpace(value), ok
+	case "add_header", "proxy_set_header":
+		name, value, ok := strings.Cut(match[2], " ")
+		value = strings.TrimSuffix(strings.TrimSpace(value), " always")
+		return name, unquote(value), ok
+	default:
+		return "", "", false
+	}
+}
+
+// unquote removes the enclosing quotes of the given nginx value. Quotes inside the value are kept.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+func setHeader(headers map[string]string, name string, value string) map[string]string {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+
+	headers[name] = value
+	return headers
+}
+
+// translateTimeout translates the nginx timeout in seconds of the given annotation into a forwarding timeout.
+func (t *nginxTranslation) translateTimeout(key string, value string, set func(timeouts *traefikapi.ForwardingTimeouts, timeout *intstr.IntOrString)) {
+	seconds, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "s"))
+	if err != nil || seconds < 0 {
+		t.untranslated = append(t.untranslated, fmt.Sprintf("annotation [%s] has invalid value [%s]", key, value))
+		return
+	}
+
+	if t.forwardingTimeouts == nil {
+		t.forwardingTimeouts = &traefikapi.ForwardingTimeouts{}
+	}
+
+	timeout := intstr.FromString(fmt.Sprintf("%ds", seconds))
+	set(t.forwardingTimeouts, &timeout)
+}
@@ -0,0 +1,183 @@
+package expose
+
+import (
+	"testing"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_translateNginxAnnotations(t *testing.T) {
+	t.Run("should keep annotations of other controllers", func(t *testing.T) {
+		// given
+		annotations := doguv2.IngressAnnotations{"traefik.ingress.kubernetes.io/router.priority": "42"}
+
+		// when
+		translation, remaining := translateNginxAnnotations(annotations, "/test")
+
+		// then
+		assert.Empty(t, translation.middlewares)
+		assert.Nil(t, translation.forwardingTimeouts)
+		assert.Empty(t, translation.untranslated)
+		assert.Equal(t, annotations, remaining)
+	})
+	t.Run("should translate proxy body size into buffering middleware", func(t *testing.T) {
+		// when
+		translation, remaining := translateNginxAnnotations(doguv2.IngressAnnotations{"nginx.ingress.kubernetes.io/proxy-body-size": "100m"}, "/test")
+
+		// then
+		assert.Equal(t, map[string]traefikapi.MiddlewareSpec{
+			"buffering": {Buffering: &dynamic.Buffering{MaxRequestBodyBytes: 100 * 1024 * 1024}},
+		}, translation.middlewares)
+		assert.Empty(t, remaining)
+	})
+	t.Run("should not limit the body size for the proxy body size 0", func(t *testing.T) {
+		// when
+		translation, _ := translateNginxAnnotations(doguv2.IngressAnnotations{"nginx.ingress.kubernetes.io/proxy-body-size": "0"}, "/test")
+
+		// then
+		assert.Empty(t, translation.middlewares)
+		assert.Empty(t, translation.untranslated)
+	})
+	t.Run("should translate rewrite target into replace path regex middleware of the path prefix", func(t *testing.T) {
+		// given
+		annotations := doguv2.IngressAnnotations{
+			"nginx.ingress.kubernetes.io/rewrite-target": "/api/$2",
+			"nginx.ingress.kubernetes.io/use-regex":      "true",
+		}
+
+		// when
+		translation, remaining := translateNginxAnnotations(annotations, "/test")
+
+		// then
+		assert.Equal(t, map[string]traefikapi.MiddlewareSpec{
+			"rewrite": {ReplacePathRegex: &dynamic.ReplacePathRegex{Regex: "^/test(/|$)(.*)", Replacement: "/api/$2"}},
+		}, translation.middlewares)
+		assert.True(t, translation.hasRewrite())
+		assert.Empty(t, translation.untranslated)
+		assert.Empty(t, remaining)
+	})
+	t.Run("should translate header directives of the configuration snippet into headers middleware", func(t *testing.T) {
+		// given
+		snippet := `more_set_headers "Content-Security-Policy: default-src 'self'; frame-ancestors 'none'";
+add_header X-Frame-Options "SAMEORIGIN" always;
+proxy_set_header X-Forwarded-Proto https;
+proxy_set_header X-Real-IP $remote_addr;
+proxy_buffering off;`
+
+		// when
+		translation, _ := translateNginxAnnotations(doguv2.IngressAnnotations{"nginx.ingress.kubernetes.io/configuration-snippet": snippet}, "/test")
+
+		// then
+		assert.Equal(t, map[string]traefikapi.MiddlewareSpec{
+			"headers": {Headers: &dynamic.Headers{
+				CustomRequestHeaders: map[string]string{"X-Forwarded-Proto": "https"},
+				CustomResponseHeaders: map[string]string{
+					"Content-Security-Policy": "default-src 'self'; frame-ancestors 'none'",
+					"X-Frame-Options":         "SAMEORIGIN",
+				},
+			}},
+		}, translation.middlewares)
+		assert.Equal(t, []string{
+			"snippet directive [proxy_set_header X-Real-IP $remote_addr] has no traefik equivalent",
+			"snippet directive [proxy_buffering off] has no traefik equivalent",
+		}, translation.untranslated)
+	})
+	t.Run("should translate timeouts into forwarding timeouts", func(t *testing.T) {
+		// given
+		annotations := doguv2.IngressAnnotations{
+			"nginx.ingress.kubernetes.io/proxy-connect-timeout": "5",
+			"nginx.ingress.kubernetes.io/proxy-read-timeout":    "3600s",
+		}
+
+		// when
+		translation, _ := translateNginxAnnotations(annotations, "/test")
+
+		// then
+		dialTimeout := intstr.FromString("5s")
+		responseHeaderTimeout := intstr.FromString("3600s")
+		assert.Equal(t, &traefikapi.ForwardingTimeouts{DialTimeout: &dialTimeout, ResponseHeaderTimeout: &responseHeaderTimeout}, translation.forwardingTimeouts)
+		assert.Empty(t, translation.middlewares)
+	})
+	t.Run("should report invalid and unknown annotations as untranslated", func(t *testing.T) {
+		// given
+		annotations := doguv2.IngressAnnotations{
+			"nginx.ingress.kubernetes.io/proxy-body-size":    "99999999999g",
+			"nginx.ingress.kubernetes.io/proxy-read-timeout": "-1",
+			"nginx.ingress.kubernetes.io/ssl-redirect":       "false",
+		}
+
+		// when
+		translation, remaining := translateNginxAnnotations(annotations, "/test")
+
+		// then
+		assert.Equal(t, []string{
+			"annotation [nginx.ingress.kubernetes.io/proxy-body-size] has invalid value [99999999999g]",
+			"annotation [nginx.ingress.kubernetes.io/proxy-read-timeout] has invalid value [-1]",
+			"annotation [nginx.ingress.kubernetes.io/ssl-redirect] has no traefik equivalent",
+		}, translation.untranslated)
+		assert.Empty(t, translation.middlewares)
+		assert.Nil(t, translation.forwardingTimeouts)
+		assert.Empty(t, remaining)
+	})
+}
+
+func Test_nginxTranslation_getMiddlewareSuffixes(t *testing.T) {
+	t.Run("should sort suffixes with the rewrite first", func(t *testing.T) {
+		// given
+		translation := nginxTranslation{middlewares: map[string]traefikapi.MiddlewareSpec{
+			"headers":   {},
+			"rewrite":   {},
+			"buffering": {},
+		}}
+
+		// when
+		actual := translation.getMiddlewareSuffixes()
+
+		// then
+		assert.Equal(t, []string{"rewrite", "buffering", "headers"}, actual)
+	})
+}
+
+func Test_parseNginxSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{value: "512", want: 512},
+		{value: "8k", want: 8 * 1024},
+		{value: "100M", want: 100 * 1024 * 1024},
+		{value: "1g", want: 1024 * 1024 * 1024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// when
+			actual, err := parseNginxSize(tt.value)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+
+	t.Run("should fail for invalid size", func(t *testing.T) {
+		// when
+		_, err := parseNginxSize("10mb")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid nginx size [10mb]")
+	})
+	t.Run("should fail for overflowing size", func(t *testing.T) {
+		// when
+		_, err := parseNginxSize("99999999999g")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "nginx size [99999999999g] exceeds the max size")
+	})
+}
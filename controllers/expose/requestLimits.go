@@ -0,0 +1,188 @@
+package expose
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// RequestLimitsAnnotation can be appended to the service of a dogu to limit the requests of all its ces services,
+	// e.g., `{"average": 100, "period": "1s", "burst": 50, "inFlight": 10, "sourceHeader": "X-Api-Key"}`.
+	RequestLimitsAnnotation = "k8s-service-discovery.cloudogu.com/request-limits"
+)
+
+const (
+	// doguConfigRateLimitAverageKey is the dogu config key with the allowed average requests per period of a ces
+	// service, e.g., `ingress/jenkins/rate_limit/average`.
+	doguConfigRateLimitAverageKey = "ingress/%s/rate_limit/average"
+	// doguConfigRateLimitPeriodKey is the dogu config key with the period of the average requests of a ces service,
+	// e.g., `1m`. Defaults to one second.
+	doguConfigRateLimitPeriodKey = "ingress/%s/rate_limit/period"
+	// doguConfigRateLimitBurstKey is the dogu config key with the allowed burst of requests of a ces service.
+	doguConfigRateLimitBurstKey = "ingress/%s/rate_limit/burst"
+	// doguConfigInFlightLimitKey is the dogu config key with the allowed concurrent requests of a ces service.
+	doguConfigInFlightLimitKey = "ingress/%s/in_flight_limit"
+	// doguConfigLimitSourceHeaderKey is the dogu config key with the request header which groups the requests of a
+	// ces service for its limits, e.g., `X-Api-Key`. Requests are grouped by their source ip by default.
+	doguConfigLimitSourceHeaderKey = "ingress/%s/limit_source_header"
+)
+
+const (
+	rateLimitMiddlewareSuffix   = "ratelimit"
+	inFlightReqMiddlewareSuffix = "inflightreq"
+)
+
+// requestLimits limit the requests of a ces service per source. A zero value disables the respective limit.
+type requestLimits struct {
+	// Average is the allowed average of requests per period.
+	Average int64 `json:"average,omitempty"`
+	// Period of the average requests, e.g., `1m`. Defaults to one second.
+	Period string `json:"period,omitempty"`
+	// Burst is the allowed number of requests exceeding the average at once.
+	Burst int64 `json:"burst,omitempty"`
+	// InFlight is the allowed number of concurrent requests.
+	InFlight int64 `json:"inFlight,omitempty"`
+	// SourceHeader is the request header whose value groups the requests. Requests are grouped by their source ip if
+	// empty.
+	SourceHeader string `json:"sourceHeader,omitempty"`
+}
+
+// getRequestLimitsOfService returns the request limits of the annotation of the given service.
+func getRequestLimitsOfService(service *corev1.Service) (requestLimits, error) {
+	limits := requestLimits{}
+	value, ok := service.Annotations[RequestLimitsAnnotation]
+	if !ok {
+		return limits, nil
+	}
+
+	err := json.Unmarshal([]byte(value), &limits)
+	if err != nil {
+		return requestLimits{}, fmt.Errorf("failed to unmarshal request limits of service [%s]: %w", service.Name, err)
+	}
+
+	err = limits.validate()
+	if err != nil {
+		return requestLimits{}, fmt.Errorf("invalid request limits of service [%s]: %w", service.Name, err)
+	}
+
+	return limits, nil
+}
+
+// withDoguConfig returns the request limits overridden by the dogu config keys of the given ces service.
+func (rl requestLimits) withDoguConfig(doguConfig libconfig.DoguConfig, cesServiceName string) (requestLimits, error) {
+	numbers := []struct {
+		key    string
+		target *int64
+	}{
+		{key: doguConfigRateLimitAverageKey, target: &rl.Average},
+		{key: doguConfigRateLimitBurstKey, target: &rl.Burst},
+		{key: doguConfigInFlightLimitKey, target: &rl.InFlight},
+	}
+
+	for _, number := range numbers {
+		key := libconfig.Key(fmt.Sprintf(number.key, cesServiceName))
+		value, ok := doguConfig.Get(key)
+		if !ok {
+			continue
+		}
+
+		parsed, err := strconv.ParseInt(value.String(), 10, 64)
+		if err != nil {
+			return requestLimits{}, fmt.Errorf("invalid value [%s] of dogu config key [%s]: expected number", value, key)
+		}
+
+		*number.target = parsed
+	}
+
+	if period, ok := doguConfig.Get(libconfig.Key(fmt.Sprintf(doguConfigRateLimitPeriodKey, cesServiceName))); ok {
+		rl.Period = period.String()
+	}
+	if header, ok := doguConfig.Get(libconfig.Key(fmt.Sprintf(doguConfigLimitSourceHeaderKey, cesServiceName))); ok {
+		rl.SourceHeader = header.String()
+	}
+
+	err := rl.validate()
+	if err != nil {
+		return requestLimits{}, fmt.Errorf("invalid request limits of ces service [%s] in dogu config: %w", cesServiceName, err)
+	}
+
+	return rl, nil
+}
+
+func (rl requestLimits) validate() error {
+	if rl.Average < 0 || rl.Burst < 0 || rl.InFlight < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+
+	if rl.Period != "" {
+		period, err := time.ParseDuration(rl.Period)
+		if err != nil || period <= 0 {
+			return fmt.Errorf("invalid period [%s]", rl.Period)
+		}
+	}
+
+	return nil
+}
+
+// getSourceCriterion returns the criterion which groups the requests for the limits. Traefik groups by the source ip
+// if no criterion is set.
+func (rl requestLimits) getSourceCriterion() *dynamic.SourceCriterion {
+	if rl.SourceHeader == "" {
+		return nil
+	}
+
+	return &dynamic.SourceCriterion{RequestHeaderName: rl.SourceHeader}
+}
+
+// getMiddlewares returns the specs of the rate limit and in-flight request middlewares of the enabled limits mapped
+// by their name suffixes.
+func (rl requestLimits) getMiddlewares() map[string]traefikapi.MiddlewareSpec {
+	middlewares := map[string]traefikapi.MiddlewareSpec{}
+
+	if rl.Average > 0 {
+		rateLimit := &traefikapi.RateLimit{
+			Average:         &rl.Average,
+			SourceCriterion: rl.getSourceCriterion(),
+		}
+		if rl.Period != "" {
+			period := intstr.FromString(rl.Period)
+			rateLimit.Period = &period
+		}
+		if rl.Burst > 0 {
+			rateLimit.Burst = &rl.Burst
+		}
+
+		middlewares[rateLimitMiddlewareSuffix] = traefikapi.MiddlewareSpec{RateLimit: rateLimit}
+	}
+
+	if rl.InFlight > 0 {
+		middlewares[inFlightReqMiddlewareSuffix] = traefikapi.MiddlewareSpec{InFlightReq: &dynamic.InFlightReq{
+			Amount:          rl.InFlight,
+			SourceCriterion: rl.getSourceCriterion(),
+		}}
+	}
+
+	return middlewares
+}
+
+// getMiddlewareSuffixes returns the name suffixes of the middlewares of the enabled limits. The rate limit rejects
+// requests before they count as in flight.
+func (rl requestLimits) getMiddlewareSuffixes() []string {
+	var suffixes []string
+	if rl.Average > 0 {
+		suffixes = append(suffixes, rateLimitMiddlewareSuffix)
+	}
+	if rl.InFlight > 0 {
+		suffixes = append(suffixes, inFlightReqMiddlewareSuffix)
+	}
+
+	return suffixes
+}
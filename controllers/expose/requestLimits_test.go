@@ -0,0 +1,142 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_getRequestLimitsOfService(t *testing.T) {
+	t.Run("should return no limits without annotation", func(t *testing.T) {
+		// when
+		actual, err := getRequestLimitsOfService(&corev1.Service{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, requestLimits{}, actual)
+	})
+	t.Run("should parse the limits of the annotation", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			"k8s-service-discovery.cloudogu.com/request-limits": `{"average":100,"period":"1m","burst":50,"inFlight":10,"sourceHeader":"X-Api-Key"}`,
+		}}}
+
+		// when
+		actual, err := getRequestLimitsOfService(service)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, requestLimits{Average: 100, Period: "1m", Burst: 50, InFlight: 10, SourceHeader: "X-Api-Key"}, actual)
+	})
+	t.Run("should fail for invalid json", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Annotations: map[string]string{
+			"k8s-service-discovery.cloudogu.com/request-limits": `{"average":`,
+		}}}
+
+		// when
+		_, err := getRequestLimitsOfService(service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to unmarshal request limits of service [jenkins]")
+	})
+	t.Run("should fail for invalid period", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Annotations: map[string]string{
+			"k8s-service-discovery.cloudogu.com/request-limits": `{"average":100,"period":"daily"}`,
+		}}}
+
+		// when
+		_, err := getRequestLimitsOfService(service)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid request limits of service [jenkins]: invalid period [daily]")
+	})
+}
+
+func Test_requestLimits_withDoguConfig(t *testing.T) {
+	t.Run("should override the limits with the dogu config of the ces service", func(t *testing.T) {
+		// given
+		limits := requestLimits{Average: 100, Burst: 50}
+		doguConfig := config.CreateDoguConfig("jenkins", config.Entries{
+			"ingress/jenkins/rate_limit/average":  "20",
+			"ingress/jenkins/limit_source_header": "Authorization",
+			"ingress/other/in_flight_limit":       "5",
+		})
+
+		// when
+		actual, err := limits.withDoguConfig(doguConfig, "jenkins")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, requestLimits{Average: 20, Burst: 50, SourceHeader: "Authorization"}, actual)
+	})
+	t.Run("should disable a limit with zero", func(t *testing.T) {
+		// given
+		limits := requestLimits{Average: 100, InFlight: 10}
+		doguConfig := config.CreateDoguConfig("jenkins", config.Entries{"ingress/jenkins/in_flight_limit": "0"})
+
+		// when
+		actual, err := limits.withDoguConfig(doguConfig, "jenkins")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, requestLimits{Average: 100}, actual)
+		assert.Equal(t, []string{"ratelimit"}, actual.getMiddlewareSuffixes())
+	})
+	t.Run("should fail for negative limits", func(t *testing.T) {
+		// given
+		doguConfig := config.CreateDoguConfig("jenkins", config.Entries{"ingress/jenkins/rate_limit/burst": "-1"})
+
+		// when
+		_, err := requestLimits{}.withDoguConfig(doguConfig, "jenkins")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid request limits of ces service [jenkins] in dogu config: limits must not be negative")
+	})
+}
+
+func Test_requestLimits_getMiddlewares(t *testing.T) {
+	t.Run("should return no middlewares without limits", func(t *testing.T) {
+		// when
+		actual := requestLimits{SourceHeader: "X-Api-Key"}.getMiddlewares()
+
+		// then
+		assert.Empty(t, actual)
+	})
+	t.Run("should group by source ip by default", func(t *testing.T) {
+		// when
+		actual := requestLimits{Average: 100, InFlight: 10}.getMiddlewares()
+
+		// then
+		average := int64(100)
+		assert.Equal(t, map[string]traefikapi.MiddlewareSpec{
+			"ratelimit":   {RateLimit: &traefikapi.RateLimit{Average: &average}},
+			"inflightreq": {InFlightReq: &dynamic.InFlightReq{Amount: 10}},
+		}, actual)
+	})
+	t.Run("should group by the source header", func(t *testing.T) {
+		// when
+		actual := requestLimits{Average: 100, Period: "1m", Burst: 50, InFlight: 10, SourceHeader: "X-Api-Key"}.getMiddlewares()
+
+		// then
+		average := int64(100)
+		burst := int64(50)
+		period := intstr.FromString("1m")
+		sourceCriterion := &dynamic.SourceCriterion{RequestHeaderName: "X-Api-Key"}
+		assert.Equal(t, map[string]traefikapi.MiddlewareSpec{
+			"ratelimit":   {RateLimit: &traefikapi.RateLimit{Average: &average, Period: &period, Burst: &burst, SourceCriterion: sourceCriterion}},
+			"inflightreq": {InFlightReq: &dynamic.InFlightReq{Amount: 10, SourceCriterion: sourceCriterion}},
+		}, actual)
+	})
+}
@@ -0,0 +1,159 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// resolvedCesService is a ces service of the service annotation with the routing resolved from the global config, the
+// dogu config and the annotations of the service. The embedded ces service contains the resolved host, location and
+// rewrite.
+type resolvedCesService struct {
+	CesService
+	// globalMiddlewares are the references of the global middlewares of the service discovery.
+	globalMiddlewares []string
+	// globalMiddlewareSpecs are the specs of the global middlewares, which the gateway api translates into filters.
+	globalMiddlewareSpecs []globalMiddleware
+	// httpsRedirect is true if the global config redirects all http requests to https.
+	httpsRedirect bool
+	// defaultMiddlewares are the comma separated default middlewares of the global config.
+	defaultMiddlewares string
+	// middlewareOverrides are the comma separated middleware overrides of the dogu config.
+	middlewareOverrides string
+	// requestLimits of the service annotation overridden by the dogu config.
+	requestLimits requestLimits
+	// ipAllowList of the dogu config.
+	ipAllowList ipAllowList
+	// forwardAuthMiddleware is the reference of the forward auth middleware if the forward auth is enabled.
+	forwardAuthMiddleware string
+	// errorPages of the dogu of the ces service.
+	errorPages errorPages
+	// backend config of the ces service overridden by the dogu config.
+	backend backendConfig
+	// mirroring of the service annotation overridden by the dogu config.
+	mirroring mirroring
+}
+
+// serviceRoutingConfig contains the routing config of a service which applies to all of its ces services.
+type serviceRoutingConfig struct {
+	doguConfig libconfig.DoguConfig
+	// requestLimits of the service annotation.
+	requestLimits requestLimits
+	// mirroring of the service annotation overridden by the dogu config.
+	mirroring mirroring
+	// isDogu is true for the services of dogus, which are served with error pages.
+	isDogu bool
+}
+
+// resolveCesServices resolves the routing of the given ces services of the given service with the given global routing
+// config and the dogu config of the service.
+func (i *ingressUpdater) resolveCesServices(ctx context.Context, service *corev1.Service, cesServices []CesService, routingConfig globalRoutingConfig) ([]resolvedCesService, error) {
+	serviceConfig, err := i.getServiceRoutingConfig(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedServices := make([]resolvedCesService, 0, len(cesServices))
+	for _, cesService := range cesServices {
+		resolvedService, err := i.resolveCesService(cesService, service, serviceConfig, routingConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedServices = append(resolvedServices, resolvedService)
+	}
+
+	return resolvedServices, nil
+}
+
+func (i *ingressUpdater) getServiceRoutingConfig(ctx context.Context, service *corev1.Service) (serviceRoutingConfig, error) {
+	doguConfig, err := i.getDoguConfig(ctx, service)
+	if err != nil {
+		return serviceRoutingConfig{}, err
+	}
+
+	limits, err := getRequestLimitsOfService(service)
+	if err != nil {
+		return serviceRoutingConfig{}, err
+	}
+
+	serviceMirroring, err := getMirroringOfService(service)
+	if err != nil {
+		return serviceRoutingConfig{}, err
+	}
+
+	serviceMirroring, err = serviceMirroring.withDoguConfig(doguConfig)
+	if err != nil {
+		return serviceRoutingConfig{}, fmt.Errorf("invalid mirroring of service [%s]: %w", service.Name, err)
+	}
+
+	return serviceRoutingConfig{
+		doguConfig:    doguConfig,
+		requestLimits: limits,
+		mirroring:     serviceMirroring,
+		isDogu:        util.HasDoguLabel(service),
+	}, nil
+}
+
+// resolveCesService resolves the routing of a single ces service by the resolvers of the routing features.
+func (i *ingressUpdater) resolveCesService(cesService CesService, service *corev1.Service, serviceConfig serviceRoutingConfig, routingConfig globalRoutingConfig) (resolvedCesService, error) {
+	err := cesService.validateRouteMatchers()
+	if err != nil {
+		return resolvedCesService{}, fmt.Errorf("invalid route matchers of ces service [%s]: %w", cesService.Name, err)
+	}
+
+	doguConfig := serviceConfig.doguConfig
+	resolved := resolvedCesService{
+		CesService:            resolveHost(cesService, doguConfig, routingConfig.fqdn),
+		globalMiddlewares:     routingConfig.globalMiddlewares,
+		globalMiddlewareSpecs: routingConfig.globalMiddlewareSpecs,
+		httpsRedirect:         routingConfig.httpsRedirect,
+		mirroring:             serviceConfig.mirroring,
+	}
+
+	resolved.defaultMiddlewares, resolved.middlewareOverrides = resolveMiddlewareConfig(cesService, doguConfig, routingConfig)
+
+	resolved.requestLimits, err = serviceConfig.requestLimits.withDoguConfig(doguConfig, cesService.Name)
+	if err != nil {
+		return resolvedCesService{}, err
+	}
+
+	resolved.ipAllowList, err = getDoguIPAllowList(doguConfig, cesService.Name, routingConfig.ipAllowListDepth)
+	if err != nil {
+		return resolvedCesService{}, fmt.Errorf("invalid ip allowlist of ces service [%s] in dogu config: %w", cesService.Name, err)
+	}
+
+	resolved.forwardAuthMiddleware, err = i.resolveForwardAuth(cesService, doguConfig, routingConfig.forwardAuthMiddleware)
+	if err != nil {
+		return resolvedCesService{}, err
+	}
+
+	resolved.backend, err = resolveBackendConfig(cesService, doguConfig)
+	if err != nil {
+		return resolvedCesService{}, fmt.Errorf("invalid backend config of ces service [%s]: %w", cesService.Name, err)
+	}
+
+	if serviceConfig.isDogu {
+		resolved.errorPages, err = resolveErrorPages(cesService, doguConfig, service.Name)
+		if err != nil {
+			return resolvedCesService{}, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolveMiddlewareConfig returns the default middlewares of the global config and the middleware overrides of the
+// given ces service in the dogu config.
+func resolveMiddlewareConfig(cesService CesService, doguConfig libconfig.DoguConfig, routingConfig globalRoutingConfig) (string, string) {
+	overrides := ""
+	if middlewares, ok := doguConfig.Get(libconfig.Key(fmt.Sprintf(doguConfigIngressMiddlewaresKey, cesService.Name))); ok {
+		overrides = middlewares.String()
+	}
+
+	return routingConfig.defaultMiddlewares, overrides
+}
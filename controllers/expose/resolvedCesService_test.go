@@ -0,0 +1,312 @@
+package expose
+
+import (
+	"testing"
+
+	cescommons "github.com/cloudogu/ces-commons-lib/dogu"
+	cesErrors "github.com/cloudogu/ces-commons-lib/errors"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ingressUpdater_resolveCesServices(t *testing.T) {
+	doguService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:   "nexus",
+		Labels: map[string]string{"dogu.name": "nexus"},
+	}}
+	nexusErrorPages := errorPages{status: []string{"502-504"}, dogu: "nexus"}
+
+	t.Run("should serve ces services without host of a non-dogu service on the fqdn", func(t *testing.T) {
+		// given
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "k8s-ces-assets"}}
+		cesServices := []CesService{{Name: "assets", Port: 80, Location: "/assets", Pass: "/"}}
+
+		sut := ingressUpdater{}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, service, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{{CesService: CesService{Name: "assets", Port: 80, Location: "/assets", Pass: "/", Host: "ces.example.com"}}}, actual)
+	})
+	t.Run("should expand subdomain with the fqdn and serve the root path", func(t *testing.T) {
+		// given
+		cesServices := []CesService{
+			{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", Host: "nexus"},
+			{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2", Rewrite: "{\"pattern\":\"v2\",\"rewrite\":\"\"}", Host: "registry"},
+		}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", nil), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Port: 8082, Location: "/", Pass: "/nexus", Host: "nexus.ces.example.com"}, errorPages: nexusErrorPages},
+			{CesService: CesService{Name: "nexus-docker", Port: 8083, Location: "/", Pass: "/v2", Host: "registry.ces.example.com"}, errorPages: nexusErrorPages},
+		}, actual)
+	})
+	t.Run("should use fully qualified host", func(t *testing.T) {
+		// given
+		cesServices := []CesService{{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", Host: "nexus.example.com"}}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", nil), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{{CesService: CesService{Name: "nexus", Port: 8082, Location: "/", Pass: "/nexus", Host: "nexus.example.com"}, errorPages: nexusErrorPages}}, actual)
+	})
+	t.Run("should apply host and tls secret overrides from the dogu config", func(t *testing.T) {
+		// given
+		cesServices := []CesService{
+			{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus"},
+			{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2"},
+		}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/host":            "repo.example.com",
+			"ingress/nexus/tls_secret_name": "repo-certificate",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Port: 8082, Location: "/", Pass: "/nexus", Host: "repo.example.com", TLSSecretName: "repo-certificate"}, errorPages: nexusErrorPages},
+			{CesService: CesService{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2", Host: "ces.example.com"}, errorPages: nexusErrorPages},
+		}, actual)
+	})
+	t.Run("should set default middlewares of the global config and middleware overrides of the dogu config", func(t *testing.T) {
+		// given
+		cesServices := []CesService{
+			{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus"},
+			{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2"},
+		}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/middlewares": "-compress@file,auth@file",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN, defaultMiddlewares: "compress@file"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", Host: testFQDN}, defaultMiddlewares: "compress@file", middlewareOverrides: "-compress@file,auth@file", errorPages: nexusErrorPages},
+			{CesService: CesService{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2", Host: testFQDN}, defaultMiddlewares: "compress@file", errorPages: nexusErrorPages},
+		}, actual)
+	})
+	t.Run("should set request limits of the service annotation overridden by the dogu config", func(t *testing.T) {
+		// given
+		cesServices := []CesService{
+			{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus"},
+			{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2"},
+		}
+		limitedService := doguService.DeepCopy()
+		limitedService.Annotations = map[string]string{"k8s-service-discovery.cloudogu.com/request-limits": `{"average":100,"burst":50}`}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus-docker/rate_limit/average": "10",
+			"ingress/nexus-docker/rate_limit/period":  "1m",
+			"ingress/nexus-docker/in_flight_limit":    "5",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, limitedService, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", Host: testFQDN}, requestLimits: requestLimits{Average: 100, Burst: 50}, errorPages: nexusErrorPages},
+			{CesService: CesService{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2", Host: testFQDN}, requestLimits: requestLimits{Average: 10, Period: "1m", Burst: 50, InFlight: 5}, errorPages: nexusErrorPages},
+		}, actual)
+	})
+	t.Run("should fail for invalid request limits in the dogu config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/in_flight_limit": "many",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		_, err := sut.resolveCesServices(testCtx, doguService, []CesService{{Name: "nexus"}}, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value [many] of dogu config key [ingress/nexus/in_flight_limit]: expected number")
+	})
+	t.Run("should set ip allowlist of the dogu config with the global depth", func(t *testing.T) {
+		// given
+		cesServices := []CesService{{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus"}}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/ip_allowlist": "10.8.0.0/16",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN, ipAllowListDepth: 1})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", Host: testFQDN}, ipAllowList: ipAllowList{sourceRanges: []string{"10.8.0.0/16"}, depth: 1}, errorPages: nexusErrorPages},
+		}, actual)
+	})
+	t.Run("should fail for invalid ip allowlist in the dogu config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/ip_allowlist": "intranet",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		_, err := sut.resolveCesServices(testCtx, doguService, []CesService{{Name: "nexus"}}, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid ip allowlist of ces service [nexus] in dogu config")
+	})
+	t.Run("should set backend config of the ces service overridden by the dogu config", func(t *testing.T) {
+		// given
+		cesServices := []CesService{{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", BackendScheme: "https", MaxBodySize: "1g"}}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/response_timeout": "10m",
+			"ingress/nexus/max_body_size":    "0",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		noLimit := int64(0)
+		assert.Equal(t, []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", BackendScheme: "https", MaxBodySize: "1g", Host: testFQDN}, errorPages: nexusErrorPages, backend: backendConfig{scheme: "https", responseTimeout: "10m", maxBodyBytes: &noLimit}},
+		}, actual)
+	})
+	t.Run("should fail for invalid backend config in the dogu config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/idle_timeout": "forever",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		_, err := sut.resolveCesServices(testCtx, doguService, []CesService{{Name: "nexus"}}, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid backend config of ces service [nexus]: invalid timeout [forever] of dogu config key [ingress/nexus/idle_timeout]: expected duration")
+	})
+	t.Run("should set error page status of the dogu config", func(t *testing.T) {
+		// given
+		cesServices := []CesService{
+			{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus"},
+			{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2", ErrorPageStatus: "500-504"},
+		}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/error_page_status": "none",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []resolvedCesService{
+			{CesService: CesService{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus", Host: testFQDN}},
+			{CesService: CesService{Name: "nexus-docker", Port: 8083, Location: "/v2", Pass: "/v2", ErrorPageStatus: "500-504", Host: testFQDN}, errorPages: errorPages{status: []string{"500-504"}, dogu: "nexus"}},
+		}, actual)
+	})
+	t.Run("should fail for invalid error page status in the dogu config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.CreateDoguConfig("nexus", config.Entries{
+			"ingress/nexus/error_page_status": "5xx",
+		}), nil)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		_, err := sut.resolveCesServices(testCtx, doguService, []CesService{{Name: "nexus"}}, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid error page status of dogu config key [ingress/nexus/error_page_status]")
+	})
+	t.Run("should ignore missing dogu config", func(t *testing.T) {
+		// given
+		cesServices := []CesService{{Name: "nexus", Port: 8082, Location: "/nexus", Pass: "/nexus"}}
+
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.DoguConfig{}, cesErrors.NewNotFoundError(assert.AnError))
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		actual, err := sut.resolveCesServices(testCtx, doguService, cesServices, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, withDefaultErrorPages(withFQDNHost(cesServices), "nexus"), actual)
+	})
+	t.Run("should fail to get dogu config", func(t *testing.T) {
+		// given
+		doguConfigRepoMock := newMockDoguConfigRepository(t)
+		doguConfigRepoMock.EXPECT().Get(testCtx, cescommons.SimpleName("nexus")).Return(config.DoguConfig{}, assert.AnError)
+
+		sut := ingressUpdater{doguConfigRepository: doguConfigRepoMock}
+
+		// when
+		_, err := sut.resolveCesServices(testCtx, doguService, []CesService{{Name: "nexus"}}, globalRoutingConfig{fqdn: testFQDN})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get dogu config of service [nexus]")
+	})
+}
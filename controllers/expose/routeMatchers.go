@@ -0,0 +1,77 @@
+package expose
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// routeMatcherMethods are the http methods supported by the method matchers of traefik and the gateway api.
+var routeMatcherMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"}
+
+// headerNamePattern matches the tokens allowed as http header names.
+var headerNamePattern = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_|~-]+$")
+
+// hasRouteMatchers returns true if the routes of the ces service are restricted to http methods or header values.
+func (cs CesService) hasRouteMatchers() bool {
+	return len(cs.Methods) > 0 || len(cs.Headers) > 0
+}
+
+// validateRouteMatchers checks the method and header matchers of the ces service. Backticks are rejected, as they
+// delimit the values of the traefik router rules.
+func (cs CesService) validateRouteMatchers() error {
+	for _, method := range cs.Methods {
+		if !slices.Contains(routeMatcherMethods, method) {
+			return fmt.Errorf("invalid method [%s]: expected one of [%s]", method, strings.Join(routeMatcherMethods, ", "))
+		}
+	}
+
+	for _, name := range cs.getSortedHeaderNames() {
+		if !headerNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid header name [%s]", name)
+		}
+
+		if strings.ContainsAny(cs.Headers[name], "`\r\n") {
+			return fmt.Errorf("invalid value of header [%s]: backticks and line breaks are not allowed", name)
+		}
+	}
+
+	return nil
+}
+
+// getSortedHeaderNames returns the names of the header matchers in a stable order.
+func (cs CesService) getSortedHeaderNames() []string {
+	return slices.Sorted(maps.Keys(cs.Headers))
+}
+
+// getRouteMatch creates the rule of a traefik router which matches the requests of the host, the given path prefix and
+// the method and header matchers of the given ces service.
+func getRouteMatch(cesService CesService, path string) string {
+	var matchers []string
+	if cesService.hasHost() {
+		matchers = append(matchers, fmt.Sprintf("Host(`%s`)", cesService.Host))
+	}
+
+	matchers = append(matchers, fmt.Sprintf("PathPrefix(`%s`)", normalizeRoutePath(path)))
+
+	var methods []string
+	for _, method := range cesService.Methods {
+		methods = append(methods, fmt.Sprintf("Method(`%s`)", method))
+	}
+
+	switch len(methods) {
+	case 0:
+	case 1:
+		matchers = append(matchers, methods[0])
+	default:
+		matchers = append(matchers, fmt.Sprintf("(%s)", strings.Join(methods, " || ")))
+	}
+
+	for _, name := range cesService.getSortedHeaderNames() {
+		matchers = append(matchers, fmt.Sprintf("Header(`%s`, `%s`)", name, cesService.Headers[name]))
+	}
+
+	return strings.Join(matchers, " && ")
+}
@@ -0,0 +1,60 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCesService_validateRouteMatchers(t *testing.T) {
+	t.Run("should accept ces service without matchers", func(t *testing.T) {
+		assert.NoError(t, CesService{Name: "nexus"}.validateRouteMatchers())
+	})
+	t.Run("should accept methods and headers", func(t *testing.T) {
+		cesService := CesService{Name: "nexus", Methods: []string{"GET", "HEAD"}, Headers: map[string]string{"X-Api-Version": "2", "Accept": "application/json"}}
+
+		assert.NoError(t, cesService.validateRouteMatchers())
+	})
+	t.Run("should fail for unknown method", func(t *testing.T) {
+		err := CesService{Name: "nexus", Methods: []string{"FETCH"}}.validateRouteMatchers()
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid method [FETCH]: expected one of [GET, HEAD, POST, PUT, PATCH, DELETE, CONNECT, OPTIONS, TRACE]")
+	})
+	t.Run("should fail for invalid header name", func(t *testing.T) {
+		err := CesService{Name: "nexus", Headers: map[string]string{"X Api": "2"}}.validateRouteMatchers()
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid header name [X Api]")
+	})
+	t.Run("should fail for header value with backtick", func(t *testing.T) {
+		err := CesService{Name: "nexus", Headers: map[string]string{"X-Api-Version": "2`) || PathPrefix(`/"}}.validateRouteMatchers()
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value of header [X-Api-Version]: backticks and line breaks are not allowed")
+	})
+}
+
+func Test_getRouteMatch(t *testing.T) {
+	t.Run("should match host and path prefix", func(t *testing.T) {
+		assert.Equal(t, "Host(`ces.example.com`) && PathPrefix(`/nexus`)", getRouteMatch(CesService{Host: "ces.example.com"}, "/nexus/"))
+	})
+	t.Run("should match path prefix without host", func(t *testing.T) {
+		assert.Equal(t, "PathPrefix(`/nexus`)", getRouteMatch(CesService{}, "/nexus"))
+	})
+	t.Run("should match a single method", func(t *testing.T) {
+		assert.Equal(t, "Host(`ces.example.com`) && PathPrefix(`/nexus`) && Method(`GET`)", getRouteMatch(CesService{Host: "ces.example.com", Methods: []string{"GET"}}, "/nexus"))
+	})
+	t.Run("should match any of multiple methods and all headers in order", func(t *testing.T) {
+		cesService := CesService{
+			Host:    "ces.example.com",
+			Methods: []string{"GET", "POST"},
+			Headers: map[string]string{"X-Api-Version": "2", "Accept": "application/json"},
+		}
+
+		actual := getRouteMatch(cesService, "/nexus")
+
+		assert.Equal(t, "Host(`ces.example.com`) && PathPrefix(`/nexus`) && (Method(`GET`) || Method(`POST`)) && Header(`Accept`, `application/json`) && Header(`X-Api-Version`, `2`)", actual)
+	})
+}
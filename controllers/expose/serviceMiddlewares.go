@@ -0,0 +1,69 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+
+	traefikapi "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getServiceMiddlewares returns the name suffixes of the middlewares configured for the ces service in the order of
+// the middleware chain and their specs. The ip allowlist rejects requests before they count for the request limits.
+// The error pages are the last middleware of the chain, as they replace the error responses of the dogu only.
+func (cs resolvedCesService) getServiceMiddlewares() ([]string, map[string]traefikapi.MiddlewareSpec) {
+	var suffixes []string
+	specs := map[string]traefikapi.MiddlewareSpec{}
+
+	if cs.ipAllowList.isEnabled() {
+		suffixes = append(suffixes, ipAllowListMiddlewareSuffix)
+		specs[ipAllowListMiddlewareSuffix] = cs.ipAllowList.getMiddleware()
+	}
+
+	limitMiddlewares := cs.requestLimits.getMiddlewares()
+	for _, suffix := range cs.requestLimits.getMiddlewareSuffixes() {
+		suffixes = append(suffixes, suffix)
+		specs[suffix] = limitMiddlewares[suffix]
+	}
+
+	if cs.errorPages.isEnabled() {
+		suffixes = append(suffixes, errorPagesMiddlewareSuffix)
+		specs[errorPagesMiddlewareSuffix] = cs.errorPages.getMiddleware()
+	}
+
+	return suffixes, specs
+}
+
+// serviceMiddlewareRefs are the references of the middlewares configured for a ces service by their position in the
+// middleware chain.
+type serviceMiddlewareRefs struct {
+	// access are the ip allowlist and the request limits, which reject requests before the forward auth.
+	access []string
+	// errorPages replace the error responses of the dogu and follow all other middlewares.
+	errorPages []string
+}
+
+// createServiceMiddlewares creates or updates the middlewares configured for the given ces service, i.e., its ip
+// allowlist, request limits and error pages, and returns their references.
+func (i *ingressUpdater) createServiceMiddlewares(ctx context.Context, cesService resolvedCesService, service *corev1.Service, ownerReferences []v1.OwnerReference) (serviceMiddlewareRefs, error) {
+	suffixes, specs := cesService.getServiceMiddlewares()
+
+	refs := serviceMiddlewareRefs{}
+	for _, suffix := range suffixes {
+		name := getTranslatedMiddlewareName(service.Name, cesService, suffix)
+		err := i.middlewareManager.createOrUpdateMiddleware(ctx, name, specs[suffix], ownerReferences)
+		if err != nil {
+			return serviceMiddlewareRefs{}, fmt.Errorf("failed to create/update service middleware %s: %w", name, err)
+		}
+
+		ref := getCRDMiddlewareRef(i.namespace, name)
+		if suffix == errorPagesMiddlewareSuffix {
+			refs.errorPages = append(refs.errorPages, ref)
+		} else {
+			refs.access = append(refs.access, ref)
+		}
+	}
+
+	return refs, nil
+}
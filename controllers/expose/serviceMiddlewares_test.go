@@ -0,0 +1,51 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func Test_resolvedCesService_getServiceMiddlewares(t *testing.T) {
+	t.Run("should return no middlewares without configuration", func(t *testing.T) {
+		// when
+		suffixes, specs := resolvedCesService{CesService: CesService{Name: "adminer"}}.getServiceMiddlewares()
+
+		// then
+		assert.Empty(t, suffixes)
+		assert.Empty(t, specs)
+	})
+	t.Run("should order the ip allowlist before the request limits", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:    CesService{Name: "adminer"},
+			ipAllowList:   ipAllowList{sourceRanges: []string{"10.0.0.0/8"}},
+			requestLimits: requestLimits{Average: 10, InFlight: 2},
+		}
+
+		// when
+		suffixes, specs := cesService.getServiceMiddlewares()
+
+		// then
+		assert.Equal(t, []string{"ipallowlist", "ratelimit", "inflightreq"}, suffixes)
+		assert.Equal(t, &dynamic.IPAllowList{SourceRange: []string{"10.0.0.0/8"}}, specs["ipallowlist"].IPAllowList)
+		assert.Equal(t, &dynamic.InFlightReq{Amount: 2}, specs["inflightreq"].InFlightReq)
+		assert.NotNil(t, specs["ratelimit"].RateLimit)
+	})
+	t.Run("should append the error pages last", func(t *testing.T) {
+		// given
+		cesService := resolvedCesService{
+			CesService:    CesService{Name: "nexus"},
+			requestLimits: requestLimits{InFlight: 2},
+			errorPages:    errorPages{status: []string{"502-504"}, dogu: "nexus"},
+		}
+
+		// when
+		suffixes, specs := cesService.getServiceMiddlewares()
+
+		// then
+		assert.Equal(t, []string{"inflightreq", "errors"}, suffixes)
+		assert.Equal(t, []string{"502-504"}, specs["errors"].Errors.Status)
+	})
+}
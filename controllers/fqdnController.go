@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	libconfig "github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	globalConfigMapName    = "global-config"
+	globalConfigMapDataKey = "config.yaml"
+)
+
+// routingGlobalConfigKeys are the keys of the global config which are part of every ingress object.
+var routingGlobalConfigKeys = append([]libconfig.Key{primaryFQDNKey, expose.GlobalConfigMiddlewaresKey}, expose.GlobalConfigSecurityKeys...)
+
+// fqdnReconciler re-renders the ingress objects of all services if the fqdn or the middlewares of the ecosystem
+// change, as every ingress object routes the host of the fqdn through the global and default middlewares.
+type fqdnReconciler struct {
+	client         k8sClient
+	namespace      string
+	ingressUpdater IngressUpdater
+}
+
+// NewFQDNReconciler creates a new reconciler which updates all ingress objects on changes of the fqdn.
+func NewFQDNReconciler(client k8sClient, namespace string, ingressUpdater IngressUpdater) *fqdnReconciler {
+	return &fqdnReconciler{
+		client:         client,
+		namespace:      namespace,
+		ingressUpdater: ingressUpdater,
+	}
+}
+
+// Reconcile updates the ingress objects of all services in the namespace.
+func (r *fqdnReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	logger.Info("FQDN changed in global config. Refresh ingress objects accordingly...")
+
+	err := r.ingressUpdater.UpsertGlobalMiddlewares(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to upsert global middlewares: %w", err)
+	}
+
+	serviceList := &corev1.ServiceList{}
+	err = r.client.List(ctx, serviceList, &client.ListOptions{Namespace: r.namespace})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get list of all services in namespace [%s]: %w", r.namespace, err)
+	}
+
+	var errs []error
+	for _, service := range serviceList.Items {
+		logger.Info(fmt.Sprintf("Updating ingress object [%s]", service.Name))
+		err = r.ingressUpdater.UpsertIngressForService(ctx, &service)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to update ingress objects of service [%s]: %w", service.Name, err))
+		}
+	}
+
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// SetupWithManager sets up the fqdn controller with the Manager.
+// The controller watches for changes of the fqdn and the middlewares in the global configmap.
+func (r *fqdnReconciler) SetupWithManager(mgr k8sManager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(fqdnChangedPredicate())).
+		Named(FQDNControllerName).
+		WithOptions(options).
+		Complete(r)
+}
+
+func fqdnChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.TypedCreateEvent[client.Object]) bool {
+			return e.Object.GetName() == globalConfigMapName
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[client.Object]) bool {
+			if e.ObjectNew.GetName() != globalConfigMapName {
+				return false
+			}
+
+			return !maps.Equal(getRoutingEntriesFromGlobalConfigMap(e.ObjectOld), getRoutingEntriesFromGlobalConfigMap(e.ObjectNew))
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[client.Object]) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// getRoutingEntriesFromGlobalConfigMap returns the values of the routing keys of the given global config map.
+func getRoutingEntriesFromGlobalConfigMap(object client.Object) map[libconfig.Key]string {
+	configMap, ok := object.(*corev1.ConfigMap)
+	if !ok {
+		return nil
+	}
+
+	converter := &libconfig.YamlConverter{}
+	entries, err := converter.Read(strings.NewReader(configMap.Data[globalConfigMapDataKey]))
+	if err != nil {
+		return nil
+	}
+
+	routingEntries := make(map[libconfig.Key]string, len(routingGlobalConfigKeys))
+	for _, key := range routingGlobalConfigKeys {
+		routingEntries[key] = entries[key].String()
+	}
+
+	return routingEntries
+}
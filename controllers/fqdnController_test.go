@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNewFQDNReconciler(t *testing.T) {
+	t.Run("successfully create reconciler", func(t *testing.T) {
+		clientMock := testclient.NewClientBuilder().WithScheme(getScheme()).Build()
+		reconciler := NewFQDNReconciler(clientMock, testNamespace, NewMockIngressUpdater(t))
+
+		require.NotEmpty(t, reconciler)
+	})
+}
+
+func Test_fqdnReconciler_Reconcile(t *testing.T) {
+	t.Run("fail to upsert global middlewares", func(t *testing.T) {
+		// given
+		ingressUpdaterMock := NewMockIngressUpdater(t)
+		ingressUpdaterMock.EXPECT().UpsertGlobalMiddlewares(testCtx).Return(assert.AnError)
+
+		sut := &fqdnReconciler{
+			namespace:      testNamespace,
+			client:         newMockK8sClient(t),
+			ingressUpdater: ingressUpdaterMock,
+		}
+
+		// when
+		_, err := sut.Reconcile(testCtx, reconcile.Request{})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to upsert global middlewares")
+	})
+	t.Run("fail to list services", func(t *testing.T) {
+		// given
+		k8sClientMock := newMockK8sClient(t)
+		k8sClientMock.EXPECT().List(testCtx, &corev1.ServiceList{}, &client.ListOptions{Namespace: testNamespace}).Return(assert.AnError)
+		ingressUpdaterMock := NewMockIngressUpdater(t)
+		ingressUpdaterMock.EXPECT().UpsertGlobalMiddlewares(testCtx).Return(nil)
+
+		sut := &fqdnReconciler{
+			namespace:      testNamespace,
+			client:         k8sClientMock,
+			ingressUpdater: ingressUpdaterMock,
+		}
+
+		// when
+		_, err := sut.Reconcile(testCtx, reconcile.Request{})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get list of all services in namespace [my-namespace]")
+	})
+	t.Run("update ingress objects of all services even if one fails", func(t *testing.T) {
+		// given
+		failingService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "failing", Namespace: testNamespace}}
+		otherService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testNamespace}}
+		serviceList := &corev1.ServiceList{Items: []corev1.Service{*failingService, *otherService}}
+		clientMock := testclient.NewClientBuilder().WithScheme(getScheme()).WithLists(serviceList).Build()
+
+		ingressUpdaterMock := NewMockIngressUpdater(t)
+		ingressUpdaterMock.EXPECT().UpsertGlobalMiddlewares(mock.Anything).Return(nil)
+		ingressUpdaterMock.EXPECT().UpsertIngressForService(mock.Anything, mock.MatchedBy(func(service *corev1.Service) bool {
+			return service.Name == "failing"
+		})).Return(assert.AnError)
+		ingressUpdaterMock.EXPECT().UpsertIngressForService(mock.Anything, mock.MatchedBy(func(service *corev1.Service) bool {
+			return service.Name == "other"
+		})).Return(nil)
+
+		sut := &fqdnReconciler{
+			namespace:      testNamespace,
+			client:         clientMock,
+			ingressUpdater: ingressUpdaterMock,
+		}
+
+		// when
+		_, err := sut.Reconcile(context.Background(), reconcile.Request{})
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to update ingress objects of service [failing]")
+	})
+	t.Run("success", func(t *testing.T) {
+		// given
+		testService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: testNamespace}}
+		serviceList := &corev1.ServiceList{Items: []corev1.Service{*testService}}
+		clientMock := testclient.NewClientBuilder().WithScheme(getScheme()).WithLists(serviceList).Build()
+
+		ingressUpdaterMock := NewMockIngressUpdater(t)
+		ingressUpdaterMock.EXPECT().UpsertGlobalMiddlewares(mock.Anything).Return(nil)
+		ingressUpdaterMock.EXPECT().UpsertIngressForService(mock.Anything, mock.Anything).Return(nil)
+
+		sut := &fqdnReconciler{
+			namespace:      testNamespace,
+			client:         clientMock,
+			ingressUpdater: ingressUpdaterMock,
+		}
+
+		// when
+		_, err := sut.Reconcile(context.Background(), reconcile.Request{})
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func Test_fqdnChangedPredicate(t *testing.T) {
+	fqdnPredicateFuncs := fqdnChangedPredicate()
+	newGlobalConfig := func(name, fqdn string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Data:       map[string]string{"config.yaml": "fqdn: " + fqdn + "\ncertificate:\n  type: selfsigned\n"},
+		}
+	}
+
+	t.Run("reconcile created global config", func(t *testing.T) {
+		assert.True(t, fqdnPredicateFuncs.CreateFunc(event.CreateEvent{Object: newGlobalConfig("global-config", primaryFQDN)}))
+	})
+
+	t.Run("reconcile if the fqdn changed", func(t *testing.T) {
+		assert.True(t, fqdnPredicateFuncs.UpdateFunc(event.UpdateEvent{
+			ObjectOld: newGlobalConfig("global-config", primaryFQDN),
+			ObjectNew: newGlobalConfig("global-config", "other.cloudogu.com"),
+		}))
+	})
+
+	t.Run("ignore if the fqdn is unchanged", func(t *testing.T) {
+		oldGlobalConfig := newGlobalConfig("global-config", primaryFQDN)
+		changedGlobalConfig := newGlobalConfig("global-config", primaryFQDN)
+		changedGlobalConfig.Data["config.yaml"] += "alternativeFQDNs: alt.cloudogu.com\n"
+
+		assert.False(t, fqdnPredicateFuncs.UpdateFunc(event.UpdateEvent{ObjectOld: oldGlobalConfig, ObjectNew: changedGlobalConfig}))
+	})
+
+	t.Run("reconcile if the default middlewares changed", func(t *testing.T) {
+		oldGlobalConfig := newGlobalConfig("global-config", primaryFQDN)
+		changedGlobalConfig := newGlobalConfig("global-config", primaryFQDN)
+		changedGlobalConfig.Data["config.yaml"] += "ingress:\n  middlewares: my-namespace-hsts@kubernetescrd\n"
+
+		assert.True(t, fqdnPredicateFuncs.UpdateFunc(event.UpdateEvent{ObjectOld: oldGlobalConfig, ObjectNew: changedGlobalConfig}))
+	})
+
+	t.Run("reconcile if a security middleware changed", func(t *testing.T) {
+		oldGlobalConfig := newGlobalConfig("global-config", primaryFQDN)
+		changedGlobalConfig := newGlobalConfig("global-config", primaryFQDN)
+		changedGlobalConfig.Data["config.yaml"] += "ingress:\n  security:\n    hsts_max_age: \"31536000\"\n"
+
+		assert.True(t, fqdnPredicateFuncs.UpdateFunc(event.UpdateEvent{ObjectOld: oldGlobalConfig, ObjectNew: changedGlobalConfig}))
+	})
+
+	t.Run("ignore deleted and generic events", func(t *testing.T) {
+		assert.False(t, fqdnPredicateFuncs.DeleteFunc(event.DeleteEvent{Object: newGlobalConfig("global-config", primaryFQDN)}))
+		assert.False(t, fqdnPredicateFuncs.GenericFunc(event.GenericEvent{Object: newGlobalConfig("global-config", primaryFQDN)}))
+	})
+
+	t.Run("ignore any other config map", func(t *testing.T) {
+		assert.False(t, fqdnPredicateFuncs.CreateFunc(event.CreateEvent{Object: newGlobalConfig("other-config", primaryFQDN)}))
+		assert.False(t, fqdnPredicateFuncs.UpdateFunc(event.UpdateEvent{
+			ObjectOld: newGlobalConfig("other-config", primaryFQDN),
+			ObjectNew: newGlobalConfig("other-config", "other.cloudogu.com"),
+		}))
+	})
+}
@@ -2,10 +2,12 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	libconfig "github.com/cloudogu/k8s-registry-lib/config"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -35,6 +37,12 @@ type GlobalConfigRepository interface {
 type IngressUpdater interface {
 	// UpsertIngressForService creates or updates the ingress object of the given service.
 	UpsertIngressForService(ctx context.Context, service *corev1.Service) error
+	// UpsertGlobalMiddlewares creates or updates the global middlewares of the global config which are part of the
+	// ingress objects of all services.
+	UpsertGlobalMiddlewares(ctx context.Context) error
+	// UpdateCanary advances the canary of the given dogu service after an upgrade of the given deployment. It returns
+	// the time until the next step of the canary is due or zero if no step is due.
+	UpdateCanary(ctx context.Context, service *corev1.Service, deployment *appsv1.Deployment) (time.Duration, error)
 }
 
 type NetworkPolicyUpdater interface {
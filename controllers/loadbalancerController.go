@@ -13,6 +13,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -150,7 +151,7 @@ func (r *LoadBalancerReconciler) upsertLoadBalancer(ctx context.Context, namespa
 // SetupWithManager sets up the ces-loadbalancer configmap with the Manager.
 // The controller watches for changes to the ces-loadbalancer configmap as well as dogu services.
 // It also reconciles when the load-balancer changes.
-func (r *LoadBalancerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *LoadBalancerReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	if iErr := createExposedServiceIndex(mgr); iErr != nil {
 		return fmt.Errorf("failed to create index for service with exposed ports: %w", iErr)
 	}
@@ -169,7 +170,8 @@ func (r *LoadBalancerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&corev1.Service{},
 			builder.WithPredicates(loadbalancerServicePredicate()),
 		).
-		Named("loadbalancer-configmap").
+		Named(LoadBalancerControllerName).
+		WithOptions(options).
 		Complete(r)
 }
 
@@ -13,6 +13,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -133,10 +134,11 @@ func (mmu *maintenanceModeController) setMaintenanceMode(ctx context.Context, ac
 
 // SetupWithManager sets up the maintenance configmap controller with the Manager.
 // The controller watches for changes to the maintenance configmap.
-func (mmu *maintenanceModeController) SetupWithManager(mgr k8sManager) error {
+func (mmu *maintenanceModeController) SetupWithManager(mgr k8sManager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1.ConfigMap{}, builder.WithPredicates(maintenancePredicate())).
-		Named("maintenance").
+		Named(MaintenanceControllerName).
+		WithOptions(options).
 		Complete(mmu)
 }
 
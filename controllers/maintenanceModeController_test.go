@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -411,7 +412,7 @@ func Test_maintenanceModeController_SetupWithManager(t *testing.T) {
 	managerMock.EXPECT().Add(mock.Anything).Return(nil)
 	managerMock.EXPECT().GetCache().Return(nil)
 
-	err := sut.SetupWithManager(managerMock)
+	err := sut.SetupWithManager(managerMock, controller.Options{MaxConcurrentReconciles: 2})
 	assert.NoError(t, err)
 }
 
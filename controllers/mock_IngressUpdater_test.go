@@ -3,10 +3,14 @@
 package controllers
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
+
+	time "time"
 )
 
 // MockIngressUpdater is an autogenerated mock type for the IngressUpdater type
@@ -22,6 +26,110 @@ func (_m *MockIngressUpdater) EXPECT() *MockIngressUpdater_Expecter {
 	return &MockIngressUpdater_Expecter{mock: &_m.Mock}
 }
 
+// UpdateCanary provides a mock function with given fields: ctx, service, deployment
+func (_m *MockIngressUpdater) UpdateCanary(ctx context.Context, service *v1.Service, deployment *appsv1.Deployment) (time.Duration, error) {
+	ret := _m.Called(ctx, service, deployment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCanary")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Service, *appsv1.Deployment) (time.Duration, error)); ok {
+		return rf(ctx, service, deployment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Service, *appsv1.Deployment) time.Duration); ok {
+		r0 = rf(ctx, service, deployment)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Service, *appsv1.Deployment) error); ok {
+		r1 = rf(ctx, service, deployment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIngressUpdater_UpdateCanary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCanary'
+type MockIngressUpdater_UpdateCanary_Call struct {
+	*mock.Call
+}
+
+// UpdateCanary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service *v1.Service
+//   - deployment *appsv1.Deployment
+func (_e *MockIngressUpdater_Expecter) UpdateCanary(ctx interface{}, service interface{}, deployment interface{}) *MockIngressUpdater_UpdateCanary_Call {
+	return &MockIngressUpdater_UpdateCanary_Call{Call: _e.mock.On("UpdateCanary", ctx, service, deployment)}
+}
+
+func (_c *MockIngressUpdater_UpdateCanary_Call) Run(run func(ctx context.Context, service *v1.Service, deployment *appsv1.Deployment)) *MockIngressUpdater_UpdateCanary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Service), args[2].(*appsv1.Deployment))
+	})
+	return _c
+}
+
+func (_c *MockIngressUpdater_UpdateCanary_Call) Return(_a0 time.Duration, _a1 error) *MockIngressUpdater_UpdateCanary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIngressUpdater_UpdateCanary_Call) RunAndReturn(run func(context.Context, *v1.Service, *appsv1.Deployment) (time.Duration, error)) *MockIngressUpdater_UpdateCanary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertGlobalMiddlewares provides a mock function with given fields: ctx
+func (_m *MockIngressUpdater) UpsertGlobalMiddlewares(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertGlobalMiddlewares")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIngressUpdater_UpsertGlobalMiddlewares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertGlobalMiddlewares'
+type MockIngressUpdater_UpsertGlobalMiddlewares_Call struct {
+	*mock.Call
+}
+
+// UpsertGlobalMiddlewares is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIngressUpdater_Expecter) UpsertGlobalMiddlewares(ctx interface{}) *MockIngressUpdater_UpsertGlobalMiddlewares_Call {
+	return &MockIngressUpdater_UpsertGlobalMiddlewares_Call{Call: _e.mock.On("UpsertGlobalMiddlewares", ctx)}
+}
+
+func (_c *MockIngressUpdater_UpsertGlobalMiddlewares_Call) Run(run func(ctx context.Context)) *MockIngressUpdater_UpsertGlobalMiddlewares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockIngressUpdater_UpsertGlobalMiddlewares_Call) Return(_a0 error) *MockIngressUpdater_UpsertGlobalMiddlewares_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIngressUpdater_UpsertGlobalMiddlewares_Call) RunAndReturn(run func(context.Context) error) *MockIngressUpdater_UpsertGlobalMiddlewares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertIngressForService provides a mock function with given fields: ctx, service
 func (_m *MockIngressUpdater) UpsertIngressForService(ctx context.Context, service *v1.Service) error {
 	ret := _m.Called(ctx, service)
@@ -12,6 +12,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -90,11 +91,12 @@ func assignDefaultCertSecrets(altFQDNList []types.AlternativeFQDN) []types.Alter
 
 // SetupWithManager sets up the global configmap controller with the Manager.
 // The controller watches for changes to the global configmap and also reconciles when the redirect ingress object changes.
-func (r *RedirectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *RedirectReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.ConfigMap{}, builder.WithPredicates(globalConfigPredicate())).
 		Owns(&networking.Ingress{}, builder.WithPredicates(redirectIngressPredicate())).
-		Named("redirect").
+		Named(RedirectControllerName).
+		WithOptions(options).
 		Complete(r)
 }
 
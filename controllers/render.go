@@ -0,0 +1,559 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-registry-lib/repository"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/util"
+	"github.com/cloudogu/k8s-service-discovery/v2/internal/types"
+	traefikfake "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/generated/clientset/versioned/fake"
+	traefikscheme "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/generated/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+	gatewayscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+const renderMaintenanceOwner = "k8s-service-discovery"
+
+// RenderOptions configure the offline rendering of the routing objects.
+type RenderOptions struct {
+	// Namespace of the rendered objects. Manifests without a namespace are placed in this namespace.
+	Namespace string
+	// IngressController is the name of the ingress controller, e.g., `k8s-ces-gateway`.
+	IngressController string
+	// IngressClassName of the rendered ingress objects.
+	IngressClassName string
+	// NetworkPoliciesEnabled enables the rendering of the network policy of the ingress controller.
+	NetworkPoliciesEnabled bool
+	// NetworkPolicyCIDR is the ip range which is allowed to access the ingress controller.
+	NetworkPolicyCIDR string
+	// GatewayName is the name of the gateway the routes are attached to if the gateway api is used.
+	GatewayName string
+	// TraefikRoutingMode defines whether the dogus are routed via ingress objects or traefik ingress routes.
+	TraefikRoutingMode string
+}
+
+// RenderResult contains the rendered routing objects and the events recorded while rendering them.
+type RenderResult struct {
+	Objects []client.Object
+	Events  []string
+}
+
+// ReadManifests reads the services, dogus and config maps of all yaml files in the given directory. Every file may
+// contain multiple documents.
+func ReadManifests(dir string) ([]runtime.Object, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.y*ml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find manifests in directory [%s]: %w", dir, err)
+	}
+
+	slices.Sort(files)
+	decoder := serializer.NewCodecFactory(newRenderScheme()).UniversalDeserializer()
+
+	var objects []runtime.Object
+	for _, file := range files {
+		fileObjects, err := readManifestFile(file, decoder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest file [%s]: %w", file, err)
+		}
+
+		objects = append(objects, fileObjects...)
+	}
+
+	return objects, nil
+}
+
+func readManifestFile(file string, decoder runtime.Decoder) ([]runtime.Object, error) {
+	manifestFile, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer manifestFile.Close()
+
+	var objects []runtime.Object
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(manifestFile))
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.TrimSpace(string(document)) == "" {
+			continue
+		}
+
+		object, gvk, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+
+		switch object.(type) {
+		case *corev1.Service, *corev1.ConfigMap, *doguv2.Dogu:
+			objects = append(objects, object)
+		default:
+			return nil, fmt.Errorf("unsupported kind %s", gvk.Kind)
+		}
+	}
+}
+
+// Render runs the builders of the service discovery against fake clients and returns the routing objects which
+// the service discovery would create for the given services, dogus and config maps. No cluster is required.
+//
+// The rendering follows the reconcilers: the global middlewares are rendered first, then the ingress objects or http
+// routes, middlewares and network policies are rendered for every service, followed by the redirect of the
+// alternative fqdns of the global config, the load balancer and the routes of the exposed ports. The load balancer is
+// only rendered if its config map is part of the manifests. All dogus are considered to be ready.
+//
+// Errors of single services do not stop the rendering, so the result contains all objects which could be rendered.
+func Render(ctx context.Context, manifests []runtime.Object, opts RenderOptions) (RenderResult, error) {
+	var (
+		services   []*corev1.Service
+		configMaps []runtime.Object
+		dogus      = map[string]*doguv2.Dogu{}
+	)
+	for _, manifest := range manifests {
+		manifest = manifest.DeepCopyObject()
+		if objMeta, err := meta.Accessor(manifest); err == nil {
+			objMeta.SetNamespace(opts.Namespace)
+		}
+
+		switch object := manifest.(type) {
+		case *corev1.Service:
+			services = append(services, object)
+		case *corev1.ConfigMap:
+			configMaps = append(configMaps, object)
+		case *doguv2.Dogu:
+			dogus[object.Name] = object
+		}
+	}
+
+	slices.SortFunc(services, func(a, b *corev1.Service) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// the services are part of the client set, so the backend annotations of the services can be patched
+	clientObjects := slices.Clone(configMaps)
+	for _, service := range services {
+		clientObjects = append(clientObjects, service)
+	}
+
+	clientSet := k8sfake.NewClientset(clientObjects...)
+	traefikClientSet := traefikfake.NewSimpleClientset()
+	// the generated fake of traefik does not support server-side apply, so apply patches replace the whole object
+	traefikClientSet.PrependReactor("patch", "*", applyReaction(traefikClientSet.Tracker(), traefikscheme.Codecs.UniversalDeserializer()))
+
+	gatewayClientSet := gatewayfake.NewSimpleClientset()
+	gatewayClientSet.PrependReactor("patch", "*", applyReaction(gatewayClientSet.Tracker(), gatewayscheme.Codecs.UniversalDeserializer()))
+
+	recorder := &renderEventRecorder{}
+	globalConfigRepo := repository.NewGlobalConfigRepository(clientSet.CoreV1().ConfigMaps(opts.Namespace))
+	controller, err := ingressController.ParseIngressController(ingressController.Dependencies{
+		Controller:             opts.IngressController,
+		IngressInterface:       clientSet.NetworkingV1().Ingresses(opts.Namespace),
+		IngressClassName:       opts.IngressClassName,
+		TraefikInterface:       traefikClientSet.TraefikV1alpha1(),
+		Recorder:               recorder,
+		Namespace:              opts.Namespace,
+		HTTPRouteInterface:     gatewayClientSet.GatewayV1().HTTPRoutes(opts.Namespace),
+		TCPRouteInterface:      gatewayClientSet.GatewayV1alpha2().TCPRoutes(opts.Namespace),
+		UDPRouteInterface:      gatewayClientSet.GatewayV1alpha2().UDPRoutes(opts.Namespace),
+		GatewayName:            opts.GatewayName,
+		ConfigMapInterface:     clientSet.CoreV1().ConfigMaps(opts.Namespace),
+		GlobalConfigRepository: globalConfigRepo,
+	})
+	if err != nil {
+		return RenderResult{}, err
+	}
+
+	renderScheme := newRenderScheme()
+	maintenanceClient := crfake.NewClientBuilder().WithScheme(renderScheme).WithRuntimeObjects(configMaps...).Build()
+	ingressUpdaterDeps := expose.IngressUpdaterDependencies{
+		DeploymentReadyChecker: readyDeploymentChecker{},
+		IngressInterface:       clientSet.NetworkingV1().Ingresses(opts.Namespace),
+		DoguInterface:          &renderDoguClient{dogus: dogus},
+		Namespace:              opts.Namespace,
+		IngressClassName:       opts.IngressClassName,
+		Recorder:               recorder,
+		Controller:             controller,
+		MiddlewareManager:      expose.NewMiddlewareManager(traefikClientSet.TraefikV1alpha1(), opts.Namespace, recorder),
+		MaintenanceAdapter:     repository.NewMaintenanceModeAdapter(renderMaintenanceOwner, maintenanceClient, opts.Namespace),
+		GlobalConfigRepository: globalConfigRepo,
+		DoguConfigRepository:   repository.NewDoguConfigRepository(clientSet.CoreV1().ConfigMaps(opts.Namespace)),
+		HTTPRouteInterface:     gatewayClientSet.GatewayV1().HTTPRoutes(opts.Namespace),
+		GatewayName:            opts.GatewayName,
+		TraefikInterface:       traefikClientSet.TraefikV1alpha1(),
+		ServiceInterface:       clientSet.CoreV1().Services(opts.Namespace),
+	}
+
+	ingressUpdater, err := controller.NewRoutingUpdater(opts.TraefikRoutingMode, ingressUpdaterDeps)
+	if err != nil {
+		return RenderResult{}, err
+	}
+	networkPolicyHandler := expose.NewNetworkPolicyHandler(clientSet.NetworkingV1().NetworkPolicies(opts.Namespace), controller, opts.NetworkPolicyCIDR)
+
+	var errs []error
+	if err := ingressUpdater.UpsertGlobalMiddlewares(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to render global middlewares: %w", err))
+	}
+
+	for _, service := range services {
+		if err := ingressUpdater.UpsertIngressForService(ctx, service); err != nil {
+			errs = append(errs, fmt.Errorf("failed to render ingress objects of service [%s]: %w", service.Name, err))
+			continue
+		}
+
+		if opts.NetworkPoliciesEnabled {
+			if err := networkPolicyHandler.UpsertNetworkPoliciesForService(ctx, service); err != nil {
+				errs = append(errs, fmt.Errorf("failed to render network policies of service [%s]: %w", service.Name, err))
+			}
+		}
+	}
+
+	if err := renderRedirect(ctx, opts.Namespace, globalConfigRepo, controller, configMaps, renderScheme); err != nil {
+		errs = append(errs, err)
+	}
+
+	loadBalancer, err := renderLoadBalancer(ctx, opts.Namespace, services, controller, configMaps, renderScheme)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	objects, err := collectRenderedObjects(ctx, opts.Namespace, clientSet, traefikClientSet, gatewayClientSet)
+	if err != nil {
+		return RenderResult{}, err
+	}
+
+	if loadBalancer != nil {
+		objects = append(objects, loadBalancer)
+	}
+
+	return RenderResult{Objects: objects, Events: recorder.events}, errors.Join(errs...)
+}
+
+func renderRedirect(ctx context.Context, namespace string, globalConfigRepo GlobalConfigRepository, redirector AlternativeFQDNRedirector, configMaps []runtime.Object, scheme *runtime.Scheme) error {
+	globalConfigMap := findConfigMap(configMaps, globalConfigMapName)
+	if globalConfigMap == nil {
+		return nil
+	}
+
+	globalCfg, err := globalConfigRepo.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get global config: %w", err)
+	}
+
+	fqdn, ok := globalCfg.Get(primaryFQDNKey)
+	if !ok {
+		return fmt.Errorf("fqdn not found in global config")
+	}
+
+	altFQDNStr, _ := globalCfg.Get(alternativeFQDNKey)
+	altFQDNList := assignDefaultCertSecrets(types.ParseAlternativeFQDNsFromConfigString(altFQDNStr.String()))
+
+	setOwnerReference := func(targetObject metav1.Object) error {
+		return ctrl.SetControllerReference(globalConfigMap, targetObject, scheme, controllerutil.WithBlockOwnerDeletion(false))
+	}
+
+	if err = redirector.RedirectAlternativeFQDN(ctx, namespace, redirectObjectName, fqdn.String(), altFQDNList, setOwnerReference); err != nil {
+		return fmt.Errorf("failed to render redirect of alternative fqdns: %w", err)
+	}
+
+	return nil
+}
+
+func renderLoadBalancer(ctx context.Context, namespace string, services []*corev1.Service, controller IngressController, configMaps []runtime.Object, scheme *runtime.Scheme) (*corev1.Service, error) {
+	lbConfigMap := findConfigMap(configMaps, types.LoadBalancerConfigName)
+	if lbConfigMap == nil {
+		// like the load balancer reconciler, neither the load balancer nor the exposed ports are rendered without config
+		return nil, nil
+	}
+
+	lbConfig, err := types.ParseLoadbalancerConfig(lbConfigMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse loadbalancer config: %w", err)
+	}
+
+	var exposedDoguServices []types.Service
+	for _, service := range services {
+		if isExposedPortService(service) {
+			exposedDoguServices = append(exposedDoguServices, types.Service(*service))
+		}
+	}
+
+	exposedDoguPorts, err := getExposedDoguPorts(exposedDoguServices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exposed ports from services: %w", err)
+	}
+
+	loadBalancer := types.CreateLoadBalancer(namespace, lbConfig, createLoadBalancerExposedPorts(exposedDoguPorts), controller.GetSelector())
+	loadBalancerService := loadBalancer.ToK8sService()
+	loadBalancerService.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+	if err = ctrl.SetControllerReference(lbConfigMap, loadBalancerService, scheme, controllerutil.WithBlockOwnerDeletion(false)); err != nil {
+		return nil, fmt.Errorf("failed to set owner of loadbalancer: %w", err)
+	}
+
+	if err = controller.ExposePorts(ctx, namespace, exposedDoguPorts); err != nil {
+		return nil, fmt.Errorf("failed to render exposed ports of the ingress controller: %w", err)
+	}
+
+	return loadBalancerService, nil
+}
+
+func collectRenderedObjects(ctx context.Context, namespace string, clientSet *k8sfake.Clientset, traefikClientSet *traefikfake.Clientset, gatewayClientSet *gatewayfake.Clientset) ([]client.Object, error) {
+	listOptions := metav1.ListOptions{}
+
+	ingresses, err := clientSet.NetworkingV1().Ingresses(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered ingress objects: %w", err)
+	}
+
+	middlewares, err := traefikClientSet.TraefikV1alpha1().Middlewares(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered middlewares: %w", err)
+	}
+
+	ingressRoutes, err := traefikClientSet.TraefikV1alpha1().IngressRoutes(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered ingress routes: %w", err)
+	}
+
+	traefikServices, err := traefikClientSet.TraefikV1alpha1().TraefikServices(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered traefik services: %w", err)
+	}
+
+	serversTransports, err := traefikClientSet.TraefikV1alpha1().ServersTransports(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered servers transports: %w", err)
+	}
+
+	tcpRoutes, err := traefikClientSet.TraefikV1alpha1().IngressRouteTCPs(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered tcp routes: %w", err)
+	}
+
+	udpRoutes, err := traefikClientSet.TraefikV1alpha1().IngressRouteUDPs(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered udp routes: %w", err)
+	}
+
+	httpRoutes, err := gatewayClientSet.GatewayV1().HTTPRoutes(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered http routes: %w", err)
+	}
+
+	gatewayTCPRoutes, err := gatewayClientSet.GatewayV1alpha2().TCPRoutes(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered gateway tcp routes: %w", err)
+	}
+
+	gatewayUDPRoutes, err := gatewayClientSet.GatewayV1alpha2().UDPRoutes(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered gateway udp routes: %w", err)
+	}
+
+	networkPolicies, err := clientSet.NetworkingV1().NetworkPolicies(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered network policies: %w", err)
+	}
+
+	// only the config maps written by the ingress controller are rendered, not the manifests
+	configMaps, err := clientSet.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(util.K8sCesServiceDiscoveryLabels).String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rendered config maps: %w", err)
+	}
+
+	var objects []client.Object
+	objects = appendSortedByName(objects, ingresses.Items, networking.SchemeGroupVersion.WithKind("Ingress"))
+	objects = appendSortedByName(objects, middlewares.Items, traefikGroupVersion.WithKind("Middleware"))
+	objects = appendSortedByName(objects, ingressRoutes.Items, traefikGroupVersion.WithKind("IngressRoute"))
+	objects = appendSortedByName(objects, traefikServices.Items, traefikGroupVersion.WithKind("TraefikService"))
+	objects = appendSortedByName(objects, serversTransports.Items, traefikGroupVersion.WithKind("ServersTransport"))
+	objects = appendSortedByName(objects, tcpRoutes.Items, traefikGroupVersion.WithKind("IngressRouteTCP"))
+	objects = appendSortedByName(objects, udpRoutes.Items, traefikGroupVersion.WithKind("IngressRouteUDP"))
+	objects = appendSortedByName(objects, httpRoutes.Items, gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+	objects = appendSortedByName(objects, gatewayTCPRoutes.Items, gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute"))
+	objects = appendSortedByName(objects, gatewayUDPRoutes.Items, gatewayv1alpha2.SchemeGroupVersion.WithKind("UDPRoute"))
+	objects = appendSortedByName(objects, networkPolicies.Items, networking.SchemeGroupVersion.WithKind("NetworkPolicy"))
+	objects = appendSortedByName(objects, configMaps.Items, corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+
+	return objects, nil
+}
+
+// appendSortedByName appends the given items with the given kind sorted by their names, as the fake clients list
+// objects in random order.
+func appendSortedByName[T any, PT interface {
+	*T
+	client.Object
+}](objects []client.Object, items []T, gvk schema.GroupVersionKind) []client.Object {
+	sorted := make([]client.Object, 0, len(items))
+	for index := range items {
+		object := PT(&items[index])
+		object.GetObjectKind().SetGroupVersionKind(gvk)
+		sorted = append(sorted, object)
+	}
+
+	slices.SortFunc(sorted, func(a, b client.Object) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	return append(objects, sorted...)
+}
+
+var traefikGroupVersion = schema.GroupVersion{Group: "traefik.io", Version: "v1alpha1"}
+
+func findConfigMap(configMaps []runtime.Object, name string) *corev1.ConfigMap {
+	for _, object := range configMaps {
+		if configMap, ok := object.(*corev1.ConfigMap); ok && configMap.Name == name {
+			return configMap
+		}
+	}
+
+	return nil
+}
+
+// WriteYAML writes the given objects as yaml documents. Fields set by the api server, e.g., the resource version and
+// the status, are omitted.
+func WriteYAML(writer io.Writer, objects []client.Object) error {
+	for _, object := range objects {
+		fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s %s: %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetName(), err)
+		}
+
+		unstructured.RemoveNestedField(fields, "status")
+		for _, field := range []string{"creationTimestamp", "managedFields", "resourceVersion", "generation", "uid"} {
+			unstructured.RemoveNestedField(fields, "metadata", field)
+		}
+
+		document, err := yaml.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s: %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetName(), err)
+		}
+
+		if _, err = fmt.Fprintf(writer, "---\n%s", document); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newRenderScheme() *runtime.Scheme {
+	renderScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(renderScheme))
+	utilruntime.Must(doguv2.AddToScheme(renderScheme))
+
+	return renderScheme
+}
+
+// applyReaction handles apply patches of fake clients without support for server-side apply by creating or
+// replacing the object of the patch.
+func applyReaction(tracker k8stesting.ObjectTracker, decoder runtime.Decoder) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patchAction, ok := action.(k8stesting.PatchAction)
+		if !ok || patchAction.GetPatchType() != apitypes.ApplyPatchType {
+			return false, nil, nil
+		}
+
+		object, _, err := decoder.Decode(patchAction.GetPatch(), nil, nil)
+		if err != nil {
+			return true, nil, fmt.Errorf("failed to decode apply patch: %w", err)
+		}
+
+		objMeta, err := meta.Accessor(object)
+		if err != nil {
+			return true, nil, err
+		}
+		objMeta.SetNamespace(patchAction.GetNamespace())
+
+		_, err = tracker.Get(patchAction.GetResource(), patchAction.GetNamespace(), patchAction.GetName())
+		if apierrors.IsNotFound(err) {
+			err = tracker.Create(patchAction.GetResource(), object, patchAction.GetNamespace())
+		} else if err == nil {
+			err = tracker.Update(patchAction.GetResource(), object, patchAction.GetNamespace())
+		}
+
+		return true, object, err
+	}
+}
+
+// readyDeploymentChecker considers all dogus to be ready as no deployments are rendered.
+type readyDeploymentChecker struct{}
+
+func (readyDeploymentChecker) IsReady(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+// renderDoguClient serves the dogus of the rendered manifests. Only reading single dogus is supported.
+type renderDoguClient struct {
+	doguClient.DoguInterface
+	dogus map[string]*doguv2.Dogu
+}
+
+func (c *renderDoguClient) Get(_ context.Context, name string, _ metav1.GetOptions) (*doguv2.Dogu, error) {
+	dogu, ok := c.dogus[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: "k8s.cloudogu.com", Resource: "dogus"}, name)
+	}
+
+	return dogu.DeepCopy(), nil
+}
+
+// renderEventRecorder collects the recorded events as readable messages.
+type renderEventRecorder struct {
+	events []string
+}
+
+func (r *renderEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	name := ""
+	if objMeta, err := meta.Accessor(object); err == nil {
+		name = objMeta.GetName()
+	}
+
+	r.events = append(r.events, fmt.Sprintf("%s %s %s/%s: %s", eventtype, reason, object.GetObjectKind().GroupVersionKind().Kind, name, message))
+}
+
+func (r *renderEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *renderEventRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
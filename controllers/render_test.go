@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files of the render tests")
+
+var testRenderOptions = RenderOptions{
+	Namespace:         "ecosystem",
+	IngressController: "k8s-ces-gateway",
+	IngressClassName:  "k8s-ecosystem-ces-service",
+}
+
+func TestReadManifests(t *testing.T) {
+	t.Run("should read all documents of the manifest files", func(t *testing.T) {
+		// when
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, manifests, 6)
+	})
+	t.Run("should fail on unsupported kind", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "deployment.yaml"), []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: nexus\n"), 0600)
+		require.NoError(t, err)
+
+		// when
+		_, err = ReadManifests(dir)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unsupported kind Deployment")
+	})
+	t.Run("should fail on invalid manifest", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("kind: [\n"), 0600)
+		require.NoError(t, err)
+
+		// when
+		_, err = ReadManifests(dir)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read manifest file")
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Run("should render routing objects of dogus", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		// when
+		result, err := Render(testCtx, manifests, testRenderOptions)
+
+		// then
+		require.NoError(t, err)
+		assertGoldenFile(t, "testdata/render/dogus/golden.yaml", result)
+		assert.Contains(t, result.Events, "Normal IngressCreation Dogu/nexus: Created regular ingress for service [nexus].")
+	})
+	t.Run("should continue rendering if a service fails", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		serviceWithoutDogu := &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cockpit",
+				Labels:      map[string]string{"dogu.name": "cockpit"},
+				Annotations: map[string]string{"k8s-dogu-operator.cloudogu.com/ces-services": `[{"name":"cockpit","port":80,"location":"/cockpit","pass":"/cockpit"}]`},
+			},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: []corev1.ServicePort{{Name: "80", Port: 80}}},
+		}
+
+		// when
+		result, err := Render(testCtx, append([]runtime.Object{serviceWithoutDogu}, manifests...), testRenderOptions)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to render ingress objects of service [cockpit]")
+		assertGoldenFile(t, "testdata/render/dogus/golden.yaml", result)
+	})
+	t.Run("should render http routes of dogus for the gateway api", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		opts := testRenderOptions
+		opts.IngressController = "gateway-api"
+		opts.GatewayName = "ces-gateway"
+
+		// when
+		result, err := Render(testCtx, manifests, opts)
+
+		// then
+		require.NoError(t, err)
+		var rendered []string
+		for _, object := range result.Objects {
+			rendered = append(rendered, object.GetObjectKind().GroupVersionKind().Kind+"/"+object.GetName())
+		}
+		assert.Equal(t, []string{"HTTPRoute/nexus", "HTTPRoute/redmine", "Service/ces-loadbalancer"}, rendered)
+		assert.Contains(t, result.Events, "Normal IngressCreation Dogu/nexus: Created regular http route for service [nexus].")
+	})
+	t.Run("should render ingress routes and traefik services of dogus", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		opts := testRenderOptions
+		opts.TraefikRoutingMode = "ingressroute"
+
+		// when
+		result, err := Render(testCtx, manifests, opts)
+
+		// then
+		require.NoError(t, err)
+		var rendered []string
+		for _, object := range result.Objects {
+			rendered = append(rendered, object.GetObjectKind().GroupVersionKind().Kind+"/"+object.GetName())
+		}
+		assert.Equal(t, []string{
+			"Middleware/nexus-nexus-errors",
+			"Middleware/redmine-redmine-errors",
+			"Middleware/redmine-redmine-rewrite",
+			"IngressRoute/nexus",
+			"IngressRoute/redmine",
+			"TraefikService/nexus",
+			"TraefikService/redmine",
+			"Service/ces-loadbalancer",
+		}, rendered)
+		assert.Contains(t, result.Events, "Normal IngressCreation Dogu/nexus: Created regular ingress route for service [nexus].")
+	})
+	t.Run("should render ingresses with rewrite annotations and port config maps for ingress-nginx", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		opts := testRenderOptions
+		opts.IngressController = "ingress-nginx"
+
+		// when
+		result, err := Render(testCtx, manifests, opts)
+
+		// then
+		require.NoError(t, err)
+		var rendered []string
+		for _, object := range result.Objects {
+			rendered = append(rendered, object.GetObjectKind().GroupVersionKind().Kind+"/"+object.GetName())
+		}
+		assert.Equal(t, []string{
+			"Ingress/nexus",
+			"Ingress/redmine",
+			"ConfigMap/tcp-services",
+			"ConfigMap/udp-services",
+			"Service/ces-loadbalancer",
+		}, rendered)
+		assert.Equal(t, "/$2", result.Objects[1].GetAnnotations()["nginx.ingress.kubernetes.io/rewrite-target"])
+	})
+	t.Run("should fail on unknown ingress controller", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		opts := testRenderOptions
+		opts.IngressController = "unknown"
+
+		// when
+		_, err = Render(testCtx, manifests, opts)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `unknown ingress controller "unknown"`)
+	})
+	t.Run("should fail on unknown traefik routing mode", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		opts := testRenderOptions
+		opts.TraefikRoutingMode = "unknown"
+
+		// when
+		_, err = Render(testCtx, manifests, opts)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown traefik routing mode [unknown]")
+	})
+	t.Run("should fail without global config", func(t *testing.T) {
+		// given
+		manifests, err := ReadManifests("testdata/render/dogus/manifests")
+		require.NoError(t, err)
+
+		var manifestsWithoutGlobalConfig []runtime.Object
+		for _, manifest := range manifests {
+			if configMap, ok := manifest.(*corev1.ConfigMap); !ok || configMap.Name != "global-config" {
+				manifestsWithoutGlobalConfig = append(manifestsWithoutGlobalConfig, manifest)
+			}
+		}
+
+		// when
+		_, err = Render(testCtx, manifestsWithoutGlobalConfig, testRenderOptions)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get global routing config")
+	})
+}
+
+func assertGoldenFile(t *testing.T, goldenFile string, result RenderResult) {
+	t.Helper()
+
+	var actual bytes.Buffer
+	require.NoError(t, WriteYAML(&actual, result.Objects))
+
+	if *updateGoldenFiles {
+		require.NoError(t, os.WriteFile(goldenFile, actual.Bytes(), 0600))
+	}
+
+	expected, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), actual.String())
+}
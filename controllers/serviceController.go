@@ -7,10 +7,21 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	configTypeLabelKey  = "k8s.cloudogu.com/type"
+	doguConfigTypeValue = "dogu-config"
+	doguConfigDoguLabel = "dogu.name"
 )
 
 // serviceReconciler watches every Service object in the cluster and creates ingress objects accordingly.
@@ -101,14 +112,31 @@ func (r *serviceReconciler) handleDelete(ctx context.Context, req ctrl.Request)
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *serviceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *serviceReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Service{}).
 		// Only reconcile if the annotation changes.
-		WithEventFilter(predicate.AnnotationChangedPredicate{}).
+		For(&corev1.Service{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		// The dogu config may override the host of the dogu's ces services.
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(enqueueDoguServiceOfConfig), builder.WithPredicates(doguConfigPredicate())).
+		Named(ServiceControllerName).
+		WithOptions(options).
 		Complete(r)
 }
 
+func doguConfigPredicate() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetLabels()[configTypeLabelKey] == doguConfigTypeValue && object.GetLabels()[doguConfigDoguLabel] != ""
+	})
+}
+
+// enqueueDoguServiceOfConfig maps a dogu config to the service of the dogu which is named like the dogu.
+func enqueueDoguServiceOfConfig(_ context.Context, object client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: object.GetNamespace(),
+		Name:      object.GetLabels()[doguConfigDoguLabel],
+	}}}
+}
+
 func (r *serviceReconciler) getService(ctx context.Context, req ctrl.Request) (*corev1.Service, error) {
 	service := &corev1.Service{}
 	err := r.client.Get(ctx, req.NamespacedName, service)
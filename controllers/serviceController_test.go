@@ -12,7 +12,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func Test_serviceReconciler_Reconcile(t *testing.T) {
@@ -113,3 +115,46 @@ func Test_serviceReconciler_Reconcile(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func Test_doguConfigPredicate(t *testing.T) {
+	doguConfigPredicateFuncs := doguConfigPredicate()
+	doguConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      "nexus-config",
+		Namespace: testNamespace,
+		Labels:    map[string]string{"app": "ces", "k8s.cloudogu.com/type": "dogu-config", "dogu.name": "nexus"},
+	}}
+
+	t.Run("reconcile dogu config", func(t *testing.T) {
+		assert.True(t, doguConfigPredicateFuncs.CreateFunc(event.CreateEvent{Object: doguConfig}))
+		assert.True(t, doguConfigPredicateFuncs.UpdateFunc(event.UpdateEvent{ObjectOld: doguConfig, ObjectNew: doguConfig}))
+		assert.True(t, doguConfigPredicateFuncs.DeleteFunc(event.DeleteEvent{Object: doguConfig}))
+	})
+
+	t.Run("ignore any other config map", func(t *testing.T) {
+		globalConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "global-config",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app": "ces", "k8s.cloudogu.com/type": "global-config"},
+		}}
+		unlabeledConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other-config", Namespace: testNamespace}}
+
+		assert.False(t, doguConfigPredicateFuncs.CreateFunc(event.CreateEvent{Object: globalConfig}))
+		assert.False(t, doguConfigPredicateFuncs.UpdateFunc(event.UpdateEvent{ObjectOld: globalConfig, ObjectNew: globalConfig}))
+		assert.False(t, doguConfigPredicateFuncs.CreateFunc(event.CreateEvent{Object: unlabeledConfig}))
+	})
+}
+
+func Test_enqueueDoguServiceOfConfig(t *testing.T) {
+	// given
+	doguConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      "nexus-config",
+		Namespace: testNamespace,
+		Labels:    map[string]string{"k8s.cloudogu.com/type": "dogu-config", "dogu.name": "nexus"},
+	}}
+
+	// when
+	actual := enqueueDoguServiceOfConfig(testCtx, doguConfig)
+
+	// then
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "nexus"}}}, actual)
+}
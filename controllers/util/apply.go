@@ -0,0 +1,296 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// FieldManager is the field manager used to server-side apply all objects generated by the service discovery.
+	FieldManager = "k8s-service-discovery"
+	// ApplyConflictEventReason is used for events which report fields that were owned by another field manager.
+	ApplyConflictEventReason = "ApplyConflict"
+	// TraefikAPIVersion is the api version of the traefik resources generated by the service discovery.
+	TraefikAPIVersion = "traefik.io/v1alpha1"
+)
+
+// ApplyClient is implemented by all typed clients which are able to get and patch objects of type T.
+type ApplyClient[T client.Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+}
+
+// ServerSideApply applies the given object with the FieldManager of the service discovery. Only the fields set in
+// the object are owned by the service discovery, so fields set by other tools survive the apply.
+//
+// The apply is skipped if the live object already matches the given object (see IsUpToDate). This avoids needless
+// writes which would bump the resource version and cause the ingress controller to reload its configuration.
+//
+// If another field manager owns one of the applied fields, a warning event is recorded for the object and the
+// apply is repeated forcefully because the generated routing must match the desired state.
+//
+// The object must contain its api version and kind.
+func ServerSideApply[T client.Object](ctx context.Context, applyClient ApplyClient[T], obj client.Object, recorder record.EventRecorder) (T, error) {
+	var result T
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+	live, err := applyClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return result, fmt.Errorf("failed to get %s %s: %w", kind, obj.GetName(), err)
+	}
+
+	if err == nil {
+		upToDate, cErr := IsUpToDate(obj, live)
+		if cErr != nil {
+			return result, fmt.Errorf("failed to compare %s %s with live object: %w", kind, obj.GetName(), cErr)
+		}
+
+		if upToDate {
+			skippedWritesCounter.WithLabelValues(kind).Inc()
+			log.FromContext(ctx).V(1).Info("skipped apply of unchanged object", "kind", kind, "name", obj.GetName())
+			return live, nil
+		}
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal %s %s for server-side apply: %w", kind, obj.GetName(), err)
+	}
+
+	result, err = applyClient.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: FieldManager})
+	if err == nil {
+		appliedWritesCounter.WithLabelValues(kind).Inc()
+		return result, nil
+	}
+
+	if !apierrors.IsConflict(err) {
+		return result, err
+	}
+
+	recorder.Eventf(obj, corev1.EventTypeWarning, ApplyConflictEventReason, "Fields are managed by another field manager and will be overwritten: %s", err.Error())
+
+	result, err = applyClient.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: FieldManager, Force: ptr.To(true)})
+	if err == nil {
+		appliedWritesCounter.WithLabelValues(kind).Inc()
+	}
+
+	return result, err
+}
+
+// IsUpToDate reports whether the live object already matches the desired object for reconciliation purposes, i.e.,
+// whether a server-side apply of the desired object would not change the live object.
+//
+// Equality is defined as follows:
+//   - Every label, annotation and owner reference of the desired object must be set in the live object. Keys added by
+//     other actors are ignored as the apply would not remove them either.
+//   - Every field of the desired spec must be set to the same value in the live spec. Fields which are only set in
+//     the live spec, e.g., defaults of the api server, are ignored. Kinds without a spec like config maps are compared
+//     by their data.
+//   - No field owned by the FieldManager in the managed fields of the live object may be missing in the desired
+//     object, as the apply would remove it.
+//
+// Server-managed fields like the resource version and the status are ignored.
+func IsUpToDate(desired, live client.Object) (bool, error) {
+	if !isSubset(desired.GetLabels(), live.GetLabels()) ||
+		!isSubset(desired.GetAnnotations(), live.GetAnnotations()) ||
+		!containsOwnerReferences(live.GetOwnerReferences(), desired.GetOwnerReferences()) {
+		return false, nil
+	}
+
+	desiredFields, err := serializedFields(desired)
+	if err != nil {
+		return false, err
+	}
+
+	liveFields, err := serializedFields(live)
+	if err != nil {
+		return false, err
+	}
+
+	if !isSubset(getSpec(desiredFields), getSpec(liveFields)) {
+		return false, nil
+	}
+
+	owned, err := getOwnedFields(live)
+	if err != nil {
+		return false, err
+	}
+
+	return !hasRemovedFields(owned, desiredFields) && !hasRemovedOwnerReferences(owned, desired.GetOwnerReferences()), nil
+}
+
+func serializedFields(obj client.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", obj.GetName(), err)
+	}
+
+	var fields map[string]interface{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", obj.GetName(), err)
+	}
+
+	return fields, nil
+}
+
+func getSpec(fields map[string]interface{}) interface{} {
+	if spec, ok := fields["spec"]; ok {
+		return spec
+	}
+
+	return fields["data"]
+}
+
+// isSubset returns true if every value set in the desired value is set to the same value in the live value. Lists
+// must have the same length, their elements are compared in order.
+func isSubset(desired, live interface{}) bool {
+	desiredValue := reflect.ValueOf(desired)
+	if !desiredValue.IsValid() {
+		return true
+	}
+
+	switch desiredValue.Kind() {
+	case reflect.Map:
+		if desiredValue.Len() == 0 {
+			return true
+		}
+
+		liveValue := reflect.ValueOf(live)
+		if liveValue.Kind() != reflect.Map {
+			return false
+		}
+
+		for _, key := range desiredValue.MapKeys() {
+			desiredEntry := desiredValue.MapIndex(key)
+			liveEntry := liveValue.MapIndex(key)
+			if !liveEntry.IsValid() {
+				if desiredEntry.Kind() == reflect.Interface && desiredEntry.IsNil() {
+					// null values are not applied
+					continue
+				}
+
+				return false
+			}
+
+			if !isSubset(desiredEntry.Interface(), liveEntry.Interface()) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Slice:
+		if desiredValue.Len() == 0 {
+			return true
+		}
+
+		liveValue := reflect.ValueOf(live)
+		if liveValue.Kind() != reflect.Slice || liveValue.Len() != desiredValue.Len() {
+			return false
+		}
+
+		for i := 0; i < desiredValue.Len(); i++ {
+			if !isSubset(desiredValue.Index(i).Interface(), liveValue.Index(i).Interface()) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return equality.Semantic.DeepEqual(desired, live)
+	}
+}
+
+func containsOwnerReferences(live, desired []metav1.OwnerReference) bool {
+	for _, reference := range desired {
+		if !slices.ContainsFunc(live, func(liveReference metav1.OwnerReference) bool {
+			return equality.Semantic.DeepEqual(reference, liveReference)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getOwnedFields returns the fields of the live object which are owned by the FieldManager in the FieldsV1 format,
+// e.g., `{"f:metadata": {"f:labels": {"f:app": {}}}}`.
+func getOwnedFields(live client.Object) (map[string]interface{}, error) {
+	for _, entry := range live.GetManagedFields() {
+		if entry.Manager != FieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+
+		owned := map[string]interface{}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &owned); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal managed fields of %s: %w", live.GetName(), err)
+		}
+
+		return owned, nil
+	}
+
+	return nil, nil
+}
+
+// hasRemovedFields returns true if a field of the given owned fields is missing in the given desired fields. The
+// elements of lists are not checked as lists of different length are never up-to-date.
+func hasRemovedFields(owned map[string]interface{}, desired interface{}) bool {
+	desiredFields, _ := desired.(map[string]interface{})
+	for key, value := range owned {
+		name, isField := strings.CutPrefix(key, "f:")
+		if !isField {
+			continue
+		}
+
+		desiredValue, ok := desiredFields[name]
+		if !ok || desiredValue == nil {
+			return true
+		}
+
+		ownedChildren, _ := value.(map[string]interface{})
+		if hasRemovedFields(ownedChildren, desiredValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasRemovedOwnerReferences returns true if an owner reference of the given owned fields is missing in the given
+// desired owner references. Owner references are identified by their uid, e.g., `k:{"uid":"..."}`.
+func hasRemovedOwnerReferences(owned map[string]interface{}, desired []metav1.OwnerReference) bool {
+	metadata, _ := owned["f:metadata"].(map[string]interface{})
+	references, _ := metadata["f:ownerReferences"].(map[string]interface{})
+	for key := range references {
+		value, isKey := strings.CutPrefix(key, "k:")
+		if !isKey {
+			continue
+		}
+
+		reference := metav1.OwnerReference{}
+		if err := json.Unmarshal([]byte(value), &reference); err != nil {
+			continue
+		}
+
+		if !slices.ContainsFunc(desired, func(desiredReference metav1.OwnerReference) bool {
+			return desiredReference.UID == reference.UID
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,207 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestIsUpToDate(t *testing.T) {
+	pathType := networking.PathTypePrefix
+	newIngress := func(mutate func(ingress *networking.Ingress)) *networking.Ingress {
+		ingress := &networking.Ingress{
+			TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "nexus",
+				Namespace:       "ecosystem",
+				Labels:          K8sCesServiceDiscoveryLabels,
+				Annotations:     map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "ecosystem-nexus-rewrite@kubernetescrd"},
+				OwnerReferences: []metav1.OwnerReference{{Name: "nexus", UID: "uid"}},
+			},
+			Spec: networking.IngressSpec{
+				Rules: []networking.IngressRule{{
+					IngressRuleValue: networking.IngressRuleValue{HTTP: &networking.HTTPIngressRuleValue{
+						Paths: []networking.HTTPIngressPath{{
+							Path:     "/nexus",
+							PathType: &pathType,
+							Backend: networking.IngressBackend{Service: &networking.IngressServiceBackend{
+								Name: "nexus",
+								Port: networking.ServiceBackendPort{Number: 8082},
+							}},
+						}},
+					}},
+				}},
+			},
+		}
+		mutate(ingress)
+		return ingress
+	}
+
+	tests := []struct {
+		name string
+		live *networking.Ingress
+		want bool
+	}{
+		{
+			name: "equal if only server-managed fields differ",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.TypeMeta = metav1.TypeMeta{}
+				ingress.ResourceVersion = "42"
+				ingress.UID = "ingress-uid"
+				ingress.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: FieldManager}}
+				ingress.Status.LoadBalancer.Ingress = []networking.IngressLoadBalancerIngress{{IP: "10.0.0.1"}}
+			}),
+			want: true,
+		},
+		{
+			name: "not equal if an annotation differs",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.Annotations = map[string]string{"traefik.ingress.kubernetes.io/router.middlewares": "ecosystem-maintenance-mode@kubernetescrd"}
+			}),
+			want: false,
+		},
+		{
+			name: "equal if the live object has an additional annotation",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.Annotations = map[string]string{
+					"traefik.ingress.kubernetes.io/router.middlewares": "ecosystem-nexus-rewrite@kubernetescrd",
+					"example-annotation": "example-value",
+				}
+			}),
+			want: true,
+		},
+		{
+			name: "equal if the live object has an additional owner reference",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.OwnerReferences = append(ingress.OwnerReferences, metav1.OwnerReference{Name: "other", UID: "other-uid"})
+			}),
+			want: true,
+		},
+		{
+			name: "equal if the api server set defaults in the live spec",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.Spec.IngressClassName = ptr.To("k8s-ces-gateway")
+				ingress.Spec.Rules[0].Host = "ces.example.com"
+			}),
+			want: true,
+		},
+		{
+			name: "equal if the owned fields are set in the desired object",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.ManagedFields = []metav1.ManagedFieldsEntry{{
+					Manager:   FieldManager,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:traefik.ingress.kubernetes.io/router.middlewares":{}},"f:ownerReferences":{"k:{\"uid\":\"uid\"}":{}}},"f:spec":{"f:rules":{}}}`)},
+				}}
+			}),
+			want: true,
+		},
+		{
+			name: "not equal if an owned annotation was removed from the desired object",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = "0"
+				ingress.ManagedFields = []metav1.ManagedFieldsEntry{{
+					Manager:   FieldManager,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:nginx.ingress.kubernetes.io/proxy-body-size":{}}}}`)},
+				}}
+			}),
+			want: false,
+		},
+		{
+			name: "not equal if an owned spec field was removed from the desired object",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.Spec.IngressClassName = ptr.To("k8s-ces-gateway")
+				ingress.ManagedFields = []metav1.ManagedFieldsEntry{{
+					Manager:   FieldManager,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:ingressClassName":{}}}`)},
+				}}
+			}),
+			want: false,
+		},
+		{
+			name: "not equal if an owned owner reference was removed from the desired object",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.OwnerReferences = append(ingress.OwnerReferences, metav1.OwnerReference{Name: "other", UID: "other-uid"})
+				ingress.ManagedFields = []metav1.ManagedFieldsEntry{{
+					Manager:   FieldManager,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:ownerReferences":{"k:{\"uid\":\"other-uid\"}":{}}}}`)},
+				}}
+			}),
+			want: false,
+		},
+		{
+			name: "not equal if the live object has an additional path",
+			live: newIngress(func(ingress *networking.Ingress) {
+				paths := ingress.Spec.Rules[0].HTTP.Paths
+				ingress.Spec.Rules[0].HTTP.Paths = append(paths, paths[0])
+			}),
+			want: false,
+		},
+		{
+			name: "not equal if a label differs",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.Labels = map[string]string{"app": "ces"}
+			}),
+			want: false,
+		},
+		{
+			name: "not equal if the owner differs",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.OwnerReferences = []metav1.OwnerReference{{Name: "nexus", UID: "other-uid"}}
+			}),
+			want: false,
+		},
+		{
+			name: "not equal if the spec differs",
+			live: newIngress(func(ingress *networking.Ingress) {
+				ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number = 80
+			}),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := newIngress(func(*networking.Ingress) {})
+
+			got, err := IsUpToDate(desired, tt.live)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsUpToDate_configMap(t *testing.T) {
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "tcp-services", Namespace: "ecosystem", Labels: K8sCesServiceDiscoveryLabels},
+			Data:       data,
+		}
+	}
+
+	t.Run("should be equal if the data is equal", func(t *testing.T) {
+		// when
+		got, err := IsUpToDate(newConfigMap(map[string]string{"2222": "ecosystem/scm:2222"}), newConfigMap(map[string]string{"2222": "ecosystem/scm:2222"}))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, got)
+	})
+	t.Run("should not be equal if the data differs", func(t *testing.T) {
+		// when
+		got, err := IsUpToDate(newConfigMap(map[string]string{"2222": "ecosystem/scm:2222"}), newConfigMap(nil))
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, got)
+	})
+}
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// GatewayAPIVersion is the api version of the http routes generated by the service discovery.
+	GatewayAPIVersion = "gateway.networking.k8s.io/v1"
+	// GatewayAPIAlphaVersion is the api version of the tcp and udp routes generated by the service discovery.
+	GatewayAPIAlphaVersion = "gateway.networking.k8s.io/v1alpha2"
+)
+
+// NewGatewayParentReference creates the reference of a route to the gateway with the given name in the namespace of
+// the route. An empty section name attaches the route to all matching listeners of the gateway.
+//
+// The defaults of the api server are set explicitly, so an applied route is considered up-to-date (see IsUpToDate).
+func NewGatewayParentReference(gatewayName string, sectionName string) gatewayv1.ParentReference {
+	ref := gatewayv1.ParentReference{
+		Group: ptr.To(gatewayv1.Group(gatewayv1.GroupName)),
+		Kind:  ptr.To(gatewayv1.Kind("Gateway")),
+		Name:  gatewayv1.ObjectName(gatewayName),
+	}
+
+	if sectionName != "" {
+		ref.SectionName = ptr.To(gatewayv1.SectionName(sectionName))
+	}
+
+	return ref
+}
+
+// NewGatewayServiceBackendRef creates the reference of a route to the port of a service in the namespace of the route.
+//
+// The defaults of the api server are set explicitly, so an applied route is considered up-to-date (see IsUpToDate).
+func NewGatewayServiceBackendRef(serviceName string, port int32) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: ptr.To(gatewayv1.Group("")),
+			Kind:  ptr.To(gatewayv1.Kind("Service")),
+			Name:  gatewayv1.ObjectName(serviceName),
+			Port:  ptr.To(gatewayv1.PortNumber(port)),
+		},
+		Weight: ptr.To(int32(1)),
+	}
+}
+
+// NewGatewayPathPrefixMatch creates a match of a http route for all request paths with the given prefix.
+func NewGatewayPathPrefixMatch(path string) gatewayv1.HTTPRouteMatch {
+	return gatewayv1.HTTPRouteMatch{
+		Path: &gatewayv1.HTTPPathMatch{
+			Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+			Value: ptr.To(path),
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package util
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	appliedWritesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_service_discovery_applied_writes_total",
+		Help: "Number of server-side applies of generated routing objects, partitioned by kind.",
+	}, []string{"kind"})
+	skippedWritesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_service_discovery_skipped_writes_total",
+		Help: "Number of skipped server-side applies because the live object already matched the desired state, partitioned by kind.",
+	}, []string{"kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(appliedWritesCounter, skippedWritesCounter)
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
@@ -30,10 +32,12 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -43,11 +47,8 @@ const (
 )
 
 var (
-	scheme               = runtime.NewScheme()
-	logger               = ctrl.Log.WithName("k8s-service-discovery.main")
-	metricsAddr          string
-	enableLeaderElection bool
-	probeAddr            string
+	scheme = runtime.NewScheme()
+	logger = ctrl.Log.WithName("k8s-service-discovery.main")
 )
 
 type k8sManager interface {
@@ -66,13 +67,32 @@ func init() {
 }
 
 func main() {
-	if err := startManager(); err != nil {
-		logger.Error(err, "manager produced an error")
+	err := execute(context.Background(), os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	if err != nil {
+		logger.Error(err, "k8s-service-discovery produced an error")
 		os.Exit(1)
 	}
 }
 
-func startManager() error {
+// runManager parses the options of the manager from the arguments and the environment and runs the service discovery.
+func runManager(_ context.Context, args []string, _ io.Writer, stderr io.Writer) error {
+	opts, err := parseManagerOptions(args, stderr)
+	if err != nil {
+		return fmt.Errorf("failed to parse manager options: %w", err)
+	}
+
+	if err = startManager(opts); err != nil {
+		return fmt.Errorf("manager produced an error: %w", err)
+	}
+
+	return nil
+}
+
+func startManager(opts managerOptions) error {
 	logger.Info("Starting k8s-service-discovery...")
 
 	watchNamespace, err := config.ReadWatchNamespace()
@@ -82,7 +102,7 @@ func startManager() error {
 
 	ingressControllerStr := config.ReadIngressController()
 
-	options := getK8sManagerOptions(watchNamespace)
+	options := getK8sManagerOptions(watchNamespace, opts)
 	serviceDiscManager, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		return fmt.Errorf("failed to create new manager: %w", err)
@@ -100,15 +120,34 @@ func startManager() error {
 		return fmt.Errorf("failed to create traefik client: %w", err)
 	}
 
-	controller := ingressController.ParseIngressController(ingressController.Dependencies{
-		Controller:       ingressControllerStr,
-		IngressInterface: clientSet.ingressClient,
-		IngressClassName: IngressClassName,
-		TraefikInterface: traefikClient,
-		Namespace:        watchNamespace,
-	})
+	gatewayClient, err := gatewayclient.NewForConfig(serviceDiscManager.GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create gateway api client: %w", err)
+	}
+
+	gatewayName := config.ReadGatewayName()
+	httpRouteClient := gatewayClient.GatewayV1().HTTPRoutes(watchNamespace)
 
 	globalConfigRepo := repository.NewGlobalConfigRepository(clientSet.configMapClient)
+
+	controller, err := ingressController.ParseIngressController(ingressController.Dependencies{
+		Controller:             ingressControllerStr,
+		IngressInterface:       clientSet.ingressClient,
+		IngressClassName:       IngressClassName,
+		TraefikInterface:       traefikClient,
+		Recorder:               eventRecorder,
+		Namespace:              watchNamespace,
+		HTTPRouteInterface:     httpRouteClient,
+		TCPRouteInterface:      gatewayClient.GatewayV1alpha2().TCPRoutes(watchNamespace),
+		UDPRouteInterface:      gatewayClient.GatewayV1alpha2().UDPRoutes(watchNamespace),
+		GatewayName:            gatewayName,
+		ConfigMapInterface:     clientSet.configMapClient,
+		GlobalConfigRepository: globalConfigRepo,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create ingress controller: %w", err)
+	}
+
 	certSync := ssl.NewCertificateSynchronizer(clientSet.secretClient, globalConfigRepo)
 
 	if err = handleCertificateSynchronization(serviceDiscManager, certSync); err != nil {
@@ -126,11 +165,11 @@ func startManager() error {
 
 	deploymentReadyChecker := dogustart.NewDeploymentReadyChecker(clientSet.k8sClient, watchNamespace)
 
-	middlewareManager := expose.NewMiddlewareManager(traefikClient, watchNamespace)
+	middlewareManager := expose.NewMiddlewareManager(traefikClient, watchNamespace, eventRecorder)
 
 	maintenanceAdapter := repository.NewMaintenanceModeAdapter(ServiceDiscoveryMaintenanceOwner, serviceDiscManager.GetClient(), watchNamespace)
 
-	ingressUpdater := expose.NewIngressUpdater(expose.IngressUpdaterDependencies{
+	ingressUpdaterDeps := expose.IngressUpdaterDependencies{
 		DeploymentReadyChecker: deploymentReadyChecker,
 		IngressInterface:       clientSet.ingressClient,
 		DoguInterface:          ecoSystemClientSet.Dogus(watchNamespace),
@@ -140,7 +179,20 @@ func startManager() error {
 		Controller:             controller,
 		MiddlewareManager:      middlewareManager,
 		MaintenanceAdapter:     maintenanceAdapter,
-	})
+		GlobalConfigRepository: globalConfigRepo,
+		DoguConfigRepository:   repository.NewDoguConfigRepository(clientSet.configMapClient),
+		HTTPRouteInterface:     httpRouteClient,
+		GatewayName:            gatewayName,
+		TraefikInterface:       traefikClient,
+		ServiceInterface:       clientSet.serviceClient,
+		PodInterface:           clientSet.podClient,
+		DeploymentInterface:    clientSet.deploymentClient,
+	}
+
+	ingressUpdater, err := controller.NewRoutingUpdater(config.ReadTraefikRoutingMode(), ingressUpdaterDeps)
+	if err != nil {
+		return fmt.Errorf("failed to create routing updater: %w", err)
+	}
 
 	cidr, err := config.ReadNetworkPolicyCIDR()
 	if err != nil {
@@ -166,6 +218,7 @@ func startManager() error {
 		certSync,
 		maintenanceAdapter,
 		eventRecorder,
+		opts.controllerConcurrency,
 	); err != nil {
 		return fmt.Errorf("failed to configure service discovery manager: %w", err)
 	}
@@ -182,6 +235,7 @@ type k8sClientSet struct {
 	configMapClient     v1.ConfigMapInterface
 	secretClient        v1.SecretInterface
 	serviceClient       v1.ServiceInterface
+	podClient           v1.PodInterface
 	deploymentClient    appsv1.DeploymentInterface
 	ingressClient       networkingv1.IngressInterface
 	networkPolicyClient networkingv1.NetworkPolicyInterface
@@ -198,6 +252,7 @@ func getK8sClientSet(config *rest.Config, namespace string) (k8sClientSet, error
 		configMapClient:     k8sClients.CoreV1().ConfigMaps(namespace),
 		secretClient:        k8sClients.CoreV1().Secrets(namespace),
 		serviceClient:       k8sClients.CoreV1().Services(namespace),
+		podClient:           k8sClients.CoreV1().Pods(namespace),
 		deploymentClient:    k8sClients.AppsV1().Deployments(namespace),
 		ingressClient:       k8sClients.NetworkingV1().Ingresses(namespace),
 		networkPolicyClient: k8sClients.NetworkingV1().NetworkPolicies(namespace),
@@ -220,6 +275,7 @@ func configureManager(
 	certSync certificateSynchronizer,
 	maintenanceAdapter controllers.MaintenanceAdapter,
 	recorder record.EventRecorder,
+	concurrency controllerConcurrency,
 ) error {
 	if err := configureReconciler(
 		k8sManager,
@@ -233,6 +289,7 @@ func configureManager(
 		certSync,
 		maintenanceAdapter,
 		recorder,
+		concurrency,
 	); err != nil {
 		return fmt.Errorf("failed to configure reconciler: %w", err)
 	}
@@ -247,23 +304,23 @@ func configureManager(
 	return nil
 }
 
-func getK8sManagerOptions(watchNamespace string) manager.Options {
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
-		"Enable leader election for controller manager. "+
-			"Enabling this will ensure there is only one active controller manager.")
-
+func getK8sManagerOptions(watchNamespace string, opts managerOptions) manager.Options {
 	return ctrl.Options{
 		Scheme:  scheme,
-		Metrics: server.Options{BindAddress: metricsAddr},
+		Metrics: server.Options{BindAddress: opts.metricsAddr},
 		Cache: cache.Options{DefaultNamespaces: map[string]cache.Config{
 			watchNamespace: {},
 		}},
-		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "92a787f2.cloudogu.com",
+		Controller:                    ctrlconfig.Controller{MaxConcurrentReconciles: opts.maxConcurrentReconciles},
+		WebhookServer:                 webhook.NewServer(webhook.Options{Port: opts.webhookPort}),
+		HealthProbeBindAddress:        opts.probeAddr,
+		LeaderElection:                opts.leaderElection,
+		LeaderElectionID:              opts.leaderElectionID,
+		LeaderElectionNamespace:       watchNamespace,
+		LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:                 &opts.leaseDuration,
+		RenewDeadline:                 &opts.renewDeadline,
+		RetryPeriod:                   &opts.retryPeriod,
 	}
 }
 
@@ -308,19 +365,20 @@ func configureReconciler(
 	certSync certificateSynchronizer,
 	maintenanceAdapter controllers.MaintenanceAdapter,
 	recorder record.EventRecorder,
+	concurrency controllerConcurrency,
 ) error {
 	reconciler := controllers.NewServiceReconciler(k8sManager.GetClient(), ingressUpdater, networkPolicyUpdater, networkPoliciesEnabled)
-	if err := reconciler.SetupWithManager(k8sManager); err != nil {
+	if err := reconciler.SetupWithManager(k8sManager, concurrency.options(controllers.ServiceControllerName)); err != nil {
 		return fmt.Errorf("failed to setup service discovery with the manager: %w", err)
 	}
 
 	deploymentReconciler := controllers.NewDeploymentReconciler(k8sManager.GetClient(), ingressUpdater)
-	if err := deploymentReconciler.SetupWithManager(k8sManager); err != nil {
+	if err := deploymentReconciler.SetupWithManager(k8sManager, concurrency.options(controllers.DeploymentControllerName)); err != nil {
 		return fmt.Errorf("failed to setup deployment reconciler with the manager: %w", err)
 	}
 
 	ecosystemCertificateReconciler := controllers.NewEcosystemCertificateReconciler(certSync)
-	if err := ecosystemCertificateReconciler.SetupWithManager(k8sManager); err != nil {
+	if err := ecosystemCertificateReconciler.SetupWithManager(k8sManager, concurrency.options(controllers.EcosystemCertificateControllerName)); err != nil {
 		return fmt.Errorf("failed to setup ecosystem certificate reconciler with the manager: %w", err)
 	}
 
@@ -331,7 +389,7 @@ func configureReconciler(
 		Namespace:          namespace,
 	}
 
-	if err := redirectReconciler.SetupWithManager(k8sManager); err != nil {
+	if err := redirectReconciler.SetupWithManager(k8sManager, concurrency.options(controllers.RedirectControllerName)); err != nil {
 		return fmt.Errorf("failed to setup redirct reconciler with the manager: %w", err)
 	}
 
@@ -341,15 +399,20 @@ func configureReconciler(
 		SvcClient:         k8sClients.serviceClient,
 	}
 
-	if err := loadbalacnerReconciler.SetupWithManager(k8sManager); err != nil {
+	if err := loadbalacnerReconciler.SetupWithManager(k8sManager, concurrency.options(controllers.LoadBalancerControllerName)); err != nil {
 		return fmt.Errorf("failed to setup loadbalancer reconciler with the manager: %w", err)
 	}
 
 	if err := controllers.NewMaintenanceModeController(k8sManager.GetClient(), namespace, ingressUpdater, maintenanceAdapter, recorder).
-		SetupWithManager(k8sManager); err != nil {
+		SetupWithManager(k8sManager, concurrency.options(controllers.MaintenanceControllerName)); err != nil {
 		return fmt.Errorf("failed to setup maintenance mode updater with the manager: %w", err)
 	}
 
+	if err := controllers.NewFQDNReconciler(k8sManager.GetClient(), namespace, ingressUpdater).
+		SetupWithManager(k8sManager, concurrency.options(controllers.FQDNControllerName)); err != nil {
+		return fmt.Errorf("failed to setup fqdn reconciler with the manager: %w", err)
+	}
+
 	return nil
 }
 
@@ -2,9 +2,9 @@ package main
 
 import (
 	"context"
-	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,6 +22,18 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testManagerOptions = managerOptions{
+	metricsAddr:             ":8080",
+	probeAddr:               ":8081",
+	webhookPort:             9443,
+	leaderElectionID:        "92a787f2.cloudogu.com",
+	leaseDuration:           15 * time.Second,
+	renewDeadline:           10 * time.Second,
+	retryPeriod:             2 * time.Second,
+	maxConcurrentReconciles: 1,
+	controllerConcurrency:   controllerConcurrency{"service": 2},
+}
+
 type FieldIndexerStub struct {
 	mock.Mock
 }
@@ -74,10 +86,9 @@ func Test_startManager(t *testing.T) {
 		ctrl.NewManager = func(config *rest.Config, options manager.Options) (manager.Manager, error) {
 			return k8sManager, nil
 		}
-		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
 		// when
-		err = startManager()
+		err = startManager(testManagerOptions)
 
 		// then
 		require.Error(t, err)
@@ -95,10 +106,9 @@ func Test_startManager(t *testing.T) {
 		ctrl.NewManager = func(config *rest.Config, options manager.Options) (manager.Manager, error) {
 			return nil, assert.AnError
 		}
-		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
 		// when
-		err := startManager()
+		err := startManager(testManagerOptions)
 
 		// then
 		require.ErrorIs(t, err, assert.AnError)
@@ -126,10 +136,9 @@ func Test_startManager(t *testing.T) {
 		ctrl.NewManager = func(config *rest.Config, options manager.Options) (manager.Manager, error) {
 			return k8sManager, nil
 		}
-		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
 		// when
-		err := startManager()
+		err := startManager(testManagerOptions)
 
 		// then
 		require.ErrorIs(t, err, assert.AnError)
@@ -156,10 +165,9 @@ func Test_startManager(t *testing.T) {
 		ctrl.NewManager = func(config *rest.Config, options manager.Options) (manager.Manager, error) {
 			return k8sManager, nil
 		}
-		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
 		// when
-		err := startManager()
+		err := startManager(testManagerOptions)
 
 		// then
 		require.ErrorIs(t, err, assert.AnError)
@@ -187,10 +195,9 @@ func Test_startManager(t *testing.T) {
 		ctrl.NewManager = func(config *rest.Config, options manager.Options) (manager.Manager, error) {
 			return k8sManager, nil
 		}
-		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
 		// when
-		err := startManager()
+		err := startManager(testManagerOptions)
 
 		// then
 		require.ErrorIs(t, err, assert.AnError)
@@ -218,10 +225,9 @@ func Test_startManager(t *testing.T) {
 		ctrl.NewManager = func(config *rest.Config, options manager.Options) (manager.Manager, error) {
 			return k8sManager, nil
 		}
-		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
 		// when
-		err := startManager()
+		err := startManager(testManagerOptions)
 
 		// then
 		require.NoError(t, err)
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// managerOptions contains the options of the controller manager. Every option is read from its flag or, if the flag
+// is not set, from the environment variable with the flag name in upper snake case, e.g., METRICS_BIND_ADDRESS.
+type managerOptions struct {
+	metricsAddr             string
+	probeAddr               string
+	webhookPort             int
+	leaderElection          bool
+	leaderElectionID        string
+	leaseDuration           time.Duration
+	renewDeadline           time.Duration
+	retryPeriod             time.Duration
+	maxConcurrentReconciles int
+	controllerConcurrency   controllerConcurrency
+}
+
+func parseManagerOptions(args []string, output io.Writer) (managerOptions, error) {
+	opts := managerOptions{controllerConcurrency: controllerConcurrency{}}
+
+	flags := flag.NewFlagSet(runCommandName, flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(output, "Usage: k8s-service-discovery %s [flags]\n\n", runCommandName)
+		_, _ = fmt.Fprintln(output, "Runs the service discovery. Every flag can also be set by its environment variable, e.g., METRICS_BIND_ADDRESS.")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flags.StringVar(&opts.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flags.IntVar(&opts.webhookPort, "webhook-server-port", 9443, "The port the webhook server listens on.")
+	flags.BoolVar(&opts.leaderElection, "leader-elect", false,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	flags.StringVar(&opts.leaderElectionID, "leader-election-id", "92a787f2.cloudogu.com", "The name of the lease used for leader election.")
+	flags.DurationVar(&opts.leaseDuration, "leader-election-lease-duration", 15*time.Second, "The duration non-leader candidates wait before they try to acquire the leadership.")
+	flags.DurationVar(&opts.renewDeadline, "leader-election-renew-deadline", 10*time.Second, "The duration the leader retries to refresh the leadership before giving it up.")
+	flags.DurationVar(&opts.retryPeriod, "leader-election-retry-period", 2*time.Second, "The duration the candidates wait between tries of leader election actions.")
+	flags.IntVar(&opts.maxConcurrentReconciles, "max-concurrent-reconciles", 1, "The maximum number of concurrent reconciles of every controller.")
+	flags.Var(opts.controllerConcurrency, "controller-max-concurrent-reconciles",
+		fmt.Sprintf("The maximum number of concurrent reconciles of single controllers, e.g., \"service=4,deployment=2\". Controllers: %s.",
+			strings.Join(controllers.ControllerNames(), ", ")))
+
+	if err := parseFlagsAndEnv(flags, args); err != nil {
+		return managerOptions{}, err
+	}
+
+	if flags.NArg() > 0 {
+		return managerOptions{}, fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	if err := opts.validate(); err != nil {
+		return managerOptions{}, err
+	}
+
+	return opts, nil
+}
+
+func (o managerOptions) validate() error {
+	if o.maxConcurrentReconciles < 1 {
+		return fmt.Errorf("max concurrent reconciles must be positive but is %d", o.maxConcurrentReconciles)
+	}
+
+	if o.leaseDuration <= o.renewDeadline {
+		return fmt.Errorf("leader election lease duration %s must be greater than the renew deadline %s", o.leaseDuration, o.renewDeadline)
+	}
+
+	if o.renewDeadline <= o.retryPeriod {
+		return fmt.Errorf("leader election renew deadline %s must be greater than the retry period %s", o.renewDeadline, o.retryPeriod)
+	}
+
+	return nil
+}
+
+// parseFlagsAndEnv parses the flags of the arguments and sets every flag which is not passed as argument from its
+// environment variable.
+func parseFlagsAndEnv(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	passedFlags := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		passedFlags[f.Name] = true
+	})
+
+	var errs []error
+	flags.VisitAll(func(f *flag.Flag) {
+		if passedFlags[f.Name] {
+			return
+		}
+
+		envVar := getFlagEnvVar(f.Name)
+		value, found := os.LookupEnv(envVar)
+		if !found {
+			return
+		}
+
+		if err := flags.Set(f.Name, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q of environment variable [%s]: %w", value, envVar, err))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+func getFlagEnvVar(flagName string) string {
+	return strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// controllerConcurrency maps the names of controllers to their maximum number of concurrent reconciles.
+// Controllers without an entry use the default of the manager.
+type controllerConcurrency map[string]int
+
+func (c controllerConcurrency) String() string {
+	entries := make([]string, 0, len(c))
+	for name, concurrency := range c {
+		entries = append(entries, fmt.Sprintf("%s=%d", name, concurrency))
+	}
+	sort.Strings(entries)
+
+	return strings.Join(entries, ",")
+}
+
+func (c controllerConcurrency) Set(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, concurrencyStr, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			return fmt.Errorf("expected entry of format <controller>=<concurrency> but got %q", entry)
+		}
+
+		if !slices.Contains(controllers.ControllerNames(), name) {
+			return fmt.Errorf("unknown controller %q", name)
+		}
+
+		concurrency, err := strconv.Atoi(concurrencyStr)
+		if err != nil || concurrency < 1 {
+			return fmt.Errorf("concurrency of controller %q must be a positive number but is %q", name, concurrencyStr)
+		}
+
+		c[name] = concurrency
+	}
+
+	return nil
+}
+
+func (c controllerConcurrency) options(controllerName string) controller.Options {
+	return controller.Options{MaxConcurrentReconciles: c[controllerName]}
+}
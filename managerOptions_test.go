@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+func Test_parseManagerOptions(t *testing.T) {
+	t.Run("should use defaults", func(t *testing.T) {
+		// when
+		actual, err := parseManagerOptions(nil, &bytes.Buffer{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, managerOptions{
+			metricsAddr:             ":8080",
+			probeAddr:               ":8081",
+			webhookPort:             9443,
+			leaderElectionID:        "92a787f2.cloudogu.com",
+			leaseDuration:           15 * time.Second,
+			renewDeadline:           10 * time.Second,
+			retryPeriod:             2 * time.Second,
+			maxConcurrentReconciles: 1,
+			controllerConcurrency:   controllerConcurrency{},
+		}, actual)
+	})
+	t.Run("should parse flags", func(t *testing.T) {
+		// given
+		args := []string{
+			"--metrics-bind-address=127.0.0.1:8080",
+			"--health-probe-bind-address=:9081",
+			"--webhook-server-port=9444",
+			"--leader-elect",
+			"--leader-election-id=service-discovery",
+			"--leader-election-lease-duration=30s",
+			"--leader-election-renew-deadline=20s",
+			"--leader-election-retry-period=5s",
+			"--max-concurrent-reconciles=3",
+			"--controller-max-concurrent-reconciles=service=4,deployment=2",
+		}
+
+		// when
+		actual, err := parseManagerOptions(args, &bytes.Buffer{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, managerOptions{
+			metricsAddr:             "127.0.0.1:8080",
+			probeAddr:               ":9081",
+			webhookPort:             9444,
+			leaderElection:          true,
+			leaderElectionID:        "service-discovery",
+			leaseDuration:           30 * time.Second,
+			renewDeadline:           20 * time.Second,
+			retryPeriod:             5 * time.Second,
+			maxConcurrentReconciles: 3,
+			controllerConcurrency:   controllerConcurrency{"service": 4, "deployment": 2},
+		}, actual)
+	})
+	t.Run("should read options from the environment", func(t *testing.T) {
+		// given
+		t.Setenv("LEADER_ELECT", "true")
+		t.Setenv("METRICS_BIND_ADDRESS", ":9090")
+		t.Setenv("CONTROLLER_MAX_CONCURRENT_RECONCILES", "fqdn=2")
+
+		// when
+		actual, err := parseManagerOptions(nil, &bytes.Buffer{})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, actual.leaderElection)
+		assert.Equal(t, ":9090", actual.metricsAddr)
+		assert.Equal(t, controllerConcurrency{"fqdn": 2}, actual.controllerConcurrency)
+	})
+	t.Run("should prefer flags over the environment", func(t *testing.T) {
+		// given
+		t.Setenv("METRICS_BIND_ADDRESS", ":9090")
+
+		// when
+		actual, err := parseManagerOptions([]string{"--metrics-bind-address=:7070"}, &bytes.Buffer{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ":7070", actual.metricsAddr)
+	})
+	t.Run("should fail on invalid environment variable", func(t *testing.T) {
+		// given
+		t.Setenv("LEADER_ELECT", "maybe")
+
+		// when
+		_, err := parseManagerOptions(nil, &bytes.Buffer{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid value \"maybe\" of environment variable [LEADER_ELECT]")
+	})
+	t.Run("should fail on unknown flag", func(t *testing.T) {
+		// when
+		_, err := parseManagerOptions([]string{"--unknown"}, &bytes.Buffer{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "flag provided but not defined: -unknown")
+	})
+	t.Run("should fail on positional arguments", func(t *testing.T) {
+		// when
+		_, err := parseManagerOptions([]string{"nexus"}, &bytes.Buffer{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unexpected arguments [nexus]")
+	})
+	t.Run("should fail on invalid options", func(t *testing.T) {
+		// when
+		_, err := parseManagerOptions([]string{"--leader-election-lease-duration=5s"}, &bytes.Buffer{})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "leader election lease duration 5s must be greater than the renew deadline 10s")
+	})
+}
+
+func Test_managerOptions_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    managerOptions
+		wantErr string
+	}{
+		{"valid", testManagerOptions, ""},
+		{"no concurrent reconciles", withOptions(func(o *managerOptions) { o.maxConcurrentReconciles = 0 }), "max concurrent reconciles must be positive but is 0"},
+		{"renew deadline exceeds lease duration", withOptions(func(o *managerOptions) { o.renewDeadline = time.Minute }), "leader election lease duration 15s must be greater than the renew deadline 1m0s"},
+		{"retry period exceeds renew deadline", withOptions(func(o *managerOptions) { o.retryPeriod = 10 * time.Second }), "leader election renew deadline 10s must be greater than the retry period 10s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func withOptions(modify func(o *managerOptions)) managerOptions {
+	opts := testManagerOptions
+	modify(&opts)
+	return opts
+}
+
+func Test_controllerConcurrency_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    controllerConcurrency
+		wantErr string
+	}{
+		{"single controller", "service=4", controllerConcurrency{"service": 4}, ""},
+		{"several controllers", "service=4, redirect=2", controllerConcurrency{"service": 4, "redirect": 2}, ""},
+		{"empty value", "", controllerConcurrency{}, ""},
+		{"missing concurrency", "service", nil, "expected entry of format <controller>=<concurrency> but got \"service\""},
+		{"unknown controller", "nginx=2", nil, "unknown controller \"nginx\""},
+		{"invalid concurrency", "service=many", nil, "concurrency of controller \"service\" must be a positive number but is \"many\""},
+		{"zero concurrency", "service=0", nil, "concurrency of controller \"service\" must be a positive number but is \"0\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := controllerConcurrency{}
+
+			err := actual.Set(tt.value)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func Test_controllerConcurrency_String(t *testing.T) {
+	assert.Equal(t, "deployment=2,service=4", controllerConcurrency{"service": 4, "deployment": 2}.String())
+	assert.Equal(t, "", controllerConcurrency(nil).String())
+}
+
+func Test_controllerConcurrency_options(t *testing.T) {
+	sut := controllerConcurrency{"service": 4}
+
+	assert.Equal(t, controller.Options{MaxConcurrentReconciles: 4}, sut.options("service"))
+	assert.Equal(t, controller.Options{}, sut.options("fqdn"))
+}
+
+func Test_getK8sManagerOptions(t *testing.T) {
+	// when
+	actual := getK8sManagerOptions("ecosystem", withOptions(func(o *managerOptions) {
+		o.leaderElection = true
+		o.maxConcurrentReconciles = 3
+	}))
+
+	// then
+	assert.True(t, actual.LeaderElection)
+	assert.Equal(t, "92a787f2.cloudogu.com", actual.LeaderElectionID)
+	assert.Equal(t, "ecosystem", actual.LeaderElectionNamespace)
+	assert.Equal(t, 15*time.Second, *actual.LeaseDuration)
+	assert.Equal(t, 10*time.Second, *actual.RenewDeadline)
+	assert.Equal(t, 2*time.Second, *actual.RetryPeriod)
+	assert.Equal(t, 3, actual.Controller.MaxConcurrentReconciles)
+	assert.Equal(t, ":8081", actual.HealthProbeBindAddress)
+	assert.Equal(t, ":8080", actual.Metrics.BindAddress)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController"
+	"github.com/cloudogu/k8s-service-discovery/v2/controllers/expose/ingressController/gatewayapi"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const renderCommandName = "render"
+
+// runRender renders the routing objects for the manifests of a directory without connecting to a cluster and
+// writes them as yaml. Warning events recorded while rendering are written to the error output.
+func runRender(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	opts := controllers.RenderOptions{IngressClassName: IngressClassName}
+
+	flags := flag.NewFlagSet(renderCommandName, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: k8s-service-discovery %s [flags] <manifest-directory>\n\n", renderCommandName)
+		_, _ = fmt.Fprintln(stderr, "Renders the routing objects for the services, dogus and config maps of the manifest directory.")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.Namespace, "namespace", "ecosystem", "The namespace of the rendered objects.")
+	flags.StringVar(&opts.IngressController, "ingress-controller", ingressController.DefaultIngressController, "The name of the ingress controller.")
+	flags.BoolVar(&opts.NetworkPoliciesEnabled, "network-policies", false, "Render the network policy of the ingress controller.")
+	flags.StringVar(&opts.NetworkPolicyCIDR, "network-policy-cidr", "0.0.0.0/0", "The ip range which is allowed to access the ingress controller.")
+	flags.StringVar(&opts.GatewayName, "gateway-name", gatewayapi.DefaultGatewayName, "The name of the gateway the routes are attached to if the gateway api is used.")
+	flags.StringVar(&opts.TraefikRoutingMode, "traefik-routing-mode", expose.TraefikRoutingModeIngress, "Route the dogus via ingress objects (ingress) or traefik ingress routes (ingressroute).")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected exactly one manifest directory but got %d arguments", flags.NArg())
+	}
+
+	manifests, err := controllers.ReadManifests(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read manifests: %w", err)
+	}
+
+	result, renderErr := controllers.Render(ctx, manifests, opts)
+
+	for _, event := range result.Events {
+		if strings.HasPrefix(event, corev1.EventTypeWarning) {
+			_, _ = fmt.Fprintln(stderr, event)
+		}
+	}
+
+	if err = controllers.WriteYAML(stdout, result.Objects); err != nil {
+		return fmt.Errorf("failed to write rendered objects: %w", err)
+	}
+
+	if renderErr != nil {
+		return fmt.Errorf("failed to render all objects: %w", renderErr)
+	}
+
+	return nil
+}
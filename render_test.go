@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_runRender(t *testing.T) {
+	t.Run("should render manifests of directory", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		err := runRender(context.Background(), []string{"--namespace", "ecosystem", "controllers/testdata/render/dogus/manifests"}, &stdout, &stderr)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "kind: Ingress")
+		assert.Contains(t, stdout.String(), "name: ces-loadbalancer")
+		assert.Empty(t, stderr.String())
+	})
+	t.Run("should fail without manifest directory", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		err := runRender(context.Background(), nil, &stdout, &stderr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected exactly one manifest directory but got 0 arguments")
+		assert.Contains(t, stderr.String(), "Usage: k8s-service-discovery render")
+	})
+	t.Run("should fail on unknown flag", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		err := runRender(context.Background(), []string{"--unknown", "manifests"}, &stdout, &stderr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "flag provided but not defined")
+	})
+}